@@ -2,22 +2,50 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
+	"maps"
 	"os"
 	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/ChernykhITMO/Avito/db/migrations"
 	dbutils "github.com/ChernykhITMO/Avito/db/utils"
 
-	"github.com/ChernykhITMO/Avito/internal/httpserver"
-	"github.com/ChernykhITMO/Avito/internal/repository"
+	"github.com/ChernykhITMO/Avito/internal/app"
+	"github.com/ChernykhITMO/Avito/internal/directory"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/idempotency"
+	"github.com/ChernykhITMO/Avito/internal/scheduler"
 	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/tenant"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5/stdlib"
 )
 
-const maxAttempts = 30
+const (
+	maxAttempts = 30
+
+	defaultSLACheckPeriod = 5 * time.Minute
+
+	defaultArchivePeriod = time.Hour
+
+	defaultGRPCAddr = ":9090"
+
+	defaultDirectorySyncPeriod = time.Minute
+
+	defaultDBMaxConns       = 20
+	defaultDBMinConns       = 2
+	defaultDBConnLifetime   = time.Hour
+	defaultDBConnIdleTime   = 30 * time.Minute
+	defaultDBStatementCache = 512
+)
 
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -28,9 +56,38 @@ func main() {
 		log.Fatal("DB_DSN is not set")
 	}
 
-	db, err := dbutils.WaitForDB(ctx, dsn, maxAttempts)
-	if err != nil {
-		log.Fatal("failed to connect to database after retries: ", err)
+	poolCfg := dbutils.PoolConfig{
+		MaxConns:               int32(envInt("DB_MAX_CONNS", defaultDBMaxConns)),
+		MinConns:               int32(envInt("DB_MIN_CONNS", defaultDBMinConns)),
+		MaxConnLifetime:        envDuration("DB_MAX_CONN_LIFETIME", defaultDBConnLifetime),
+		MaxConnIdleTime:        envDuration("DB_MAX_CONN_IDLE_TIME", defaultDBConnIdleTime),
+		StatementCacheCapacity: envInt("DB_STATEMENT_CACHE_CAPACITY", defaultDBStatementCache),
+	}
+
+	var (
+		db    *sql.DB
+		repos app.Repos
+	)
+	switch driver := envString("DB_DRIVER", "pgx"); driver {
+	case "pgx":
+		pool, err := dbutils.WaitForPool(ctx, dsn, maxAttempts, poolCfg)
+		if err != nil {
+			log.Fatal("failed to connect to database after retries: ", err)
+		}
+		defer pool.Close()
+
+		// Migrations and health checks share the pool through database/sql.
+		db = stdlib.OpenDBFromPool(pool)
+		repos = app.PgxRepos(pool)
+	case "sql":
+		var err error
+		db, err = dbutils.WaitForDB(ctx, dsn, maxAttempts, poolCfg)
+		if err != nil {
+			log.Fatal("failed to connect to database after retries: ", err)
+		}
+		repos = app.SQLRepos(db)
+	default:
+		log.Fatalf("invalid DB_DRIVER: %q", driver)
 	}
 	defer func() {
 		if err := db.Close(); err != nil {
@@ -42,31 +99,218 @@ func main() {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 
+	cfg := app.DefaultConfig()
+	cfg.CacheTTL = envDuration("REPOSITORY_CACHE_TTL", 0)
+	cfg.ReviewSLA = envDuration("REVIEW_SLA", cfg.ReviewSLA)
+	cfg.IdempotencyTTL = envDuration("IDEMPOTENCY_TTL", cfg.IdempotencyTTL)
+	cfg.DrainDelay = envDuration("SHUTDOWN_DRAIN_DELAY", cfg.DrainDelay)
+
+	slaCfg := scheduler.Config{
+		SLA:      cfg.ReviewSLA,
+		Interval: envDuration("REVIEW_SLA_CHECK_INTERVAL", defaultSLACheckPeriod),
+		Action:   scheduler.Action(envString("REVIEW_SLA_ACTION", string(scheduler.ActionEscalate))),
+	}
+	if slaCfg.Action != scheduler.ActionReassign && slaCfg.Action != scheduler.ActionEscalate {
+		log.Fatalf("invalid REVIEW_SLA_ACTION: %q", slaCfg.Action)
+	}
+
+	switch store := envString("IDEMPOTENCY_STORE", "postgres"); store {
+	case "postgres":
+	case "memory":
+		cfg.IdempotencyStore = idempotency.NewMemoryStore(service.SystemClock())
+	default:
+		log.Fatalf("invalid IDEMPOTENCY_STORE: %q", store)
+	}
+
+	tenantCfg := app.DefaultTenantConfig()
+	// TENANT_DEFAULT set to an empty value makes every request name its
+	// tenant.
+	if v, ok := os.LookupEnv("TENANT_DEFAULT"); ok {
+		tenantCfg.Default = v
+	}
+	if v, ok := os.LookupEnv("TENANT_HEADER"); ok {
+		tenantCfg.Header = v
+	}
+	tenantCfg.Tokens = envTenantTokens("TENANT_TOKENS")
+	tenantCfg.Known = envList("TENANTS")
+	tenants, err := tenant.New(tenantCfg)
+	if err != nil {
+		log.Fatalf("invalid tenant configuration: %v", err)
+	}
+	cfg.Tenants = tenants
+
+	limits := cfg.Limits
+	limits.Rate = envFloat("RATE_LIMIT_RPS", limits.Rate)
+	limits.Burst = envInt("RATE_LIMIT_BURST", limits.Burst)
+	limits.MaxConcurrent = envInt("MAX_CONCURRENT_REQUESTS", limits.MaxConcurrent)
+	limits.RouteConcurrency = envRouteLimits("ROUTE_CONCURRENCY")
+	limits.MaxBodyBytes = int64(envInt("MAX_BODY_BYTES", int(limits.MaxBodyBytes)))
+	// Tokens of tenants identify their clients, as do the tokens of
+	// RATE_LIMIT_TOKENS that act for no particular tenant.
+	limits.ClientTokens = append(slices.Collect(maps.Keys(tenantCfg.Tokens)), envList("RATE_LIMIT_TOKENS")...)
+	limits.TrustProxy = os.Getenv("TRUST_PROXY") == "true"
+
+	a := app.New(repos, db, cfg)
+
+	httpSrv := a.HTTPServer(":8080")
+
+	grpcAddr := envString("GRPC_ADDR", defaultGRPCAddr)
+	grpcSrv := a.GRPCServer(grpcAddr)
+
+	// Both servers share ctx: a signal or a failure of either one shuts
+	// down the other.
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	serve := func(name string, run func(context.Context) error) {
+		defer wg.Done()
+		if err := run(ctx); err != nil {
+			errs <- fmt.Errorf("%s server: %w", name, err)
+			stop()
+		}
+	}
+
+	wg.Add(2)
+	log.Println("Starting HTTP server on :8080")
+	go serve("http", httpSrv.Run)
+	log.Printf("Starting gRPC server on %s", grpcAddr)
+	go serve("grpc", grpcSrv.Run)
+
+	// The servers are already up so that /livez answers and /readyz reports
+	// the pending migrations while they run.
 	log.Println("Running database migrations...")
 	if err := migrations.CreateTables(db); err != nil {
 		log.Fatal("Failed to run migrations: ", err)
 	}
+	if err := a.Ready(ctx); err != nil {
+		log.Fatal("Failed to start: ", err)
+	}
 	log.Println("Migrations completed successfully")
 
-	teamRepo := repository.NewTeamRepository(db)
-	userRepo := repository.NewUserRepository(db)
-	prRepo := repository.NewPRRepository(db)
-	statsRepo := repository.NewStatsRepository(db)
-
-	teamSvc := service.NewTeamService(teamRepo, userRepo)
-	userSvc := service.NewUserService(userRepo, prRepo)
-	prSvc := service.NewPullRequestService(prRepo, userRepo, teamRepo)
-	statsSvc := service.NewStatsService(statsRepo)
-
-	srv := httpserver.New(":8080", httpserver.Deps{
-		TeamService:        teamSvc,
-		UserService:        userSvc,
-		PullRequestService: prSvc,
-		StatsService:       statsSvc,
-	})
-
-	log.Println("Starting server on :8080")
-	if err := srv.Run(ctx); err != nil {
+	sla := scheduler.NewSLAScheduler(slaCfg, repos.Tenants, a.Reviews, a.PRs, scheduler.LogEscalator{}, service.SystemClock())
+	go sla.Run(ctx)
+
+	if after := envDuration("PR_ARCHIVE_AFTER", 0); after > 0 {
+		archiver := scheduler.NewPRArchiver(
+			scheduler.ArchiveConfig{After: after, Interval: envDuration("PR_ARCHIVE_INTERVAL", defaultArchivePeriod)},
+			repos.Tenants, a.PRs, service.SystemClock(),
+		)
+		log.Printf("Archiving pull requests merged more than %s ago", after)
+		go archiver.Run(ctx)
+	}
+
+	if path := os.Getenv("DIRECTORY_FILE"); path != "" {
+		orgSync := scheduler.NewOrgSync(
+			scheduler.OrgSyncConfig{Interval: envDuration("DIRECTORY_SYNC_INTERVAL", defaultDirectorySyncPeriod)},
+			directory.NewFile(path),
+			a.Teams, a.Users, a.PRs,
+			scheduler.LogSyncReporter{},
+			service.SystemClock(),
+		)
+		dirTenant := envString("DIRECTORY_TENANT", domain.DefaultTenant)
+		log.Printf("Syncing teams of tenant %s from %s", dirTenant, path)
+		go orgSync.Run(domain.WithTenant(ctx, dirTenant))
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
 		log.Fatal(err)
 	}
 }
+
+func envString(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+func envDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Fatalf("invalid %s: %q", name, v)
+	}
+	return d
+}
+
+// envInt reads a non-negative integer; zero turns the corresponding limit off.
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		log.Fatalf("invalid %s: %q", name, v)
+	}
+	return n
+}
+
+func envFloat(name string, def float64) float64 {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 {
+		log.Fatalf("invalid %s: %q", name, v)
+	}
+	return f
+}
+
+// envRouteLimits reads per-route limits written as
+// "/users/getReview=10,/stats=4".
+func envRouteLimits(name string) map[string]int {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+
+	limits := make(map[string]int)
+	for _, item := range strings.Split(v, ",") {
+		path, limit, ok := strings.Cut(strings.TrimSpace(item), "=")
+		n, err := strconv.Atoi(limit)
+		if !ok || !strings.HasPrefix(path, "/") || err != nil || n < 0 {
+			log.Fatalf("invalid %s: %q", name, v)
+		}
+		limits[path] = n
+	}
+	return limits
+}
+
+// envList reads a comma-separated list, skipping empty items.
+func envList(name string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(name), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// envTenantTokens reads bearer tokens mapped to tenants, written as
+// "token1:payments,token2:search".
+func envTenantTokens(name string) map[string]string {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+
+	tokens := make(map[string]string)
+	for _, item := range strings.Split(v, ",") {
+		token, id, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok || token == "" || id == "" {
+			log.Fatalf("invalid %s: entries must be token:tenant", name)
+		}
+		tokens[token] = id
+	}
+	return tokens
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/db/migrations"
+	dbutils "github.com/ChernykhITMO/Avito/db/utils"
+	"github.com/ChernykhITMO/Avito/internal/app"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// startInProcess serves the application with its default configuration on
+// a random local port over a new schema of dsn, so every run starts from the
+// same empty state. The returned function stops the server and drops the
+// schema.
+func startInProcess(ctx context.Context, dsn string) (string, func(), error) {
+	admin, err := dbutils.WaitForPool(ctx, dsn, 1, dbutils.PoolConfig{MaxConns: 2})
+	if err != nil {
+		return "", nil, err
+	}
+
+	schema := fmt.Sprintf("loadgen_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		return "", nil, fmt.Errorf("create schema: %w", err)
+	}
+	dropSchema := func() {
+		if _, err := admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			log.Printf("loadgen: drop schema %s: %v", schema, err)
+		}
+		admin.Close()
+	}
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		dropSchema()
+		return "", nil, fmt.Errorf("parse dsn: %w", err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		dropSchema()
+		return "", nil, fmt.Errorf("open pool: %w", err)
+	}
+	db := stdlib.OpenDBFromPool(pool)
+	closeDB := func() {
+		_ = db.Close()
+		pool.Close()
+		dropSchema()
+	}
+
+	if err := migrations.CreateTables(db); err != nil {
+		closeDB()
+		return "", nil, fmt.Errorf("migrate: %w", err)
+	}
+
+	appCfg := app.DefaultConfig()
+	// No load balancer waits for /readyz to fail.
+	appCfg.DrainDelay = 0
+	a := app.New(app.PgxRepos(pool), db, appCfg)
+	if err := a.Ready(ctx); err != nil {
+		closeDB()
+		return "", nil, err
+	}
+	srv := a.HTTPServer("")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		closeDB()
+		return "", nil, err
+	}
+
+	srvCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(srvCtx, ln) }()
+
+	stop := func() {
+		cancel()
+		if err := <-done; err != nil {
+			log.Printf("loadgen: in-process server: %v", err)
+		}
+		closeDB()
+	}
+	return "http://" + ln.Addr().String(), stop, nil
+}
@@ -0,0 +1,127 @@
+// Command loadgen seeds the reviewer service with teams and drives a mix of
+// API calls at a fixed rate, then reports latency percentiles and error rates
+// against the service SLIs. Run `loadgen -h` for the flags.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+const (
+	defaultAddr = "http://localhost:8080"
+	defaultMix  = "create=25,merge=10,reassign=10,getReview=40,stats=15"
+
+	// The SLIs of the assignment: 300 ms response time, 99.9% success.
+	defaultSLILatency = 300 * time.Millisecond
+	defaultSLISuccess = 99.9
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("loadgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", envString("LOADGEN_ADDR", defaultAddr), "service base URL (env LOADGEN_ADDR)")
+	inProcess := fs.Bool("in-process", false, "start the service in this process on a fresh schema of -dsn")
+	dsn := fs.String("dsn", os.Getenv("DB_DSN"), "Postgres DSN for -in-process (env DB_DSN)")
+	rps := fs.Float64("rps", 5, "target requests per second")
+	duration := fs.Duration("duration", time.Minute, "how long to drive load after seeding")
+	teams := fs.Int("teams", 20, "teams to seed")
+	users := fs.Int("users", 10, "users per team")
+	mixFlag := fs.String("mix", defaultMix, "weighted operations: create, merge, reassign, getReview, stats")
+	seed := fs.Uint64("seed", 1, "random seed; the same seed replays the same choices")
+	prefix := fs.String("prefix", "", "prefix of seeded ids (default derived from the start time)")
+	concurrency := fs.Int("concurrency", 64, "maximum requests in flight; ticks beyond it are dropped")
+	timeout := fs.Duration("timeout", 5*time.Second, "request timeout")
+	sliLatency := fs.Duration("sli-latency", defaultSLILatency, "p99 latency objective")
+	sliSuccess := fs.Float64("sli-success", defaultSLISuccess, "success rate objective, percent")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	mix, err := parseMix(*mixFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "loadgen: -mix: %v\n", err)
+		return 2
+	}
+	if *rps <= 0 || *duration <= 0 || *teams <= 0 || *users < 2 || *concurrency <= 0 {
+		fmt.Fprintln(stderr, "loadgen: -rps, -duration, -teams and -concurrency must be positive and -users at least 2")
+		return 2
+	}
+	if *prefix == "" {
+		*prefix = "lg" + strconv.FormatInt(time.Now().Unix(), 36)
+	}
+
+	if *inProcess {
+		if *dsn == "" {
+			fmt.Fprintln(stderr, "loadgen: -in-process needs -dsn or DB_DSN")
+			return 2
+		}
+		url, stopServer, err := startInProcess(ctx, *dsn)
+		if err != nil {
+			fmt.Fprintf(stderr, "loadgen: start in-process server: %v\n", err)
+			return 1
+		}
+		defer stopServer()
+		*addr = url
+	}
+
+	g := newGenerator(
+		client.New(*addr, client.WithHTTPClient(&http.Client{Timeout: *timeout})),
+		config{
+			RPS:         *rps,
+			Duration:    *duration,
+			Teams:       *teams,
+			Users:       *users,
+			Mix:         mix,
+			Prefix:      *prefix,
+			Concurrency: *concurrency,
+		},
+		rand.New(rand.NewPCG(*seed, *seed)),
+	)
+
+	fmt.Fprintf(stderr, "loadgen: seeding %d teams of %d users on %s\n", *teams, *users, *addr)
+	if err := g.seed(ctx); err != nil {
+		fmt.Fprintf(stderr, "loadgen: seed: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "loadgen: driving %.1f rps for %s\n", *rps, *duration)
+	rep := g.drive(ctx)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		fmt.Fprintln(stderr, "loadgen: interrupted, reporting partial results")
+	}
+
+	sli := objectives{Latency: *sliLatency, Success: *sliSuccess}
+	rep.write(stdout, sli)
+	if !rep.meets(sli) {
+		return 1
+	}
+	return 0
+}
+
+func envString(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+func TestParseMix(t *testing.T) {
+	m, err := parseMix("create=3, stats=1,merge=0")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(m) != 2 || m[0].op != opCreate || m[0].weight != 3 || m[1].op != opStats {
+		t.Fatalf("unexpected mix %+v", m)
+	}
+
+	for _, bad := range []string{"", "create", "create=x", "create=-1", "deploy=1", "create=1,create=2", "stats=0"} {
+		if _, err := parseMix(bad); err == nil {
+			t.Errorf("parseMix(%q) must fail", bad)
+		}
+	}
+}
+
+func TestMixPick_FollowsWeights(t *testing.T) {
+	m := mix{{opCreate, 3}, {opStats, 1}}
+	rng := rand.New(rand.NewPCG(1, 1))
+
+	counts := make(map[op]int)
+	for range 4000 {
+		counts[m.pick(rng)]++
+	}
+	if counts[opCreate] < 2800 || counts[opCreate] > 3200 {
+		t.Fatalf("unexpected distribution %v", counts)
+	}
+}
+
+func TestReport_PercentilesAndSuccess(t *testing.T) {
+	rep := newReport()
+	for i := 1; i <= 100; i++ {
+		rep.record(opStats, time.Duration(i)*time.Millisecond, nil)
+	}
+	rep.record(opCreate, time.Millisecond, &client.Error{StatusCode: http.StatusConflict})
+	rep.record(opCreate, time.Millisecond, errors.New("connection reset"))
+	rep.record(opMerge, 0, errSkipped)
+	rep.drop(opCreate)
+
+	stats := rep.ops[opStats]
+	if got := stats.percentile(50); got != 50*time.Millisecond {
+		t.Errorf("p50 = %s", got)
+	}
+	if got := stats.percentile(99); got != 99*time.Millisecond {
+		t.Errorf("p99 = %s", got)
+	}
+
+	total := rep.total()
+	// 102 answered plus one dropped; the transport error and the drop fail.
+	want := 100 * 101.0 / 103.0
+	if got := total.successRate(); got < want-0.001 || got > want+0.001 {
+		t.Errorf("success = %.3f, want %.3f", got, want)
+	}
+	if total.rejected != 1 || total.skipped != 1 {
+		t.Errorf("unexpected totals %+v", total)
+	}
+
+	if rep.meets(objectives{Latency: 300 * time.Millisecond, Success: 99.9}) {
+		t.Errorf("report must violate the success objective")
+	}
+	if !rep.meets(objectives{Latency: 300 * time.Millisecond, Success: 95}) {
+		t.Errorf("report must meet relaxed objectives")
+	}
+}
+
+// fakeService answers the endpoints loadgen calls, assigning the first two
+// seeded users of the author's team as reviewers.
+func fakeService(t *testing.T) (http.Handler, func() map[string]int) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		calls = make(map[string]int)
+	)
+	count := func(r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+	}
+	reply := func(w http.ResponseWriter, status int, body string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}
+	pr := `{"pr":{"pull_request_id":"x","pull_request_name":"x","author_id":"a","status":"OPEN","assigned_reviewers":["r1","r2"]}}`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /team/add", func(w http.ResponseWriter, r *http.Request) {
+		count(r)
+		reply(w, http.StatusCreated, `{"team":{"team_name":"t","members":[]}}`)
+	})
+	mux.HandleFunc("POST /pullRequest/create", func(w http.ResponseWriter, r *http.Request) {
+		count(r)
+		reply(w, http.StatusCreated, pr)
+	})
+	mux.HandleFunc("POST /pullRequest/merge", func(w http.ResponseWriter, r *http.Request) {
+		count(r)
+		reply(w, http.StatusOK, pr)
+	})
+	mux.HandleFunc("POST /pullRequest/reassign", func(w http.ResponseWriter, r *http.Request) {
+		count(r)
+		reply(w, http.StatusConflict, `{"error":{"code":"NO_CANDIDATE","message":"no active replacement candidate in team"}}`)
+	})
+	mux.HandleFunc("GET /users/getReview", func(w http.ResponseWriter, r *http.Request) {
+		count(r)
+		reply(w, http.StatusOK, `{"user_id":"u","pull_requests":[]}`)
+	})
+	mux.HandleFunc("GET /stats", func(w http.ResponseWriter, r *http.Request) {
+		count(r)
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+
+	return mux, func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+}
+
+func TestRun_DrivesMixAndReports(t *testing.T) {
+	h, calls := fakeService(t)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{
+		"-addr", srv.URL,
+		"-rps", "200",
+		"-duration", "500ms",
+		"-teams", "2",
+		"-users", "3",
+		"-mix", "create=4,merge=1,reassign=1,getReview=2,stats=1",
+	}, &stdout, &stderr)
+
+	// /stats always fails, so the success objective is violated.
+	if code != 1 {
+		t.Fatalf("exit code %d, stderr %q", code, stderr.String())
+	}
+
+	got := calls()
+	if got["/team/add"] != 2 {
+		t.Errorf("seeded %d teams, want 2", got["/team/add"])
+	}
+	for _, path := range []string{"/pullRequest/create", "/pullRequest/merge", "/users/getReview", "/stats"} {
+		if got[path] == 0 {
+			t.Errorf("%s was never called: %v", path, got)
+		}
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"create", "reassign", "total", "SLI success", "VIOLATED"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRun_RejectsBadFlags(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"-mix", "deploy=1"}, &stdout, &stderr); code != 2 {
+		t.Fatalf("exit code %d", code)
+	}
+	if code := run(context.Background(), []string{"-in-process", "-dsn", ""}, &stdout, &stderr); code != 2 {
+		t.Fatalf("exit code %d", code)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+// objectives are the SLIs a run is checked against: the p99 latency and the
+// share of requests the service answered without a server or transport
+// error.
+type objectives struct {
+	Latency time.Duration
+	Success float64
+}
+
+type opResults struct {
+	latencies []time.Duration
+	// rejected counts 4xx answers: the service worked, the request was
+	// refused by a business rule (for example NO_CANDIDATE).
+	rejected int
+	// failed counts 5xx answers and transport errors.
+	failed int
+	// dropped counts ticks skipped because too many requests were in
+	// flight; they are failures of the service to keep up.
+	dropped int
+	// skipped counts operations that had nothing to act on.
+	skipped int
+}
+
+type report struct {
+	mu      sync.Mutex
+	ops     map[op]*opResults
+	elapsed time.Duration
+}
+
+func newReport() *report {
+	return &report{ops: make(map[op]*opResults)}
+}
+
+func (r *report) results(o op) *opResults {
+	res, ok := r.ops[o]
+	if !ok {
+		res = &opResults{}
+		r.ops[o] = res
+	}
+	return res
+}
+
+func (r *report) record(o op, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := r.results(o)
+	if errors.Is(err, errSkipped) {
+		res.skipped++
+		return
+	}
+
+	res.latencies = append(res.latencies, d)
+	if err == nil {
+		return
+	}
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode < 500 {
+		res.rejected++
+		return
+	}
+	res.failed++
+}
+
+func (r *report) drop(o op) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results(o).dropped++
+}
+
+// total merges the results of every operation.
+func (r *report) total() *opResults {
+	var t opResults
+	for _, res := range r.ops {
+		t.latencies = append(t.latencies, res.latencies...)
+		t.rejected += res.rejected
+		t.failed += res.failed
+		t.dropped += res.dropped
+		t.skipped += res.skipped
+	}
+	return &t
+}
+
+// successRate is the percentage of attempted requests that did not fail.
+func (res *opResults) successRate() float64 {
+	attempted := len(res.latencies) + res.dropped
+	if attempted == 0 {
+		return 100
+	}
+	return 100 * float64(attempted-res.failed-res.dropped) / float64(attempted)
+}
+
+// percentile returns the nearest-rank percentile of the latencies.
+func (res *opResults) percentile(p float64) time.Duration {
+	if len(res.latencies) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(res.latencies)
+	slices.Sort(sorted)
+
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+func (r *report) meets(sli objectives) bool {
+	t := r.total()
+	return t.percentile(99) <= sli.Latency && t.successRate() >= sli.Success
+}
+
+func (r *report) write(w io.Writer, sli objectives) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "OPERATION\tREQUESTS\tREJECTED\tFAILED\tDROPPED\tSKIPPED\tP50\tP90\tP99\tMAX")
+
+	row := func(name string, res *opResults) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			name, len(res.latencies), res.rejected, res.failed, res.dropped, res.skipped,
+			round(res.percentile(50)), round(res.percentile(90)), round(res.percentile(99)), round(res.percentile(100)))
+	}
+	for _, o := range knownOps {
+		if res, ok := r.ops[o]; ok {
+			row(string(o), res)
+		}
+	}
+	t := r.total()
+	row("total", t)
+	_ = tw.Flush()
+
+	rps := 0.0
+	if r.elapsed > 0 {
+		rps = float64(len(t.latencies)) / r.elapsed.Seconds()
+	}
+	fmt.Fprintf(w, "\nachieved %.2f rps over %s\n", rps, r.elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "SLI latency: p99 %s (objective %s) %s\n", round(t.percentile(99)), sli.Latency, verdict(t.percentile(99) <= sli.Latency))
+	fmt.Fprintf(w, "SLI success: %.3f%% (objective %.3f%%) %s\n", t.successRate(), sli.Success, verdict(t.successRate() >= sli.Success))
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(100 * time.Microsecond)
+}
+
+func verdict(ok bool) string {
+	if ok {
+		return "OK"
+	}
+	return "VIOLATED"
+}
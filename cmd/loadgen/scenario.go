@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+type op string
+
+const (
+	opCreate    op = "create"
+	opMerge     op = "merge"
+	opReassign  op = "reassign"
+	opGetReview op = "getReview"
+	opStats     op = "stats"
+)
+
+var knownOps = []op{opCreate, opMerge, opReassign, opGetReview, opStats}
+
+type weightedOp struct {
+	op     op
+	weight int
+}
+
+// mix is a weighted choice of operations.
+type mix []weightedOp
+
+// parseMix reads a mix written as "create=25,merge=10,...".
+func parseMix(s string) (mix, error) {
+	var m mix
+	seen := make(map[op]bool)
+	for _, item := range strings.Split(s, ",") {
+		name, weight, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not op=weight", item)
+		}
+		o := op(name)
+		if !isKnownOp(o) {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+		if seen[o] {
+			return nil, fmt.Errorf("operation %q listed twice", name)
+		}
+		w, err := strconv.Atoi(weight)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("invalid weight of %s: %q", name, weight)
+		}
+		seen[o] = true
+		if w > 0 {
+			m = append(m, weightedOp{op: o, weight: w})
+		}
+	}
+	if len(m) == 0 {
+		return nil, errors.New("all weights are zero")
+	}
+	return m, nil
+}
+
+func isKnownOp(o op) bool {
+	for _, k := range knownOps {
+		if k == o {
+			return true
+		}
+	}
+	return false
+}
+
+func (m mix) pick(rng *rand.Rand) op {
+	total := 0
+	for _, w := range m {
+		total += w.weight
+	}
+	n := rng.IntN(total)
+	for _, w := range m {
+		if n < w.weight {
+			return w.op
+		}
+		n -= w.weight
+	}
+	return m[len(m)-1].op
+}
+
+type config struct {
+	RPS         float64
+	Duration    time.Duration
+	Teams       int
+	Users       int
+	Mix         mix
+	Prefix      string
+	Concurrency int
+}
+
+// openPR is a pull request the generator may still merge or reassign.
+type openPR struct {
+	id        string
+	reviewers []string
+}
+
+// generator keeps what it created so that later operations target existing
+// entities. Choices come from one seeded source, so a run with the same seed
+// issues the same sequence of operations.
+type generator struct {
+	api *client.Client
+	cfg config
+
+	mu     sync.Mutex
+	rng    *rand.Rand
+	users  []string
+	open   []openPR
+	nextPR int
+}
+
+func newGenerator(api *client.Client, cfg config, rng *rand.Rand) *generator {
+	return &generator{api: api, cfg: cfg, rng: rng}
+}
+
+// seed creates the teams; every tenth user is inactive.
+func (g *generator) seed(ctx context.Context) error {
+	for t := range g.cfg.Teams {
+		team := client.Team{TeamName: fmt.Sprintf("%s-team-%d", g.cfg.Prefix, t)}
+		for u := range g.cfg.Users {
+			id := fmt.Sprintf("%s-u%d-%d", g.cfg.Prefix, t, u)
+			active := u%10 != 9
+			team.Members = append(team.Members, client.TeamMember{UserId: id, Username: "User " + id, IsActive: active})
+			if active {
+				g.users = append(g.users, id)
+			}
+		}
+		if _, err := g.api.AddTeam(ctx, team); err != nil {
+			return fmt.Errorf("add team %s: %w", team.TeamName, err)
+		}
+	}
+	return nil
+}
+
+// drive issues operations at the configured rate until the duration passes
+// or ctx is cancelled, then waits for the requests in flight.
+func (g *generator) drive(ctx context.Context) *report {
+	ctx, cancel := context.WithTimeout(ctx, g.cfg.Duration)
+	defer cancel()
+
+	rep := newReport()
+	sem := make(chan struct{}, g.cfg.Concurrency)
+	var wg sync.WaitGroup
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / g.cfg.RPS))
+	defer ticker.Stop()
+
+	start := time.Now()
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+		}
+
+		g.mu.Lock()
+		o := g.cfg.Mix.pick(g.rng)
+		g.mu.Unlock()
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			rep.drop(o)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// In-flight requests may finish after the deadline.
+			reqCtx := context.WithoutCancel(ctx)
+			began := time.Now()
+			err := g.do(reqCtx, o)
+			rep.record(o, time.Since(began), err)
+		}()
+	}
+	wg.Wait()
+	rep.elapsed = time.Since(start)
+
+	return rep
+}
+
+// errSkipped marks an operation with nothing to act on, such as a merge
+// before any pull request is open. It is reported apart from failures.
+var errSkipped = errors.New("skipped")
+
+func (g *generator) do(ctx context.Context, o op) error {
+	switch o {
+	case opCreate:
+		return g.create(ctx)
+	case opMerge:
+		return g.merge(ctx)
+	case opReassign:
+		return g.reassign(ctx)
+	case opGetReview:
+		_, err := g.api.GetUserReviews(ctx, g.randomUser())
+		return err
+	case opStats:
+		_, err := g.api.Stats(ctx, client.GetStatsParams{})
+		return err
+	}
+	return fmt.Errorf("unknown operation %q", o)
+}
+
+func (g *generator) create(ctx context.Context) error {
+	g.mu.Lock()
+	g.nextPR++
+	id := fmt.Sprintf("%s-pr-%d", g.cfg.Prefix, g.nextPR)
+	author := g.users[g.rng.IntN(len(g.users))]
+	g.mu.Unlock()
+
+	pr, err := g.api.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   id,
+		PullRequestName: "Load " + id,
+		AuthorId:        author,
+	})
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	g.open = append(g.open, openPR{id: pr.PullRequestId, reviewers: pr.AssignedReviewers})
+	g.mu.Unlock()
+	return nil
+}
+
+func (g *generator) merge(ctx context.Context) error {
+	pr, ok := g.takeOpen()
+	if !ok {
+		return errSkipped
+	}
+	_, err := g.api.Merge(ctx, pr.id)
+	return err
+}
+
+func (g *generator) reassign(ctx context.Context) error {
+	g.mu.Lock()
+	var candidates []int
+	for i, pr := range g.open {
+		if len(pr.reviewers) > 0 {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		g.mu.Unlock()
+		return errSkipped
+	}
+	pr := g.open[candidates[g.rng.IntN(len(candidates))]]
+	old := pr.reviewers[g.rng.IntN(len(pr.reviewers))]
+	g.mu.Unlock()
+
+	resp, err := g.api.Reassign(ctx, pr.id, old)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	for i := range g.open {
+		if g.open[i].id == pr.id {
+			g.open[i].reviewers = resp.Pr.AssignedReviewers
+		}
+	}
+	g.mu.Unlock()
+	return nil
+}
+
+// takeOpen removes a random open pull request from the pool.
+func (g *generator) takeOpen() (openPR, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.open) == 0 {
+		return openPR{}, false
+	}
+	i := g.rng.IntN(len(g.open))
+	pr := g.open[i]
+	g.open[i] = g.open[len(g.open)-1]
+	g.open = g.open[:len(g.open)-1]
+	return pr, true
+}
+
+func (g *generator) randomUser() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.users[g.rng.IntN(len(g.users))]
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+type cli struct {
+	api   *client.Client
+	out   *printer
+	stdin io.Reader
+	errw  io.Writer
+}
+
+func (c *cli) dispatch(ctx context.Context, cmd string, args []string) error {
+	switch cmd {
+	case "team":
+		return c.team(ctx, args)
+	case "user":
+		return c.user(ctx, args)
+	case "pr":
+		return c.pullRequest(ctx, args)
+	case "reviews":
+		return c.reviews(ctx, args)
+	case "stats":
+		return c.stats(ctx, args)
+	default:
+		return c.usageErr("unknown command %q", cmd)
+	}
+}
+
+func (c *cli) usageErr(format string, args ...any) error {
+	fmt.Fprintf(c.errw, "prctl: "+format+"\n", args...)
+	return errUsage
+}
+
+func (c *cli) team(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return c.usageErr("team: expected add, get, delete or import")
+	}
+
+	switch sub, args := args[0], args[1:]; sub {
+	case "add":
+		if len(args) < 1 {
+			return c.usageErr("team add <team_name> <user_id>:<username>[:inactive] ...")
+		}
+		team := client.Team{TeamName: args[0], Members: []client.TeamMember{}}
+		for _, spec := range args[1:] {
+			m, err := parseMember(spec)
+			if err != nil {
+				return c.usageErr("team add: %v", err)
+			}
+			team.Members = append(team.Members, m)
+		}
+		created, err := c.api.AddTeam(ctx, team)
+		if err != nil {
+			return err
+		}
+		return c.out.team(created)
+
+	case "get", "delete":
+		if len(args) != 1 {
+			return c.usageErr("team %s <team_name>", sub)
+		}
+		call := c.api.GetTeam
+		if sub == "delete" {
+			call = c.api.DeleteTeam
+		}
+		team, err := call(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return c.out.team(team)
+
+	case "import":
+		if len(args) > 1 {
+			return c.usageErr("team import [file|-]")
+		}
+		path := "-"
+		if len(args) == 1 {
+			path = args[0]
+		}
+		return c.importTeams(ctx, path)
+
+	default:
+		return c.usageErr("team: unknown subcommand %q", sub)
+	}
+}
+
+// parseMember parses "<user_id>:<username>[:inactive]".
+func parseMember(spec string) (client.TeamMember, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return client.TeamMember{}, fmt.Errorf("invalid member %q, want <user_id>:<username>[:inactive]", spec)
+	}
+
+	m := client.TeamMember{UserId: parts[0], Username: parts[1], IsActive: true}
+	if len(parts) == 3 {
+		if parts[2] != "inactive" {
+			return client.TeamMember{}, fmt.Errorf("invalid member flag %q in %q", parts[2], spec)
+		}
+		m.IsActive = false
+	}
+	return m, nil
+}
+
+// importTeams creates every team from a JSON file holding either a single
+// team object or an array of them. It keeps going after a failure and
+// reports each team separately.
+func (c *cli) importTeams(ctx context.Context, path string) error {
+	var r io.Reader = c.stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("team import: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	teams, err := decodeTeams(r)
+	if err != nil {
+		return fmt.Errorf("team import: %w", err)
+	}
+
+	results := make([]importResult, 0, len(teams))
+	failed := 0
+	for _, team := range teams {
+		res := importResult{TeamName: team.TeamName, Members: len(team.Members), Status: "created"}
+		if _, err := c.api.AddTeam(ctx, team); err != nil {
+			res.Status, res.Error = "failed", err.Error()
+			failed++
+		}
+		results = append(results, res)
+	}
+
+	if err := c.out.importResults(results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("team import: %d of %d teams failed", failed, len(teams))
+	}
+	return nil
+}
+
+func decodeTeams(r io.Reader) ([]client.Team, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		var teams []client.Team
+		if err := json.Unmarshal(data, &teams); err != nil {
+			return nil, fmt.Errorf("decode teams: %w", err)
+		}
+		return teams, nil
+	}
+
+	var team client.Team
+	if err := json.Unmarshal(data, &team); err != nil {
+		return nil, fmt.Errorf("decode team: %w", err)
+	}
+	return []client.Team{team}, nil
+}
+
+func (c *cli) user(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return c.usageErr("user activate|deactivate|delete|purge <user_id>")
+	}
+
+	var call func(ctx context.Context, userID string) (*client.User, error)
+	switch args[0] {
+	case "activate", "deactivate":
+		active := args[0] == "activate"
+		call = func(ctx context.Context, userID string) (*client.User, error) {
+			return c.api.SetIsActive(ctx, userID, active)
+		}
+	case "delete":
+		call = c.api.DeleteUser
+	case "purge":
+		call = c.api.PurgeUser
+	default:
+		return c.usageErr("user activate|deactivate|delete|purge <user_id>")
+	}
+
+	user, err := call(ctx, args[1])
+	if err != nil {
+		return err
+	}
+	return c.out.user(user)
+}
+
+func (c *cli) pullRequest(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return c.usageErr("pr: expected create, merge, reassign or get")
+	}
+
+	switch sub, args := args[0], args[1:]; sub {
+	case "create":
+		fs := flag.NewFlagSet("pr create", flag.ContinueOnError)
+		fs.SetOutput(c.errw)
+		draft := fs.Bool("draft", false, "create the pull request as DRAFT without reviewers")
+		if err := fs.Parse(args); err != nil {
+			return errUsage
+		}
+		if fs.NArg() != 3 {
+			return c.usageErr("pr create [-draft] <pull_request_id> <pull_request_name> <author_id>")
+		}
+		pr, err := c.api.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+			PullRequestId:   fs.Arg(0),
+			PullRequestName: fs.Arg(1),
+			AuthorId:        fs.Arg(2),
+			Draft:           draft,
+		})
+		if err != nil {
+			return err
+		}
+		return c.out.pullRequest(pr)
+
+	case "merge":
+		if len(args) != 1 {
+			return c.usageErr("pr merge <pull_request_id>")
+		}
+		pr, err := c.api.Merge(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return c.out.pullRequest(pr)
+
+	case "get":
+		fs := flag.NewFlagSet("pr get", flag.ContinueOnError)
+		fs.SetOutput(c.errw)
+		archived := fs.Bool("archived", false, "also look among archived pull requests")
+		if err := fs.Parse(args); err != nil {
+			return errUsage
+		}
+		if fs.NArg() != 1 {
+			return c.usageErr("pr get [-archived] <pull_request_id>")
+		}
+		call := c.api.GetPullRequest
+		if *archived {
+			call = c.api.GetPullRequestIncludingArchived
+		}
+		pr, err := call(ctx, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		return c.out.pullRequest(pr)
+
+	case "reassign":
+		if len(args) != 2 {
+			return c.usageErr("pr reassign <pull_request_id> <old_user_id>")
+		}
+		resp, err := c.api.Reassign(ctx, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return c.out.reassign(resp)
+
+	default:
+		return c.usageErr("pr: unknown subcommand %q", sub)
+	}
+}
+
+func (c *cli) reviews(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("reviews", flag.ContinueOnError)
+	fs.SetOutput(c.errw)
+	archived := fs.Bool("archived", false, "include archived pull requests")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() != 1 {
+		return c.usageErr("reviews [-archived] <user_id>")
+	}
+
+	call := c.api.GetUserReviews
+	if *archived {
+		call = c.api.GetUserReviewsIncludingArchived
+	}
+	prs, err := call(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return c.out.reviews(fs.Arg(0), prs)
+}
+
+func (c *cli) stats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(c.errw)
+	team := fs.String("team", "", "restrict to one team")
+	from := fs.String("from", "", "period start (RFC 3339 or YYYY-MM-DD)")
+	to := fs.String("to", "", "period end, exclusive (RFC 3339 or YYYY-MM-DD)")
+	granularity := fs.String("granularity", "", "bucket size: day or week")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() != 0 {
+		return c.usageErr("stats: unexpected arguments %q", fs.Args())
+	}
+
+	params := client.GetStatsParams{TeamName: team, From: from, To: to}
+	if *granularity != "" {
+		g := client.GetStatsParamsGranularity(*granularity)
+		params.Granularity = &g
+	}
+
+	stats, err := c.api.Stats(ctx, params)
+	if err != nil {
+		var apiErr *client.Error
+		if errors.As(err, &apiErr) && apiErr.Code == client.BADREQUEST {
+			return fmt.Errorf("stats: %s", apiErr.Message)
+		}
+		return err
+	}
+	return c.out.stats(stats)
+}
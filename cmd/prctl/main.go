@@ -0,0 +1,106 @@
+// Command prctl is an admin CLI for the reviewer service. It talks to the
+// HTTP API through pkg/client; run `prctl -h` for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+const (
+	defaultAddr    = "http://localhost:8080"
+	defaultTimeout = 10 * time.Second
+)
+
+// errUsage makes run exit with status 2 after the message is printed.
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("prctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", envString("PRCTL_ADDR", defaultAddr), "service base URL (env PRCTL_ADDR)")
+	format := fs.String("o", "table", "output format: table or json")
+	timeout := fs.Duration("timeout", defaultTimeout, "request timeout")
+	tenant := fs.String("tenant", os.Getenv("PRCTL_TENANT"), "tenant to act for (env PRCTL_TENANT)")
+	token := fs.String("token", os.Getenv("PRCTL_TOKEN"), "bearer token (env PRCTL_TOKEN)")
+	fs.Usage = func() { usage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(stderr, "prctl: unknown output format %q\n", *format)
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cli := &cli{
+		api: client.New(*addr,
+			client.WithHTTPClient(&http.Client{Timeout: *timeout}),
+			client.WithTenant(*tenant),
+			client.WithToken(*token),
+		),
+		out:   newPrinter(stdout, *format),
+		stdin: stdin,
+		errw:  stderr,
+	}
+
+	err := cli.dispatch(ctx, fs.Arg(0), fs.Args()[1:])
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		fmt.Fprintf(stderr, "prctl: %v\n", err)
+		return 1
+	}
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprint(w, `Usage: prctl [flags] <command> [args]
+
+Commands:
+  team add <team_name> <user_id>:<username>[:inactive] ...
+  team get|delete <team_name>
+  team import [file|-]
+  user activate|deactivate|delete|purge <user_id>
+  pr create [-draft] <pull_request_id> <pull_request_name> <author_id>
+  pr merge <pull_request_id>
+  pr get [-archived] <pull_request_id>
+  pr reassign <pull_request_id> <old_user_id>
+  reviews [-archived] <user_id>
+  stats [-team NAME] [-from DATE] [-to DATE] [-granularity day|week]
+
+Flags:
+`)
+	fs.PrintDefaults()
+}
+
+func envString(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func runCLI(t *testing.T, h http.Handler, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append([]string{"-addr", srv.URL}, args...), strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestTeamImport_ReportsEachTeam(t *testing.T) {
+	var created []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /team/add", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			TeamName string `json:"team_name"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if body.TeamName == "backend" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":{"code":"TEAM_EXISTS","message":"team_name already exists"}}`)
+			return
+		}
+		created = append(created, body.TeamName)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"team":{"team_name":"`+body.TeamName+`","members":[]}}`)
+	})
+
+	input := `[
+		{"team_name": "backend", "members": [{"user_id": "u1", "username": "Alice", "is_active": true}]},
+		{"team_name": "payments", "members": []}
+	]`
+	code, stdout, stderr := runCLI(t, mux, input, "team", "import", "-")
+
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d (stderr %q)", code, stderr)
+	}
+	if len(created) != 1 || created[0] != "payments" {
+		t.Fatalf("import must continue after a failed team, created %v", created)
+	}
+	if !strings.Contains(stdout, "TEAM_EXISTS") || !strings.Contains(stdout, "created") {
+		t.Fatalf("unexpected table:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "1 of 2 teams failed") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+}
+
+func TestPRGet_JSONOutput(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pullRequest/get", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"pr":{"pull_request_id":"`+r.URL.Query().Get("pull_request_id")+`","pull_request_name":"Add search","author_id":"u1","status":"OPEN","assigned_reviewers":["u2"]}}`)
+	})
+
+	code, stdout, stderr := runCLI(t, mux, "", "-o", "json", "pr", "get", "pr1")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr)
+	}
+
+	var pr struct {
+		PullRequestID string   `json:"pull_request_id"`
+		Reviewers     []string `json:"assigned_reviewers"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &pr); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+	if pr.PullRequestID != "pr1" || len(pr.Reviewers) != 1 {
+		t.Fatalf("unexpected output %+v", pr)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"unknown"},
+		{"team", "add"},
+		{"user", "suspend", "u1"},
+		{"pr", "create", "pr1"},
+		{"-o", "yaml", "reviews", "u1"},
+	} {
+		code, _, _ := runCLI(t, http.NotFoundHandler(), "", args...)
+		if code != 2 {
+			t.Errorf("args %q: expected exit code 2, got %d", args, code)
+		}
+	}
+}
+
+func TestUserPurge(t *testing.T) {
+	var purged string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/users/purge", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			UserID string `json:"user_id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		purged = body.UserID
+		_, _ = io.WriteString(w, `{"user":{"user_id":"`+body.UserID+`","username":"deleted user","team_name":"backend","is_active":false,"deletedAt":"2025-10-24T12:00:00Z"}}`)
+	})
+
+	code, stdout, stderr := runCLI(t, mux, "", "user", "purge", "u2")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr %q)", code, stderr)
+	}
+	if purged != "u2" || !strings.Contains(stdout, "deleted user") {
+		t.Fatalf("unexpected purge of %q:\n%s", purged, stdout)
+	}
+
+	if code, _, _ := runCLI(t, mux, "", "user", "erase", "u2"); code != 2 {
+		t.Fatalf("expected usage error for an unknown subcommand, got %d", code)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+// printer renders command results either as aligned tables or as the JSON
+// documents returned by the API.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+type importResult struct {
+	TeamName string `json:"team_name"`
+	Members  int    `json:"members"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newPrinter(w io.Writer, format string) *printer {
+	return &printer{w: w, json: format == "json"}
+}
+
+// print writes v as JSON in json mode and calls table otherwise.
+func (p *printer) print(v any, table func(tw *tabwriter.Writer)) error {
+	if p.json {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func (p *printer) team(t *client.Team) error {
+	return p.print(t, func(tw *tabwriter.Writer) {
+		fmt.Fprintf(tw, "TEAM\t%s\n\n", t.TeamName)
+		fmt.Fprintln(tw, "USER_ID\tUSERNAME\tACTIVE")
+		for _, m := range t.Members {
+			fmt.Fprintf(tw, "%s\t%s\t%t\n", m.UserId, m.Username, m.IsActive)
+		}
+	})
+}
+
+func (p *printer) importResults(results []importResult) error {
+	return p.print(results, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "TEAM\tMEMBERS\tSTATUS\tERROR")
+		for _, r := range results {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", r.TeamName, r.Members, r.Status, r.Error)
+		}
+	})
+}
+
+func (p *printer) user(u *client.User) error {
+	return p.print(u, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "USER_ID\tUSERNAME\tTEAM\tACTIVE")
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", u.UserId, u.Username, u.TeamName, u.IsActive)
+	})
+}
+
+func (p *printer) pullRequest(pr *client.PullRequest) error {
+	return p.print(pr, func(tw *tabwriter.Writer) {
+		fmt.Fprintf(tw, "ID\t%s\n", pr.PullRequestId)
+		fmt.Fprintf(tw, "NAME\t%s\n", pr.PullRequestName)
+		fmt.Fprintf(tw, "AUTHOR\t%s\n", pr.AuthorId)
+		fmt.Fprintf(tw, "STATUS\t%s\n", pr.Status)
+		fmt.Fprintf(tw, "REVIEWERS\t%s\n", strings.Join(pr.AssignedReviewers, ", "))
+		fmt.Fprintf(tw, "CREATED\t%s\n", formatTime(pr.CreatedAt))
+		fmt.Fprintf(tw, "MERGED\t%s\n", formatTime(pr.MergedAt))
+		if pr.ArchivedAt != nil {
+			fmt.Fprintf(tw, "ARCHIVED\t%s\n", formatTime(pr.ArchivedAt))
+		}
+	})
+}
+
+func (p *printer) reassign(resp *client.ReassignResponse) error {
+	if p.json {
+		return p.print(resp, nil)
+	}
+	if err := p.pullRequest(&resp.Pr); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(p.w, "REPLACED_BY  %s\n", resp.ReplacedBy)
+	return err
+}
+
+func (p *printer) reviews(userID string, prs []client.PullRequestShort) error {
+	resp := client.UserReviewsResponse{UserId: userID, PullRequests: prs}
+	return p.print(resp, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "PULL_REQUEST_ID\tNAME\tAUTHOR\tSTATUS\tARCHIVED")
+		for _, pr := range prs {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status, formatTime(pr.ArchivedAt))
+		}
+	})
+}
+
+func (p *printer) stats(s *client.StatsResponse) error {
+	return p.print(s, func(tw *tabwriter.Writer) {
+		fmt.Fprintf(tw, "PERIOD\t%s — %s (%s)\n\n", s.From.Format(time.RFC3339), s.To.Format(time.RFC3339), s.Granularity)
+
+		fmt.Fprintln(tw, "TOTAL\tOPEN\tDRAFT\tMERGED\tCLOSED")
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\n\n", s.PrStats.Total, s.PrStats.Open, s.PrStats.Draft, s.PrStats.Merged, s.PrStats.Closed)
+
+		fmt.Fprintln(tw, "USER_ID\tASSIGNMENTS")
+		for _, a := range s.AssignmentsPerUser {
+			fmt.Fprintf(tw, "%s\t%d\n", a.UserId, a.Count)
+		}
+
+		fmt.Fprintln(tw, "\nTEAM\tOPEN_LOAD\tMEDIAN_TIME_TO_MERGE")
+		for _, t := range append([]client.TeamStats{s.Global}, s.Teams...) {
+			name := "(all)"
+			if t.TeamName != nil {
+				name = *t.TeamName
+			}
+			open := 0
+			for _, l := range t.OpenLoad {
+				open += l.Open
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", name, open, formatSeconds(t.MedianTimeToMergeSeconds))
+		}
+	})
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatSeconds(s *float64) string {
+	if s == nil {
+		return "-"
+	}
+	return (time.Duration(*s) * time.Second).String()
+}
@@ -29,6 +29,248 @@ func CreateTables(db *sql.DB) error {
             reviewer_id TEXT REFERENCES users(id),
             PRIMARY KEY (pull_request_id, reviewer_id)
         )`,
+		`ALTER TABLE pull_request_reviewers
+            ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP NOT NULL DEFAULT NOW()`,
+		`CREATE TABLE IF NOT EXISTS reviewer_assignments (
+            id BIGSERIAL PRIMARY KEY,
+            pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+            reviewer_id TEXT NOT NULL REFERENCES users(id),
+            assigned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+            unassigned_at TIMESTAMP
+        )`,
+		beforeTenants(`INSERT INTO reviewer_assignments (pull_request_id, reviewer_id, assigned_at)
+            SELECT pull_request_id, reviewer_id, assigned_at
+            FROM pull_request_reviewers
+            WHERE NOT EXISTS (SELECT 1 FROM reviewer_assignments)`),
+		`CREATE TABLE IF NOT EXISTS user_activity_events (
+            id BIGSERIAL PRIMARY KEY,
+            user_id TEXT NOT NULL REFERENCES users(id),
+            is_active BOOLEAN NOT NULL,
+            changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+        )`,
+		beforeTenants(`INSERT INTO user_activity_events (user_id, is_active)
+            SELECT u.id, u.is_active
+            FROM users AS u
+            WHERE NOT EXISTS (SELECT 1 FROM user_activity_events AS e WHERE e.user_id = u.id)`),
+		`CREATE TABLE IF NOT EXISTS idempotency_keys (
+            key TEXT PRIMARY KEY,
+            fingerprint TEXT NOT NULL,
+            status_code INTEGER,
+            content_type TEXT NOT NULL DEFAULT '',
+            body BYTEA,
+            expires_at TIMESTAMP NOT NULL
+        )`,
+		`CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at)`,
+
+		// Referential integrity: every team a user points at exists, and a
+		// user without a team has NULL rather than an empty name.
+		`UPDATE users SET team_name = NULL WHERE team_name = ''`,
+		beforeTenants(`INSERT INTO teams (name)
+            SELECT DISTINCT team_name FROM users WHERE team_name IS NOT NULL
+            ON CONFLICT (name) DO NOTHING`),
+		`DO $$
+        BEGIN
+            IF NOT EXISTS (
+                SELECT 1 FROM pg_constraint
+                WHERE conrelid = 'users'::regclass AND conname = 'users_team_name_fkey'
+            ) THEN
+                ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+                    FOREIGN KEY (team_name) REFERENCES teams(name) ON UPDATE CASCADE;
+            END IF;
+        END $$`,
+		`DO $$
+        BEGIN
+            IF NOT EXISTS (
+                SELECT 1 FROM pg_constraint
+                WHERE conrelid = 'pull_requests'::regclass AND conname = 'pull_requests_status_check'
+            ) THEN
+                ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_status_check
+                    CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
+            END IF;
+        END $$`,
+		// Current reviewers go away with their pull request or user.
+		`DO $$
+        BEGIN
+            IF EXISTS (
+                SELECT 1 FROM pg_constraint
+                WHERE conrelid = 'pull_request_reviewers'::regclass
+                AND conname = 'pull_request_reviewers_pull_request_id_fkey' AND confdeltype <> 'c'
+            ) THEN
+                ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pull_request_id_fkey;
+            END IF;
+            IF NOT EXISTS (
+                SELECT 1 FROM pg_constraint
+                WHERE conrelid = 'pull_request_reviewers'::regclass
+                AND conname = 'pull_request_reviewers_pull_request_id_fkey'
+            ) THEN
+                ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_pull_request_id_fkey
+                    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE;
+            END IF;
+
+            IF EXISTS (
+                SELECT 1 FROM pg_constraint
+                WHERE conrelid = 'pull_request_reviewers'::regclass
+                AND conname = 'pull_request_reviewers_reviewer_id_fkey' AND confdeltype <> 'c'
+            ) THEN
+                ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_reviewer_id_fkey;
+            END IF;
+            IF NOT EXISTS (
+                SELECT 1 FROM pg_constraint
+                WHERE conrelid = 'pull_request_reviewers'::regclass
+                AND conname = 'pull_request_reviewers_reviewer_id_fkey'
+            ) THEN
+                ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_reviewer_id_fkey
+                    FOREIGN KEY (reviewer_id) REFERENCES users(id) ON DELETE CASCADE;
+            END IF;
+        END $$`,
+
+		// Stored timestamps were written in UTC; keep the instants when
+		// switching the columns to timestamptz.
+		`DO $$
+        DECLARE
+            c record;
+        BEGIN
+            FOR c IN
+                SELECT table_name, column_name FROM information_schema.columns
+                WHERE table_schema = current_schema()
+                AND data_type = 'timestamp without time zone'
+                AND table_name IN ('pull_requests', 'pull_request_reviewers', 'reviewer_assignments',
+                                   'user_activity_events', 'idempotency_keys')
+            LOOP
+                EXECUTE format('ALTER TABLE %I ALTER COLUMN %I TYPE TIMESTAMPTZ USING %I AT TIME ZONE ''UTC''',
+                    c.table_name, c.column_name, c.column_name);
+            END LOOP;
+        END $$`,
+
+		`CREATE INDEX IF NOT EXISTS pull_request_reviewers_reviewer_id_idx
+            ON pull_request_reviewers (reviewer_id)`,
+		`CREATE INDEX IF NOT EXISTS users_team_name_is_active_idx ON users (team_name, is_active)`,
+		`CREATE INDEX IF NOT EXISTS pull_requests_author_id_idx ON pull_requests (author_id)`,
+		`CREATE INDEX IF NOT EXISTS reviewer_assignments_open_idx
+            ON reviewer_assignments (pull_request_id) WHERE unassigned_at IS NULL`,
+		`CREATE INDEX IF NOT EXISTS reviewer_assignments_reviewer_id_idx ON reviewer_assignments (reviewer_id)`,
+		`CREATE INDEX IF NOT EXISTS user_activity_events_user_id_idx
+            ON user_activity_events (user_id, changed_at DESC, id DESC)`,
+
+		// Tenants: every row belongs to one, ids are unique within it and
+		// references never cross it. Existing data moves to the default
+		// tenant.
+		`CREATE TABLE IF NOT EXISTS tenants (
+            id TEXT PRIMARY KEY,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+        )`,
+		`INSERT INTO tenants (id) VALUES ('default') ON CONFLICT (id) DO NOTHING`,
+		`DO $$
+        DECLARE
+            t text;
+        BEGIN
+            IF EXISTS (
+                SELECT 1 FROM information_schema.columns
+                WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'tenant_id'
+            ) THEN
+                RETURN;
+            END IF;
+
+            FOREACH t IN ARRAY ARRAY['teams', 'users', 'pull_requests', 'pull_request_reviewers',
+                                     'reviewer_assignments', 'user_activity_events', 'idempotency_keys']
+            LOOP
+                EXECUTE format('ALTER TABLE %I ADD COLUMN tenant_id TEXT NOT NULL DEFAULT ''default''
+                    REFERENCES tenants(id)', t);
+                -- Writes have to name their tenant from now on.
+                EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id DROP DEFAULT', t);
+            END LOOP;
+
+            -- Dropping the keys also drops the foreign keys that use them.
+            ALTER TABLE teams DROP CONSTRAINT teams_pkey CASCADE;
+            ALTER TABLE users DROP CONSTRAINT users_pkey CASCADE;
+            ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey CASCADE;
+            ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey;
+            ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+
+            ALTER TABLE teams ADD PRIMARY KEY (tenant_id, name);
+            ALTER TABLE users ADD PRIMARY KEY (tenant_id, id);
+            ALTER TABLE pull_requests ADD PRIMARY KEY (tenant_id, pull_request_id);
+            ALTER TABLE pull_request_reviewers ADD PRIMARY KEY (tenant_id, pull_request_id, reviewer_id);
+            ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant_id, key);
+
+            ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+                FOREIGN KEY (tenant_id, team_name) REFERENCES teams (tenant_id, name) ON UPDATE CASCADE;
+            ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+                FOREIGN KEY (tenant_id, author_id) REFERENCES users (tenant_id, id);
+            ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_pull_request_id_fkey
+                FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests (tenant_id, pull_request_id)
+                ON DELETE CASCADE;
+            ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_reviewer_id_fkey
+                FOREIGN KEY (tenant_id, reviewer_id) REFERENCES users (tenant_id, id) ON DELETE CASCADE;
+            ALTER TABLE reviewer_assignments ADD CONSTRAINT reviewer_assignments_pull_request_id_fkey
+                FOREIGN KEY (tenant_id, pull_request_id) REFERENCES pull_requests (tenant_id, pull_request_id);
+            ALTER TABLE reviewer_assignments ADD CONSTRAINT reviewer_assignments_reviewer_id_fkey
+                FOREIGN KEY (tenant_id, reviewer_id) REFERENCES users (tenant_id, id);
+            ALTER TABLE user_activity_events ADD CONSTRAINT user_activity_events_user_id_fkey
+                FOREIGN KEY (tenant_id, user_id) REFERENCES users (tenant_id, id);
+
+            -- Every query filters by tenant first.
+            DROP INDEX pull_request_reviewers_reviewer_id_idx;
+            CREATE INDEX pull_request_reviewers_reviewer_id_idx
+                ON pull_request_reviewers (tenant_id, reviewer_id);
+            DROP INDEX users_team_name_is_active_idx;
+            CREATE INDEX users_team_name_is_active_idx ON users (tenant_id, team_name, is_active);
+            DROP INDEX pull_requests_author_id_idx;
+            CREATE INDEX pull_requests_author_id_idx ON pull_requests (tenant_id, author_id);
+            DROP INDEX reviewer_assignments_open_idx;
+            CREATE INDEX reviewer_assignments_open_idx
+                ON reviewer_assignments (tenant_id, pull_request_id) WHERE unassigned_at IS NULL;
+            DROP INDEX reviewer_assignments_reviewer_id_idx;
+            CREATE INDEX reviewer_assignments_reviewer_id_idx ON reviewer_assignments (tenant_id, reviewer_id);
+            DROP INDEX user_activity_events_user_id_idx;
+            CREATE INDEX user_activity_events_user_id_idx
+                ON user_activity_events (tenant_id, user_id, changed_at DESC, id DESC);
+        END $$`,
+
+		// Soft delete: deleted users and teams are hidden from reads but keep
+		// their history.
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ`,
+		`ALTER TABLE teams ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ`,
+
+		// Archival: merged pull requests past the retention period move here
+		// with their reviewers. The assignment history stays where it is and
+		// outlives the pull requests it refers to.
+		`CREATE TABLE IF NOT EXISTS archived_pull_requests (
+            tenant_id TEXT NOT NULL REFERENCES tenants(id),
+            pull_request_id TEXT NOT NULL,
+            pull_request_name TEXT NOT NULL,
+            author_id TEXT NOT NULL,
+            status TEXT NOT NULL,
+            created_at TIMESTAMPTZ,
+            merged_at TIMESTAMPTZ,
+            archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            PRIMARY KEY (tenant_id, pull_request_id),
+            FOREIGN KEY (tenant_id, author_id) REFERENCES users (tenant_id, id)
+        )`,
+		`CREATE TABLE IF NOT EXISTS archived_pull_request_reviewers (
+            tenant_id TEXT NOT NULL,
+            pull_request_id TEXT NOT NULL,
+            reviewer_id TEXT NOT NULL,
+            assigned_at TIMESTAMPTZ NOT NULL,
+            PRIMARY KEY (tenant_id, pull_request_id, reviewer_id),
+            FOREIGN KEY (tenant_id, pull_request_id)
+                REFERENCES archived_pull_requests (tenant_id, pull_request_id) ON DELETE CASCADE,
+            FOREIGN KEY (tenant_id, reviewer_id) REFERENCES users (tenant_id, id)
+        )`,
+		`CREATE INDEX IF NOT EXISTS archived_pull_request_reviewers_reviewer_id_idx
+            ON archived_pull_request_reviewers (tenant_id, reviewer_id)`,
+		`CREATE INDEX IF NOT EXISTS pull_requests_merged_at_idx
+            ON pull_requests (tenant_id, merged_at) WHERE status = 'MERGED'`,
+		`ALTER TABLE reviewer_assignments DROP CONSTRAINT IF EXISTS reviewer_assignments_pull_request_id_fkey`,
+		// History queries read live and archived pull requests alike.
+		`CREATE OR REPLACE VIEW all_pull_requests AS
+            SELECT tenant_id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at,
+                NULL::timestamptz AS archived_at
+            FROM pull_requests
+            UNION ALL
+            SELECT tenant_id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at,
+                archived_at
+            FROM archived_pull_requests`,
 	}
 
 	for _, query := range queries {
@@ -38,3 +280,18 @@ func CreateTables(db *sql.DB) error {
 	}
 	return nil
 }
+
+// beforeTenants runs query only while the schema has no tenants: the
+// backfills wrapped in it predate tenant_id and have nothing left to do
+// once the data is split by tenant.
+func beforeTenants(query string) string {
+	return `DO $$
+        BEGIN
+            IF NOT EXISTS (
+                SELECT 1 FROM information_schema.columns
+                WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'tenant_id'
+            ) THEN
+                ` + query + `;
+            END IF;
+        END $$`
+}
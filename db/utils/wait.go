@@ -3,14 +3,28 @@ package dbutils
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
-func WaitForDB(ctx context.Context, dsn string, maxAttempts int) (*sql.DB, error) {
-	var db *sql.DB
+// PoolConfig bounds the connections kept to the database. Zero fields keep
+// the driver defaults.
+type PoolConfig struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+	// StatementCacheCapacity is the number of prepared statements cached per
+	// connection by the pgx pool.
+	StatementCacheCapacity int
+}
+
+func WaitForDB(ctx context.Context, dsn string, maxAttempts int, cfg PoolConfig) (*sql.DB, error) {
 	var err error
 
 	for i := 0; i < maxAttempts; i++ {
@@ -18,6 +32,7 @@ func WaitForDB(ctx context.Context, dsn string, maxAttempts int) (*sql.DB, error
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
+			var db *sql.DB
 			db, err = sql.Open("pgx", dsn)
 			if err != nil {
 				log.Printf("Failed to open database: %v, retrying...", err)
@@ -27,16 +42,21 @@ func WaitForDB(ctx context.Context, dsn string, maxAttempts int) (*sql.DB, error
 
 			if err = db.PingContext(ctx); err != nil {
 				log.Printf("Failed to ping database (attempt %d/%d): %v", i+1, maxAttempts, err)
-				defer func() {
-					if err := db.Close(); err != nil {
-						log.Printf("failed to close db: %v", err)
-					}
-				}()
-				
+				if err := db.Close(); err != nil {
+					log.Printf("failed to close db: %v", err)
+				}
+
 				time.Sleep(1 * time.Second)
 				continue
 			}
 
+			if cfg.MaxConns > 0 {
+				db.SetMaxOpenConns(int(cfg.MaxConns))
+				db.SetMaxIdleConns(int(cfg.MaxConns))
+			}
+			db.SetConnMaxLifetime(cfg.MaxConnLifetime)
+			db.SetConnMaxIdleTime(cfg.MaxConnIdleTime)
+
 			log.Println("Successfully connected to database")
 			return db, nil
 		}
@@ -44,3 +64,56 @@ func WaitForDB(ctx context.Context, dsn string, maxAttempts int) (*sql.DB, error
 
 	return nil, err
 }
+
+// WaitForPool is WaitForDB for a native pgx pool. The pool caches prepared
+// statements per connection, so repeated queries skip the parse step.
+func WaitForPool(ctx context.Context, dsn string, maxAttempts int, cfg PoolConfig) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse dsn: %w", err)
+	}
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	poolCfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+	if cfg.StatementCacheCapacity > 0 {
+		poolCfg.ConnConfig.StatementCacheCapacity = cfg.StatementCacheCapacity
+	}
+
+	for i := 0; i < maxAttempts; i++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			var pool *pgxpool.Pool
+			pool, err = pgxpool.NewWithConfig(ctx, poolCfg)
+			if err != nil {
+				log.Printf("Failed to open database pool: %v, retrying...", err)
+				time.Sleep(1 * time.Second)
+				continue
+			}
+
+			if err = pool.Ping(ctx); err != nil {
+				log.Printf("Failed to ping database (attempt %d/%d): %v", i+1, maxAttempts, err)
+				pool.Close()
+
+				time.Sleep(1 * time.Second)
+				continue
+			}
+
+			log.Println("Successfully connected to database")
+			return pool, nil
+		}
+	}
+
+	return nil, err
+}
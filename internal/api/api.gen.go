@@ -0,0 +1,2170 @@
+//go:build go1.22
+
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerAuthScopes   = "BearerAuth.Scopes"
+	TenantHeaderScopes = "TenantHeader.Scopes"
+)
+
+// Defines values for ErrorResponseErrorCode.
+const (
+	BADREQUEST           ErrorResponseErrorCode = "BAD_REQUEST"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INTERNALERROR        ErrorResponseErrorCode = "INTERNAL_ERROR"
+	INVALIDTRANSITION    ErrorResponseErrorCode = "INVALID_TRANSITION"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PAYLOADTOOLARGE      ErrorResponseErrorCode = "PAYLOAD_TOO_LARGE"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	PRNOTOPEN            ErrorResponseErrorCode = "PR_NOT_OPEN"
+	REQUESTINPROGRESS    ErrorResponseErrorCode = "REQUEST_IN_PROGRESS"
+	STORENOTEMPTY        ErrorResponseErrorCode = "STORE_NOT_EMPTY"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	TOOMANYREQUESTS      ErrorResponseErrorCode = "TOO_MANY_REQUESTS"
+)
+
+// Defines values for MemberFairnessOutlier.
+const (
+	Over  MemberFairnessOutlier = "over"
+	Under MemberFairnessOutlier = "under"
+)
+
+// Defines values for PullRequestStatus.
+const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
+	PullRequestStatusMERGED PullRequestStatus = "MERGED"
+	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
+)
+
+// Defines values for PullRequestShortStatus.
+const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
+	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
+	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
+)
+
+// Defines values for ReadinessChecksLifecycle.
+const (
+	Draining ReadinessChecksLifecycle = "draining"
+	Serving  ReadinessChecksLifecycle = "serving"
+)
+
+// Defines values for ReadinessChecksMigrations.
+const (
+	Applied ReadinessChecksMigrations = "applied"
+	Pending ReadinessChecksMigrations = "pending"
+)
+
+// Defines values for ReadinessStatus.
+const (
+	NotReady ReadinessStatus = "not_ready"
+	Ready    ReadinessStatus = "ready"
+)
+
+// Defines values for StatsResponseGranularity.
+const (
+	StatsResponseGranularityDay  StatsResponseGranularity = "day"
+	StatsResponseGranularityWeek StatsResponseGranularity = "week"
+)
+
+// Defines values for GetStatsParamsGranularity.
+const (
+	GetStatsParamsGranularityDay  GetStatsParamsGranularity = "day"
+	GetStatsParamsGranularityWeek GetStatsParamsGranularity = "week"
+)
+
+// Defines values for GetStatsParamsFormat.
+const (
+	GetStatsParamsFormatCsv        GetStatsParamsFormat = "csv"
+	GetStatsParamsFormatJson       GetStatsParamsFormat = "json"
+	GetStatsParamsFormatPrometheus GetStatsParamsFormat = "prometheus"
+)
+
+// Defines values for GetStatsParamsTable.
+const (
+	Assignments GetStatsParamsTable = "assignments"
+	PrStats     GetStatsParamsTable = "pr_stats"
+)
+
+// Defines values for ExportTeamsParamsFormat.
+const (
+	ExportTeamsParamsFormatCsv  ExportTeamsParamsFormat = "csv"
+	ExportTeamsParamsFormatJson ExportTeamsParamsFormat = "json"
+	ExportTeamsParamsFormatYaml ExportTeamsParamsFormat = "yaml"
+)
+
+// ActivityEvent defines model for ActivityEvent.
+type ActivityEvent struct {
+	ChangedAt time.Time `json:"changed_at"`
+	IsActive  bool      `json:"is_active"`
+	UserId    string    `json:"user_id"`
+}
+
+// Archive Полная копия хранилища. Текущие ревьюверы PR — это его назначения без unassigned_at;
+// team_name в назначениях справочный и при восстановлении не используется.
+type Archive struct {
+	ActivityEvents []ActivityEvent    `json:"activity_events"`
+	Assignments    []AssignmentRecord `json:"assignments"`
+	CreatedAt      time.Time          `json:"created_at"`
+	PullRequests   []PullRequest      `json:"pull_requests"`
+	Teams          []string           `json:"teams"`
+	Users          []User             `json:"users"`
+
+	// Version Версия формата архива
+	Version int `json:"version"`
+}
+
+// AssignmentRecord defines model for AssignmentRecord.
+type AssignmentRecord struct {
+	AssignedAt    time.Time  `json:"assigned_at"`
+	PullRequestId string     `json:"pull_request_id"`
+	ReviewerId    string     `json:"reviewer_id"`
+	TeamName      string     `json:"team_name"`
+	UnassignedAt  *time.Time `json:"unassigned_at,omitempty"`
+}
+
+// CacheMetrics defines model for CacheMetrics.
+type CacheMetrics struct {
+	Entries int `json:"entries"`
+
+	// HitRate Доля запросов, обслуженных из кэша (0..1)
+	HitRate       float32 `json:"hit_rate"`
+	Hits          int64   `json:"hits"`
+	Invalidations int64   `json:"invalidations"`
+	Misses        int64   `json:"misses"`
+	Name          string  `json:"name"`
+}
+
+// CacheStats Кэш команд и пользователей перед хранилищем. Записи живут ttl_seconds и сбрасываются
+// при изменениях через этот экземпляр; изменения через другие экземпляры видны не позже ttl_seconds.
+type CacheStats struct {
+	Caches     []CacheMetrics `json:"caches"`
+	Enabled    bool           `json:"enabled"`
+	TtlSeconds float32        `json:"ttl_seconds"`
+}
+
+// CreatePullRequestRequest defines model for CreatePullRequestRequest.
+type CreatePullRequestRequest struct {
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать PR в статусе DRAFT без назначения ревьюверов
+	Draft           *bool  `json:"draft,omitempty"`
+	PullRequestId   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error struct {
+		Code    ErrorResponseErrorCode `json:"code"`
+		Message string                 `json:"message"`
+	} `json:"error"`
+}
+
+// ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
+type ErrorResponseErrorCode string
+
+// FairnessResponse defines model for FairnessResponse.
+type FairnessResponse struct {
+	Teams []TeamFairness `json:"teams"`
+}
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	CreatedTeams     []string `json:"created_teams"`
+	CreatedUsers     []User   `json:"created_users"`
+	DeactivatedUsers []User   `json:"deactivated_users"`
+
+	// DryRun Изменения только рассчитаны и не применены
+	DryRun     bool       `json:"dry_run"`
+	MovedUsers []UserMove `json:"moved_users"`
+
+	// UpdatedUsers Изменились username или is_active
+	UpdatedUsers []User `json:"updated_users"`
+}
+
+// MemberFairness defines model for MemberFairness.
+type MemberFairness struct {
+	ActiveDays      float64                `json:"active_days"`
+	ActiveShare     float64                `json:"active_share"`
+	AssignmentShare float64                `json:"assignment_share"`
+	Assignments     int                    `json:"assignments"`
+	Outlier         *MemberFairnessOutlier `json:"outlier,omitempty"`
+
+	// Ratio Доля назначений, делённая на долю активного времени
+	Ratio  *float64 `json:"ratio"`
+	UserId string   `json:"user_id"`
+}
+
+// MemberFairnessOutlier defines model for MemberFairness.Outlier.
+type MemberFairnessOutlier string
+
+// OpenLoadStat defines model for OpenLoadStat.
+type OpenLoadStat struct {
+	Open   int    `json:"open"`
+	UserId string `json:"user_id"`
+}
+
+// OrgSnapshot Полный состав организации; пользователи, которых нет в снимке, деактивируются
+type OrgSnapshot struct {
+	Teams []Team `json:"teams"`
+}
+
+// OverdueReviewsResponse defines model for OverdueReviewsResponse.
+type OverdueReviewsResponse struct {
+	Reviews []ReviewAssignment `json:"reviews"`
+}
+
+// PRStats defines model for PRStats.
+type PRStats struct {
+	Closed int `json:"closed"`
+	Draft  int `json:"draft"`
+	Merged int `json:"merged"`
+	Open   int `json:"open"`
+	Total  int `json:"total"`
+}
+
+// PeriodAssignmentStat defines model for PeriodAssignmentStat.
+type PeriodAssignmentStat struct {
+	Count       int       `json:"count"`
+	PeriodStart time.Time `json:"period_start"`
+	UserId      string    `json:"user_id"`
+}
+
+// PeriodPRStats defines model for PeriodPRStats.
+type PeriodPRStats struct {
+	Merged      int       `json:"merged"`
+	Opened      int       `json:"opened"`
+	PeriodStart time.Time `json:"period_start"`
+}
+
+// PeriodReassignmentStat defines model for PeriodReassignmentStat.
+type PeriodReassignmentStat struct {
+	Assignments   int       `json:"assignments"`
+	PeriodStart   time.Time `json:"period_start"`
+	Rate          float64   `json:"rate"`
+	Reassignments int       `json:"reassignments"`
+}
+
+// PullRequest defines model for PullRequest.
+type PullRequest struct {
+	// ArchivedAt Время переноса PR в архив
+	ArchivedAt *time.Time `json:"archivedAt"`
+
+	// AssignedReviewers user_id назначенных ревьюверов (0..2)
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	AuthorId          string            `json:"author_id"`
+	CreatedAt         *time.Time        `json:"createdAt"`
+	MergedAt          *time.Time        `json:"mergedAt"`
+	PullRequestId     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	Status            PullRequestStatus `json:"status"`
+}
+
+// PullRequestStatus defines model for PullRequest.Status.
+type PullRequestStatus string
+
+// PullRequestIdRequest defines model for PullRequestIdRequest.
+type PullRequestIdRequest struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PullRequestResponse defines model for PullRequestResponse.
+type PullRequestResponse struct {
+	Pr PullRequest `json:"pr"`
+}
+
+// PullRequestShort defines model for PullRequestShort.
+type PullRequestShort struct {
+	// ArchivedAt Время переноса PR в архив
+	ArchivedAt      *time.Time             `json:"archivedAt"`
+	AuthorId        string                 `json:"author_id"`
+	PullRequestId   string                 `json:"pull_request_id"`
+	PullRequestName string                 `json:"pull_request_name"`
+	Status          PullRequestShortStatus `json:"status"`
+}
+
+// PullRequestShortStatus defines model for PullRequestShort.Status.
+type PullRequestShortStatus string
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks struct {
+		// Database ok или текст ошибки подключения к БД
+		Database string `json:"database"`
+
+		// Lifecycle draining — сервис завершает работу и не принимает новый трафик
+		Lifecycle  ReadinessChecksLifecycle  `json:"lifecycle"`
+		Migrations ReadinessChecksMigrations `json:"migrations"`
+	} `json:"checks"`
+	Status ReadinessStatus `json:"status"`
+}
+
+// ReadinessChecksLifecycle draining — сервис завершает работу и не принимает новый трафик
+type ReadinessChecksLifecycle string
+
+// ReadinessChecksMigrations defines model for Readiness.Checks.Migrations.
+type ReadinessChecksMigrations string
+
+// ReadinessStatus defines model for Readiness.Status.
+type ReadinessStatus string
+
+// ReassignRequest defines model for ReassignRequest.
+type ReassignRequest struct {
+	OldUserId     string `json:"old_user_id"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// ReassignResponse defines model for ReassignResponse.
+type ReassignResponse struct {
+	Pr PullRequest `json:"pr"`
+
+	// ReplacedBy user_id нового ревьювера
+	ReplacedBy string `json:"replaced_by"`
+}
+
+// RestoreResult Количество восстановленных записей
+type RestoreResult struct {
+	ActivityEvents int `json:"activity_events"`
+	Assignments    int `json:"assignments"`
+	PullRequests   int `json:"pull_requests"`
+	Teams          int `json:"teams"`
+	Users          int `json:"users"`
+}
+
+// ReviewAssignment defines model for ReviewAssignment.
+type ReviewAssignment struct {
+	AssignedAt      time.Time `json:"assigned_at"`
+	AuthorId        string    `json:"author_id"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	ReviewerId      string    `json:"reviewer_id"`
+	TeamName        string    `json:"team_name"`
+}
+
+// SetIsActiveRequest defines model for SetIsActiveRequest.
+type SetIsActiveRequest struct {
+	IsActive bool   `json:"is_active"`
+	UserId   string `json:"user_id"`
+}
+
+// StatsResponse defines model for StatsResponse.
+type StatsResponse struct {
+	AssignmentsPerUser []UserAssignmentStat     `json:"assignments_per_user"`
+	From               time.Time                `json:"from"`
+	Global             TeamStats                `json:"global"`
+	Granularity        StatsResponseGranularity `json:"granularity"`
+	PrStats            PRStats                  `json:"pr_stats"`
+	Teams              []TeamStats              `json:"teams"`
+	To                 time.Time                `json:"to"`
+}
+
+// StatsResponseGranularity defines model for StatsResponse.Granularity.
+type StatsResponseGranularity string
+
+// Team defines model for Team.
+type Team struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// TeamFairness defines model for TeamFairness.
+type TeamFairness struct {
+	From time.Time `json:"from"`
+
+	// Gini Коэффициент Джини по числу назначений на день активности
+	Gini             float64          `json:"gini"`
+	MaxMinRatio      *float64         `json:"max_min_ratio"`
+	Members          []MemberFairness `json:"members"`
+	TeamName         string           `json:"team_name"`
+	To               time.Time        `json:"to"`
+	TotalAssignments int              `json:"total_assignments"`
+}
+
+// TeamMember defines model for TeamMember.
+type TeamMember struct {
+	IsActive bool   `json:"is_active"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// TeamNameRequest defines model for TeamNameRequest.
+type TeamNameRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+// TeamResponse defines model for TeamResponse.
+type TeamResponse struct {
+	Team Team `json:"team"`
+}
+
+// TeamStats defines model for TeamStats.
+type TeamStats struct {
+	AssignmentsPerPeriod     []PeriodAssignmentStat   `json:"assignments_per_period"`
+	MedianTimeToMergeSeconds *float64                 `json:"median_time_to_merge_seconds"`
+	OpenLoad                 []OpenLoadStat           `json:"open_load"`
+	PrsPerPeriod             []PeriodPRStats          `json:"prs_per_period"`
+	ReassignmentsPerPeriod   []PeriodReassignmentStat `json:"reassignments_per_period"`
+
+	// TeamName Отсутствует для глобальной статистики
+	TeamName *string `json:"team_name,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	// DeletedAt Время удаления пользователя; удалённые пользователи видны только в архиве хранилища
+	DeletedAt *time.Time `json:"deletedAt"`
+	IsActive  bool       `json:"is_active"`
+	TeamName  string     `json:"team_name"`
+	UserId    string     `json:"user_id"`
+	Username  string     `json:"username"`
+}
+
+// UserAssignmentStat defines model for UserAssignmentStat.
+type UserAssignmentStat struct {
+	Count  int    `json:"count"`
+	UserId string `json:"user_id"`
+}
+
+// UserIdRequest defines model for UserIdRequest.
+type UserIdRequest struct {
+	UserId string `json:"user_id"`
+}
+
+// UserMove defines model for UserMove.
+type UserMove struct {
+	FromTeam string `json:"from_team"`
+	IsActive bool   `json:"is_active"`
+	ToTeam   string `json:"to_team"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// UserResponse defines model for UserResponse.
+type UserResponse struct {
+	User User `json:"user"`
+}
+
+// UserReviewsResponse defines model for UserReviewsResponse.
+type UserReviewsResponse struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	UserId       string             `json:"user_id"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IncludeArchived defines model for IncludeArchived.
+type IncludeArchived = bool
+
+// TeamNameQuery defines model for TeamNameQuery.
+type TeamNameQuery = string
+
+// UserIdQuery defines model for UserIdQuery.
+type UserIdQuery = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = ErrorResponse
+
+// IdempotencyKeyReused defines model for IdempotencyKeyReused.
+type IdempotencyKeyReused = ErrorResponse
+
+// PayloadTooLarge defines model for PayloadTooLarge.
+type PayloadTooLarge = ErrorResponse
+
+// RequestInProgress defines model for RequestInProgress.
+type RequestInProgress = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// RestoreSnapshotParams defines parameters for RestoreSnapshot.
+type RestoreSnapshotParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PurgeUserParams defines parameters for PurgeUser.
+type PurgeUserParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ClosePullRequestParams defines parameters for ClosePullRequest.
+type ClosePullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CreatePullRequestParams defines parameters for CreatePullRequest.
+type CreatePullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPullRequestParams defines parameters for GetPullRequest.
+type GetPullRequestParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+
+	// IncludeArchived Искать также среди архивных (смёрдженных и перенесённых в архив) PR
+	IncludeArchived *IncludeArchived `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
+// MergePullRequestParams defines parameters for MergePullRequest.
+type MergePullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// MarkPullRequestReadyParams defines parameters for MarkPullRequestReady.
+type MarkPullRequestReadyParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ReassignReviewerParams defines parameters for ReassignReviewer.
+type ReassignReviewerParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ReopenPullRequestParams defines parameters for ReopenPullRequest.
+type ReopenPullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ListOverdueReviewsParams defines parameters for ListOverdueReviews.
+type ListOverdueReviewsParams struct {
+	// OlderThan Минимальное время ожидания (Go duration); по умолчанию SLA сервиса
+	OlderThan *string `form:"older_than,omitempty" json:"older_than,omitempty"`
+
+	// TeamName Фильтр по команде ревьювера
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
+
+// GetStatsParams defines parameters for GetStats.
+type GetStatsParams struct {
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// From Начало периода (RFC 3339 или YYYY-MM-DD); по умолчанию to - 30 дней
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода, не включительно (RFC 3339 или YYYY-MM-DD); по умолчанию текущее время
+	To          *string                    `form:"to,omitempty" json:"to,omitempty"`
+	Granularity *GetStatsParamsGranularity `form:"granularity,omitempty" json:"granularity,omitempty"`
+
+	// Format Формат ответа; имеет приоритет над заголовком Accept
+	Format *GetStatsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Table Таблица для формата csv
+	Table *GetStatsParamsTable `form:"table,omitempty" json:"table,omitempty"`
+}
+
+// GetStatsParamsGranularity defines parameters for GetStats.
+type GetStatsParamsGranularity string
+
+// GetStatsParamsFormat defines parameters for GetStats.
+type GetStatsParamsFormat string
+
+// GetStatsParamsTable defines parameters for GetStats.
+type GetStatsParamsTable string
+
+// ExportAssignmentsParams defines parameters for ExportAssignments.
+type ExportAssignmentsParams struct {
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+	From     *string `form:"from,omitempty" json:"from,omitempty"`
+	To       *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetFairnessParams defines parameters for GetFairness.
+type GetFairnessParams struct {
+	// TeamName Если не указана, отчёт строится по всем командам
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+	From     *string `form:"from,omitempty" json:"from,omitempty"`
+	To       *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// AddTeamParams defines parameters for AddTeam.
+type AddTeamParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteTeamParams defines parameters for DeleteTeam.
+type DeleteTeamParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ExportTeamsParams defines parameters for ExportTeams.
+type ExportTeamsParams struct {
+	// Format Формат ответа; имеет приоритет над заголовком Accept
+	Format *ExportTeamsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportTeamsParamsFormat defines parameters for ExportTeams.
+type ExportTeamsParamsFormat string
+
+// GetTeamParams defines parameters for GetTeam.
+type GetTeamParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// ImportTeamsParams defines parameters for ImportTeams.
+type ImportTeamsParams struct {
+	// DryRun Только рассчитать изменения, не применяя их
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteUserParams defines parameters for DeleteUser.
+type DeleteUserParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetUserReviewsParams defines parameters for GetUserReviews.
+type GetUserReviewsParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// IncludeArchived Искать также среди архивных (смёрдженных и перенесённых в архив) PR
+	IncludeArchived *IncludeArchived `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
+// SetUserIsActiveParams defines parameters for SetUserIsActive.
+type SetUserIsActiveParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// RestoreSnapshotJSONRequestBody defines body for RestoreSnapshot for application/json ContentType.
+type RestoreSnapshotJSONRequestBody = Archive
+
+// PurgeUserJSONRequestBody defines body for PurgeUser for application/json ContentType.
+type PurgeUserJSONRequestBody = UserIdRequest
+
+// ClosePullRequestJSONRequestBody defines body for ClosePullRequest for application/json ContentType.
+type ClosePullRequestJSONRequestBody = PullRequestIdRequest
+
+// CreatePullRequestJSONRequestBody defines body for CreatePullRequest for application/json ContentType.
+type CreatePullRequestJSONRequestBody = CreatePullRequestRequest
+
+// MergePullRequestJSONRequestBody defines body for MergePullRequest for application/json ContentType.
+type MergePullRequestJSONRequestBody = PullRequestIdRequest
+
+// MarkPullRequestReadyJSONRequestBody defines body for MarkPullRequestReady for application/json ContentType.
+type MarkPullRequestReadyJSONRequestBody = PullRequestIdRequest
+
+// ReassignReviewerJSONRequestBody defines body for ReassignReviewer for application/json ContentType.
+type ReassignReviewerJSONRequestBody = ReassignRequest
+
+// ReopenPullRequestJSONRequestBody defines body for ReopenPullRequest for application/json ContentType.
+type ReopenPullRequestJSONRequestBody = PullRequestIdRequest
+
+// AddTeamJSONRequestBody defines body for AddTeam for application/json ContentType.
+type AddTeamJSONRequestBody = Team
+
+// DeleteTeamJSONRequestBody defines body for DeleteTeam for application/json ContentType.
+type DeleteTeamJSONRequestBody = TeamNameRequest
+
+// ImportTeamsJSONRequestBody defines body for ImportTeams for application/json ContentType.
+type ImportTeamsJSONRequestBody = OrgSnapshot
+
+// DeleteUserJSONRequestBody defines body for DeleteUser for application/json ContentType.
+type DeleteUserJSONRequestBody = UserIdRequest
+
+// SetUserIsActiveJSONRequestBody defines body for SetUserIsActive for application/json ContentType.
+type SetUserIsActiveJSONRequestBody = SetIsActiveRequest
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Статистика кэша команд и пользователей
+	// (GET /admin/cache)
+	GetCacheStats(w http.ResponseWriter, r *http.Request)
+	// Загрузить архив в пустое хранилище
+	// (POST /admin/restore)
+	RestoreSnapshot(w http.ResponseWriter, r *http.Request, params RestoreSnapshotParams)
+	// Выгрузить версионированный архив команд, пользователей, PR и назначений
+	// (GET /admin/snapshot)
+	GetSnapshot(w http.ResponseWriter, r *http.Request)
+	// Удалить пользователя и обезличить его данные
+	// (POST /admin/users/purge)
+	PurgeUser(w http.ResponseWriter, r *http.Request, params PurgeUserParams)
+	// Интерактивная документация API (без внешних зависимостей)
+	// (GET /docs)
+	GetDocs(w http.ResponseWriter, r *http.Request)
+	// Проверка работоспособности сервиса
+	// (GET /health)
+	Health(w http.ResponseWriter, r *http.Request)
+	// Проверка живости процесса (не обращается к БД)
+	// (GET /livez)
+	Livez(w http.ResponseWriter, r *http.Request)
+	// Спецификация API в формате JSON
+	// (GET /openapi.json)
+	GetOpenAPIJSON(w http.ResponseWriter, r *http.Request)
+	// Спецификация API в формате YAML
+	// (GET /openapi.yaml)
+	GetOpenAPIYAML(w http.ResponseWriter, r *http.Request)
+	// Закрыть PR без merge (ревьюверы замораживаются)
+	// (POST /pullRequest/close)
+	ClosePullRequest(w http.ResponseWriter, r *http.Request, params ClosePullRequestParams)
+	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// (POST /pullRequest/create)
+	CreatePullRequest(w http.ResponseWriter, r *http.Request, params CreatePullRequestParams)
+	// Получить PR с назначенными ревьюверами
+	// (GET /pullRequest/get)
+	GetPullRequest(w http.ResponseWriter, r *http.Request, params GetPullRequestParams)
+	// Пометить PR как MERGED (идемпотентная операция)
+	// (POST /pullRequest/merge)
+	MergePullRequest(w http.ResponseWriter, r *http.Request, params MergePullRequestParams)
+	// Перевести DRAFT в OPEN и назначить ревьюверов
+	// (POST /pullRequest/ready)
+	MarkPullRequestReady(w http.ResponseWriter, r *http.Request, params MarkPullRequestReadyParams)
+	// Переназначить конкретного ревьювера на другого из его команды
+	// (POST /pullRequest/reassign)
+	ReassignReviewer(w http.ResponseWriter, r *http.Request, params ReassignReviewerParams)
+	// Переоткрыть закрытый PR
+	// (POST /pullRequest/reopen)
+	ReopenPullRequest(w http.ResponseWriter, r *http.Request, params ReopenPullRequestParams)
+	// Готовность принимать трафик
+	// (GET /readyz)
+	Readyz(w http.ResponseWriter, r *http.Request)
+	// Получить назначения ревьюверов на открытые PR, ожидающие дольше SLA
+	// (GET /reviews/overdue)
+	ListOverdueReviews(w http.ResponseWriter, r *http.Request, params ListOverdueReviewsParams)
+	// Статистика по PR и назначениям (за всё время и по периодам, глобально и по командам)
+	// (GET /stats)
+	GetStats(w http.ResponseWriter, r *http.Request, params GetStatsParams)
+	// Потоковая выгрузка полной истории назначений ревьюверов (NDJSON)
+	// (GET /stats/assignments/export)
+	ExportAssignments(w http.ResponseWriter, r *http.Request, params ExportAssignmentsParams)
+	// Равномерность распределения назначений внутри команд
+	// (GET /stats/fairness)
+	GetFairness(w http.ResponseWriter, r *http.Request, params GetFairnessParams)
+	// Создать команду с участниками (создаёт/обновляет пользователей)
+	// (POST /team/add)
+	AddTeam(w http.ResponseWriter, r *http.Request, params AddTeamParams)
+	// Удалить команду вместе с участниками
+	// (POST /team/delete)
+	DeleteTeam(w http.ResponseWriter, r *http.Request, params DeleteTeamParams)
+	// Выгрузить все команды с участниками в формате, пригодном для /team/import
+	// (GET /team/export)
+	ExportTeams(w http.ResponseWriter, r *http.Request, params ExportTeamsParams)
+	// Получить команду с участниками
+	// (GET /team/get)
+	GetTeam(w http.ResponseWriter, r *http.Request, params GetTeamParams)
+	// Синхронизировать команды и пользователей с полным снимком организации
+	// (POST /team/import)
+	ImportTeams(w http.ResponseWriter, r *http.Request, params ImportTeamsParams)
+	// Удалить пользователя
+	// (POST /users/delete)
+	DeleteUser(w http.ResponseWriter, r *http.Request, params DeleteUserParams)
+	// Получить PR'ы, где пользователь назначен ревьювером
+	// (GET /users/getReview)
+	GetUserReviews(w http.ResponseWriter, r *http.Request, params GetUserReviewsParams)
+	// Установить флаг активности пользователя
+	// (POST /users/setIsActive)
+	SetUserIsActive(w http.ResponseWriter, r *http.Request, params SetUserIsActiveParams)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetCacheStats operation middleware
+func (siw *ServerInterfaceWrapper) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCacheStats(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestoreSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RestoreSnapshotParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreSnapshot(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSnapshot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PurgeUser operation middleware
+func (siw *ServerInterfaceWrapper) PurgeUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PurgeUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PurgeUser(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDocs operation middleware
+func (siw *ServerInterfaceWrapper) GetDocs(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDocs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Health operation middleware
+func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Health(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Livez operation middleware
+func (siw *ServerInterfaceWrapper) Livez(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Livez(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOpenAPIJSON operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenAPIJSON(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOpenAPIJSON(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOpenAPIYAML operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenAPIYAML(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOpenAPIYAML(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClosePullRequest operation middleware
+func (siw *ServerInterfaceWrapper) ClosePullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ClosePullRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClosePullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePullRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreatePullRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequest operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestParams
+
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	if paramValue := r.URL.Query().Get("pull_request_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pull_request_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", r.URL.Query(), &params.PullRequestId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pull_request_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_archived" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_archived", r.URL.Query(), &params.IncludeArchived)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_archived", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergePullRequest operation middleware
+func (siw *ServerInterfaceWrapper) MergePullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MergePullRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergePullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MarkPullRequestReady operation middleware
+func (siw *ServerInterfaceWrapper) MarkPullRequestReady(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MarkPullRequestReadyParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkPullRequestReady(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReassignReviewer operation middleware
+func (siw *ServerInterfaceWrapper) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReassignReviewerParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReassignReviewer(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReopenPullRequest operation middleware
+func (siw *ServerInterfaceWrapper) ReopenPullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReopenPullRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReopenPullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Readyz operation middleware
+func (siw *ServerInterfaceWrapper) Readyz(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Readyz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListOverdueReviews operation middleware
+func (siw *ServerInterfaceWrapper) ListOverdueReviews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOverdueReviewsParams
+
+	// ------------- Optional query parameter "older_than" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "older_than", r.URL.Query(), &params.OlderThan)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "older_than", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOverdueReviews(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetStats operation middleware
+func (siw *ServerInterfaceWrapper) GetStats(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "granularity" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "granularity", r.URL.Query(), &params.Granularity)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "granularity", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", r.URL.Query(), &params.Table)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetStats(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportAssignments operation middleware
+func (siw *ServerInterfaceWrapper) ExportAssignments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportAssignmentsParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportAssignments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFairness operation middleware
+func (siw *ServerInterfaceWrapper) GetFairness(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetFairnessParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFairness(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddTeam operation middleware
+func (siw *ServerInterfaceWrapper) AddTeam(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AddTeamParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddTeam(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteTeam operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTeamParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTeam(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportTeams operation middleware
+func (siw *ServerInterfaceWrapper) ExportTeams(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportTeamsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportTeams(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeam operation middleware
+func (siw *ServerInterfaceWrapper) GetTeam(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeam(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ImportTeams operation middleware
+func (siw *ServerInterfaceWrapper) ImportTeams(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportTeamsParams
+
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dry_run", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportTeams(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetUserReviews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserReviewsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "include_archived" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "include_archived", r.URL.Query(), &params.IncludeArchived)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "include_archived", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserReviews(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserIsActive operation middleware
+func (siw *ServerInterfaceWrapper) SetUserIsActive(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, TenantHeaderScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetUserIsActiveParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserIsActive(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/admin/cache", wrapper.GetCacheStats)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/restore", wrapper.RestoreSnapshot)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/snapshot", wrapper.GetSnapshot)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/users/purge", wrapper.PurgeUser)
+	m.HandleFunc("GET "+options.BaseURL+"/docs", wrapper.GetDocs)
+	m.HandleFunc("GET "+options.BaseURL+"/health", wrapper.Health)
+	m.HandleFunc("GET "+options.BaseURL+"/livez", wrapper.Livez)
+	m.HandleFunc("GET "+options.BaseURL+"/openapi.json", wrapper.GetOpenAPIJSON)
+	m.HandleFunc("GET "+options.BaseURL+"/openapi.yaml", wrapper.GetOpenAPIYAML)
+	m.HandleFunc("POST "+options.BaseURL+"/pullRequest/close", wrapper.ClosePullRequest)
+	m.HandleFunc("POST "+options.BaseURL+"/pullRequest/create", wrapper.CreatePullRequest)
+	m.HandleFunc("GET "+options.BaseURL+"/pullRequest/get", wrapper.GetPullRequest)
+	m.HandleFunc("POST "+options.BaseURL+"/pullRequest/merge", wrapper.MergePullRequest)
+	m.HandleFunc("POST "+options.BaseURL+"/pullRequest/ready", wrapper.MarkPullRequestReady)
+	m.HandleFunc("POST "+options.BaseURL+"/pullRequest/reassign", wrapper.ReassignReviewer)
+	m.HandleFunc("POST "+options.BaseURL+"/pullRequest/reopen", wrapper.ReopenPullRequest)
+	m.HandleFunc("GET "+options.BaseURL+"/readyz", wrapper.Readyz)
+	m.HandleFunc("GET "+options.BaseURL+"/reviews/overdue", wrapper.ListOverdueReviews)
+	m.HandleFunc("GET "+options.BaseURL+"/stats", wrapper.GetStats)
+	m.HandleFunc("GET "+options.BaseURL+"/stats/assignments/export", wrapper.ExportAssignments)
+	m.HandleFunc("GET "+options.BaseURL+"/stats/fairness", wrapper.GetFairness)
+	m.HandleFunc("POST "+options.BaseURL+"/team/add", wrapper.AddTeam)
+	m.HandleFunc("POST "+options.BaseURL+"/team/delete", wrapper.DeleteTeam)
+	m.HandleFunc("GET "+options.BaseURL+"/team/export", wrapper.ExportTeams)
+	m.HandleFunc("GET "+options.BaseURL+"/team/get", wrapper.GetTeam)
+	m.HandleFunc("POST "+options.BaseURL+"/team/import", wrapper.ImportTeams)
+	m.HandleFunc("POST "+options.BaseURL+"/users/delete", wrapper.DeleteUser)
+	m.HandleFunc("GET "+options.BaseURL+"/users/getReview", wrapper.GetUserReviews)
+	m.HandleFunc("POST "+options.BaseURL+"/users/setIsActive", wrapper.SetUserIsActive)
+
+	return m
+}
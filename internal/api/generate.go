@@ -0,0 +1,6 @@
+// Package api contains the HTTP server interface and models generated from
+// task/openapi.yaml. Regenerate with `go generate ./internal/api` after
+// changing the spec; do not edit api.gen.go by hand.
+package api
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.0 --config=oapi-codegen.yaml ../../task/openapi.yaml
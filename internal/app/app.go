@@ -0,0 +1,225 @@
+// Package app wires repositories into the services and servers of the
+// application. The service binary, the in-process load generator and the
+// integration tests all build on it, so they serve the same middleware
+// stack.
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ChernykhITMO/Avito/internal/cache"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/grpcserver"
+	"github.com/ChernykhITMO/Avito/internal/handlers"
+	"github.com/ChernykhITMO/Avito/internal/httpserver"
+	"github.com/ChernykhITMO/Avito/internal/ratelimit"
+	"github.com/ChernykhITMO/Avito/internal/repository"
+	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/tenant"
+)
+
+const (
+	DefaultReviewSLA      = 48 * time.Hour
+	DefaultIdempotencyTTL = 24 * time.Hour
+	DefaultDrainDelay     = 5 * time.Second
+
+	defaultRateLimitRPS        = 20
+	defaultRateLimitBurst      = 40
+	defaultMaxConcurrent       = 100
+	defaultMaxBodyBytes        = 1 << 20
+	defaultMaxRestoreBodyBytes = 32 << 20
+)
+
+// Probes and the API description are served to everyone.
+var (
+	probePaths = []string{"/health", "/livez", "/readyz"}
+	docsPaths  = []string{"/openapi.yaml", "/openapi.json", "/docs"}
+)
+
+// Repos are the repositories the application runs on.
+type Repos struct {
+	Teams       domain.TeamRepository
+	Users       domain.UserRepository
+	PRs         domain.PRRepository
+	Stats       domain.StatsRepository
+	Archives    domain.ArchiveRepository
+	Idempotency domain.IdempotencyStore
+	Tenants     domain.TenantRepository
+}
+
+// PgxRepos returns the repositories on a pgx pool.
+func PgxRepos(pool *pgxpool.Pool) Repos {
+	return Repos{
+		Teams:       repository.NewPgxTeamRepository(pool),
+		Users:       repository.NewPgxUserRepository(pool),
+		PRs:         repository.NewPgxPRRepository(pool),
+		Stats:       repository.NewPgxStatsRepository(pool),
+		Archives:    repository.NewPgxArchiveRepository(pool),
+		Idempotency: repository.NewPgxIdempotencyRepository(pool),
+		Tenants:     repository.NewPgxTenantRepository(pool),
+	}
+}
+
+// SQLRepos returns the repositories on database/sql.
+func SQLRepos(db *sql.DB) Repos {
+	return Repos{
+		Teams:       repository.NewTeamRepository(db),
+		Users:       repository.NewUserRepository(db),
+		PRs:         repository.NewPRRepository(db),
+		Stats:       repository.NewStatsRepository(db),
+		Archives:    repository.NewArchiveRepository(db),
+		Idempotency: repository.NewIdempotencyRepository(db),
+		Tenants:     repository.NewTenantRepository(db),
+	}
+}
+
+// Config tunes the services and servers built by New.
+type Config struct {
+	ReviewSLA time.Duration
+
+	// CacheTTL above zero caches teams and users for that long.
+	CacheTTL time.Duration
+
+	// IdempotencyStore replaces Repos.Idempotency, e.g. with an in-memory
+	// store; responses are kept for IdempotencyTTL.
+	IdempotencyStore domain.IdempotencyStore
+	IdempotencyTTL   time.Duration
+
+	// Limits guards the HTTP server; nil disables all limits.
+	Limits *ratelimit.Config
+
+	Tenants    *tenant.Resolver
+	DrainDelay time.Duration
+}
+
+// DefaultConfig returns the configuration the service runs with when
+// nothing is overridden. It serves the default tenant only.
+func DefaultConfig() Config {
+	limits := DefaultLimits()
+	// Only the literal default tenant, which is always a valid id.
+	tenants, _ := tenant.New(DefaultTenantConfig())
+
+	return Config{
+		ReviewSLA:      DefaultReviewSLA,
+		IdempotencyTTL: DefaultIdempotencyTTL,
+		Limits:         &limits,
+		Tenants:        tenants,
+		DrainDelay:     DefaultDrainDelay,
+	}
+}
+
+// DefaultLimits returns the overload protection of the service.
+func DefaultLimits() ratelimit.Config {
+	return ratelimit.Config{
+		Rate:          defaultRateLimitRPS,
+		Burst:         defaultRateLimitBurst,
+		MaxConcurrent: defaultMaxConcurrent,
+		MaxBodyBytes:  defaultMaxBodyBytes,
+		RouteMaxBodyBytes: map[string]int64{
+			// The import handler applies the same cap, also when the
+			// server runs without limits.
+			"/team/import":   handlers.MaxImportBodyBytes,
+			"/admin/restore": defaultMaxRestoreBodyBytes,
+		},
+		ExemptPaths: probePaths,
+	}
+}
+
+// DefaultTenantConfig resolves requests without a tenant to the default
+// one and exempts probes and docs.
+func DefaultTenantConfig() tenant.Config {
+	return tenant.Config{
+		Header:      tenant.Header,
+		Default:     domain.DefaultTenant,
+		ExemptPaths: append(append([]string{}, probePaths...), docsPaths...),
+	}
+}
+
+// App holds the services built on Repos.
+type App struct {
+	Repos   Repos
+	Teams   service.TeamService
+	Users   service.UserService
+	PRs     service.PullRequestService
+	Stats   service.StatsService
+	Reviews service.ReviewService
+	Admin   service.AdminService
+	Health  service.HealthService
+
+	cfg Config
+}
+
+// New builds the services; db is checked by the readiness probe.
+func New(repos Repos, db service.Pinger, cfg Config) *App {
+	clock := service.SystemClock()
+
+	teams, users := repos.Teams, repos.Users
+	var caches service.CacheStatsSource
+	if cfg.CacheTTL > 0 {
+		c := cache.New(cfg.CacheTTL, clock)
+		teams, users = c.Teams(teams), c.Users(users)
+		caches = c
+	}
+
+	if cfg.IdempotencyStore == nil {
+		cfg.IdempotencyStore = repos.Idempotency
+	}
+
+	return &App{
+		Repos:   repos,
+		Teams:   service.NewTeamService(teams, users),
+		Users:   service.NewUserService(users, repos.PRs),
+		PRs:     service.NewPullRequestService(repos.PRs, users, teams, service.SystemRand()),
+		Stats:   service.NewStatsService(repos.Stats, clock),
+		Reviews: service.NewReviewService(repos.PRs, clock, cfg.ReviewSLA),
+		Admin:   service.NewAdminService(repos.Archives, clock, caches),
+		Health:  service.NewHealthService(db),
+		cfg:     cfg,
+	}
+}
+
+// Ready registers the configured tenants and reports the application
+// ready. Call it once the schema is migrated.
+func (a *App) Ready(ctx context.Context) error {
+	if a.cfg.Tenants != nil {
+		if err := a.Repos.Tenants.Ensure(ctx, a.cfg.Tenants.Tenants()); err != nil {
+			return fmt.Errorf("register tenants: %w", err)
+		}
+	}
+	a.Health.MarkMigrated()
+	return nil
+}
+
+// HTTPServer returns the REST API server on addr.
+func (a *App) HTTPServer(addr string) *httpserver.Server {
+	return httpserver.New(addr, httpserver.Deps{
+		TeamService:        a.Teams,
+		UserService:        a.Users,
+		PullRequestService: a.PRs,
+		StatsService:       a.Stats,
+		ReviewService:      a.Reviews,
+		AdminService:       a.Admin,
+		HealthService:      a.Health,
+		IdempotencyStore:   a.cfg.IdempotencyStore,
+		IdempotencyTTL:     a.cfg.IdempotencyTTL,
+		Limits:             a.cfg.Limits,
+		Tenants:            a.cfg.Tenants,
+		DrainDelay:         a.cfg.DrainDelay,
+	})
+}
+
+// GRPCServer returns the gRPC API server on addr.
+func (a *App) GRPCServer(addr string) *grpcserver.Server {
+	return grpcserver.New(addr, grpcserver.Deps{
+		TeamService:        a.Teams,
+		UserService:        a.Users,
+		PullRequestService: a.PRs,
+		StatsService:       a.Stats,
+		Tenants:            a.cfg.Tenants,
+	})
+}
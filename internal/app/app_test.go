@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/handlers"
+	"github.com/ChernykhITMO/Avito/internal/idempotency"
+	"github.com/ChernykhITMO/Avito/internal/ratelimit"
+	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+)
+
+type pingerStub struct{}
+
+func (pingerStub) PingContext(context.Context) error {
+	return nil
+}
+
+// oversizedImport is a valid CSV snapshot a little over the import limit,
+// well below the limit of bulk bodies in general.
+func oversizedImport() string {
+	var body strings.Builder
+	body.WriteString("team_name,user_id,username,is_active\n")
+	for i := 0; body.Len() <= handlers.MaxImportBodyBytes+1<<20; i++ {
+		fmt.Fprintf(&body, "backend,n%d,User%d,true\n", i, i)
+	}
+	return body.String()
+}
+
+func TestDefaultLimits_TeamImportBody(t *testing.T) {
+	limits := DefaultLimits()
+	h := ratelimit.Middleware(limits, service.SystemClock())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.Copy(io.Discard, r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	body := oversizedImport()
+	for _, known := range []bool{true, false} {
+		t.Run(fmt.Sprintf("known length %v", known), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/team/import", strings.NewReader(body))
+			if !known {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("expected 413, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHTTPServer_RejectsOversizedImport(t *testing.T) {
+	store := fakes.New()
+	repos := Repos{
+		Teams:    store.Teams(),
+		Users:    store.Users(),
+		PRs:      store.PRs(),
+		Stats:    store.Stats(),
+		Archives: store.Archives(),
+		Tenants:  store.Tenants(),
+	}
+	team := fixtures.Repos{Teams: repos.Teams, Users: repos.Users, PRs: repos.PRs}.
+		Team("backend").Members("u1", "u2").Create(t)
+
+	cfg := DefaultConfig()
+	cfg.IdempotencyStore = idempotency.NewMemoryStore(service.SystemClock())
+	cfg.DrainDelay = 0
+	a := New(repos, pingerStub{}, cfg)
+	if err := a.Ready(context.Background()); err != nil {
+		t.Fatalf("ready: %v", err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.HTTPServer("").Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Errorf("server did not stop")
+		}
+	})
+
+	body := oversizedImport()
+	for _, known := range []bool{true, false} {
+		t.Run(fmt.Sprintf("known length %v", known), func(t *testing.T) {
+			var r io.Reader = strings.NewReader(body)
+			if !known {
+				// Hides the length from the client, which then sends the
+				// body chunked.
+				r = io.MultiReader(r)
+			}
+			req, err := http.NewRequest(http.MethodPost, "http://"+ln.Addr().String()+"/team/import", r)
+			if err != nil {
+				t.Fatalf("new request: %v", err)
+			}
+			req.Header.Set("Content-Type", "text/csv")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("post: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusRequestEntityTooLarge {
+				t.Fatalf("expected 413, got %d", resp.StatusCode)
+			}
+		})
+	}
+
+	got, err := store.Teams().GetByName(context.Background(), "backend")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if !reflect.DeepEqual(got.Members, team.Members) {
+		t.Fatalf("oversized import changed the team: %+v", got.Members)
+	}
+	if n := store.Calls("Teams.ApplyDiff"); n != 0 {
+		t.Fatalf("expected no diff applied, got %d", n)
+	}
+}
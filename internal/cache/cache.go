@@ -0,0 +1,215 @@
+// Package cache adds read-through caching in front of the team and user
+// repositories. Entries live for a fixed TTL and are invalidated by the
+// writes that go through the cached repositories; writes from other
+// instances become visible once the TTL passes.
+package cache
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+// key names a team or user within the tenant of the request.
+type key struct {
+	tenant, id string
+}
+
+func keyFor(ctx context.Context, id string) key {
+	return key{tenant: domain.TenantFrom(ctx), id: id}
+}
+
+// Cache holds the entries shared by the cached repositories, so that a
+// write through one of them invalidates what the other has cached.
+type Cache struct {
+	ttl time.Duration
+
+	teams *store[key, domain.Team]
+	users *store[key, domain.User]
+	// candidates holds the active members of a team by team name.
+	candidates *store[key, []domain.User]
+}
+
+func New(ttl time.Duration, clock service.Clock) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		teams:      newStore[key, domain.Team]("teams", ttl, clock),
+		users:      newStore[key, domain.User]("users", ttl, clock),
+		candidates: newStore[key, []domain.User]("candidates", ttl, clock),
+	}
+}
+
+// Stats reports the hit rate of every cache.
+func (c *Cache) Stats() domain.CacheStats {
+	return domain.CacheStats{
+		Enabled: true,
+		TTL:     c.ttl,
+		Caches: []domain.CacheMetrics{
+			c.teams.metrics(),
+			c.users.metrics(),
+			c.candidates.metrics(),
+		},
+	}
+}
+
+// Teams wraps next: GetByName is cached, writes invalidate.
+func (c *Cache) Teams(next domain.TeamRepository) domain.TeamRepository {
+	return &teamRepository{next: next, cache: c}
+}
+
+// Users wraps next: GetUserByID and ListReviewCandidates are cached, writes
+// invalidate.
+func (c *Cache) Users(next domain.UserRepository) domain.UserRepository {
+	return &userRepository{next: next, cache: c}
+}
+
+// purgeAll drops everything; used by bulk writes that may move users
+// between teams.
+func (c *Cache) purgeAll() {
+	c.teams.purge()
+	c.users.purge()
+	c.candidates.purge()
+}
+
+var _ domain.TeamRepository = (*teamRepository)(nil)
+
+type teamRepository struct {
+	next  domain.TeamRepository
+	cache *Cache
+}
+
+func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
+	if err := r.next.Create(ctx, team); err != nil {
+		return err
+	}
+	k := keyFor(ctx, team.Name)
+	r.cache.teams.invalidate(k)
+	r.cache.candidates.invalidate(k)
+	return nil
+}
+
+func (r *teamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	k := keyFor(ctx, name)
+	team, gen, ok := r.cache.teams.get(k)
+	if ok {
+		return copyTeam(team), nil
+	}
+
+	loaded, err := r.next.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.teams.put(k, *copyTeam(*loaded), gen)
+	return loaded, nil
+}
+
+func (r *teamRepository) List(ctx context.Context) ([]domain.Team, error) {
+	return r.next.List(ctx)
+}
+
+// Delete also deletes the members, so every cached user may be stale.
+func (r *teamRepository) Delete(ctx context.Context, name string) (*domain.Team, error) {
+	team, err := r.next.Delete(ctx, name)
+	r.cache.purgeAll()
+	return team, err
+}
+
+func (r *teamRepository) ApplyDiff(ctx context.Context, diff domain.OrgDiff) error {
+	err := r.next.ApplyDiff(ctx, diff)
+	// A failed transaction changes nothing, but the error may hide a
+	// commit that went through.
+	r.cache.purgeAll()
+	return err
+}
+
+var _ domain.UserRepository = (*userRepository)(nil)
+
+type userRepository struct {
+	next  domain.UserRepository
+	cache *Cache
+}
+
+func (r *userRepository) SaveAll(ctx context.Context, users []domain.User) error {
+	err := r.next.SaveAll(ctx, users)
+	// Users may have moved from teams this batch does not name.
+	r.cache.purgeAll()
+	return err
+}
+
+func (r *userRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	k := keyFor(ctx, id)
+	user, gen, ok := r.cache.users.get(k)
+	if ok {
+		return &user, nil
+	}
+
+	loaded, err := r.next.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.users.put(k, *loaded, gen)
+	return loaded, nil
+}
+
+func (r *userRepository) SetIsActive(ctx context.Context, id string, active bool) (*domain.User, error) {
+	return r.write(ctx, id, func() (*domain.User, error) {
+		return r.next.SetIsActive(ctx, id, active)
+	})
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) (*domain.User, error) {
+	return r.write(ctx, id, func() (*domain.User, error) {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+func (r *userRepository) Purge(ctx context.Context, id string) (*domain.User, error) {
+	return r.write(ctx, id, func() (*domain.User, error) {
+		return r.next.Purge(ctx, id)
+	})
+}
+
+// write runs a change of user id and invalidates the user and its team.
+func (r *userRepository) write(ctx context.Context, id string, fn func() (*domain.User, error)) (*domain.User, error) {
+	user, err := fn()
+	r.cache.users.invalidate(keyFor(ctx, id))
+	if err != nil {
+		return nil, err
+	}
+
+	team := keyFor(ctx, user.TeamName)
+	r.cache.teams.invalidate(team)
+	r.cache.candidates.invalidate(team)
+	return user, nil
+}
+
+// ListReviewCandidates caches the active members of the team once and
+// filters out the excluded user on every call.
+func (r *userRepository) ListReviewCandidates(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
+	k := keyFor(ctx, teamName)
+	active, gen, ok := r.cache.candidates.get(k)
+	if !ok {
+		loaded, err := r.next.ListReviewCandidates(ctx, teamName, "")
+		if err != nil {
+			return nil, err
+		}
+		active = loaded
+		r.cache.candidates.put(k, slices.Clone(loaded), gen)
+	}
+
+	var users []domain.User
+	for _, u := range active {
+		if u.ID != excludeUserID {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+func copyTeam(t domain.Team) *domain.Team {
+	t.Members = slices.Clone(t.Members)
+	return &t
+}
@@ -0,0 +1,491 @@
+package cache
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+}
+
+// fakeDB backs both repositories and counts the reads that reach it.
+type fakeDB struct {
+	mu    sync.Mutex
+	users map[string]domain.User
+	reads int
+	// beforeRead runs inside a read after it took its snapshot, to model a
+	// write landing while the read is in flight.
+	beforeRead func()
+}
+
+func newFakeDB(users ...domain.User) *fakeDB {
+	db := &fakeDB{users: make(map[string]domain.User)}
+	for _, u := range users {
+		db.users[u.ID] = u
+	}
+	return db
+}
+
+func (db *fakeDB) snapshot() map[string]domain.User {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.reads++
+	users := make(map[string]domain.User, len(db.users))
+	for id, u := range db.users {
+		users[id] = u
+	}
+	if hook := db.beforeRead; hook != nil {
+		db.beforeRead = nil
+		db.mu.Unlock()
+		hook()
+		db.mu.Lock()
+	}
+	return users
+}
+
+func (db *fakeDB) Create(ctx context.Context, team *domain.Team) error {
+	return db.SaveAll(ctx, team.Members)
+}
+
+func (db *fakeDB) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	team := domain.Team{Name: name}
+	for _, u := range db.snapshot() {
+		if u.TeamName == name {
+			team.Members = append(team.Members, u)
+		}
+	}
+	if len(team.Members) == 0 {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+	}
+	slices.SortFunc(team.Members, func(a, b domain.User) int { return cmp.Compare(a.ID, b.ID) })
+	return &team, nil
+}
+
+func (db *fakeDB) List(ctx context.Context) ([]domain.Team, error) {
+	return nil, nil
+}
+
+func (db *fakeDB) ApplyDiff(ctx context.Context, diff domain.OrgDiff) error {
+	return nil
+}
+
+func (db *fakeDB) SaveAll(ctx context.Context, users []domain.User) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, u := range users {
+		db.users[u.ID] = u
+	}
+	return nil
+}
+
+func (db *fakeDB) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	u, ok := db.snapshot()[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	return &u, nil
+}
+
+func (db *fakeDB) SetIsActive(ctx context.Context, id string, active bool) (*domain.User, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	u.IsActive = active
+	db.users[id] = u
+	return &u, nil
+}
+
+func (db *fakeDB) Delete(ctx context.Context, id string) (*domain.User, error) {
+	return db.remove(id, func(u *domain.User) {})
+}
+
+func (db *fakeDB) Purge(ctx context.Context, id string) (*domain.User, error) {
+	return db.remove(id, func(u *domain.User) { u.Name = domain.PurgedUserName })
+}
+
+// remove drops user id from the reads, as a soft delete does.
+func (db *fakeDB) remove(id string, change func(*domain.User)) (*domain.User, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	delete(db.users, id)
+	change(&u)
+	u.IsActive = false
+	return &u, nil
+}
+
+// teams returns db as a team repository; the Delete of fakeDB is the one
+// of users.
+func (db *fakeDB) teams() domain.TeamRepository {
+	return fakeTeams{db}
+}
+
+type fakeTeams struct {
+	*fakeDB
+}
+
+func (db fakeTeams) Delete(ctx context.Context, name string) (*domain.Team, error) {
+	team, err := db.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range team.Members {
+		if _, err := db.fakeDB.Delete(ctx, u.ID); err != nil {
+			return nil, err
+		}
+	}
+	return team, nil
+}
+
+func (db *fakeDB) ListReviewCandidates(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
+	var users []domain.User
+	for _, u := range db.snapshot() {
+		if u.TeamName == teamName && u.IsActive && u.ID != excludeUserID {
+			users = append(users, u)
+		}
+	}
+	slices.SortFunc(users, func(a, b domain.User) int { return cmp.Compare(a.ID, b.ID) })
+	return users, nil
+}
+
+func backend() *fakeDB {
+	return newFakeDB(
+		domain.User{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		domain.User{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		domain.User{ID: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+	)
+}
+
+func ids(users []domain.User) []string {
+	var out []string
+	for _, u := range users {
+		out = append(out, u.ID)
+	}
+	return out
+}
+
+func TestCache_ServesReadsUntilTTL(t *testing.T) {
+	ctx := context.Background()
+	clock := newClock()
+	db := backend()
+	c := New(time.Minute, clock)
+	teams := c.Teams(db.teams())
+
+	for range 3 {
+		if _, err := teams.GetByName(ctx, "backend"); err != nil {
+			t.Fatalf("get team: %v", err)
+		}
+	}
+	if db.reads != 1 {
+		t.Fatalf("reads = %d, want 1", db.reads)
+	}
+
+	// A write that bypasses the cache stays invisible until the TTL passes.
+	_ = db.SaveAll(ctx, []domain.User{{ID: "u4", Name: "Dan", TeamName: "backend", IsActive: true}})
+
+	team, _ := teams.GetByName(ctx, "backend")
+	if len(team.Members) != 3 {
+		t.Fatalf("members before ttl = %v, want the cached 3", ids(team.Members))
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	team, _ = teams.GetByName(ctx, "backend")
+	if len(team.Members) != 4 {
+		t.Fatalf("members after ttl = %v, want 4", ids(team.Members))
+	}
+	if db.reads != 2 {
+		t.Fatalf("reads = %d, want 2", db.reads)
+	}
+}
+
+func TestCache_KeysArePerTenant(t *testing.T) {
+	def := context.Background()
+	acme := domain.WithTenant(def, "acme")
+	db := backend()
+	c := New(time.Minute, newClock())
+	users := c.Users(db)
+
+	for _, ctx := range []context.Context{def, acme, def, acme} {
+		if _, err := users.GetUserByID(ctx, "u1"); err != nil {
+			t.Fatalf("get user: %v", err)
+		}
+	}
+	if db.reads != 2 {
+		t.Fatalf("reads = %d, want one per tenant", db.reads)
+	}
+
+	// A write in one tenant leaves the entries of the others alone.
+	if _, err := users.SetIsActive(acme, "u1", false); err != nil {
+		t.Fatalf("set is active: %v", err)
+	}
+	if _, err := users.GetUserByID(def, "u1"); err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if db.reads != 2 {
+		t.Fatalf("reads = %d, want the default tenant served from the cache", db.reads)
+	}
+}
+
+func TestCache_WritesInvalidate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		write func(teams domain.TeamRepository, users domain.UserRepository) error
+	}{
+		{
+			name: "set is active",
+			write: func(_ domain.TeamRepository, users domain.UserRepository) error {
+				_, err := users.SetIsActive(ctx, "u2", false)
+				return err
+			},
+		},
+		{
+			name: "save all",
+			write: func(_ domain.TeamRepository, users domain.UserRepository) error {
+				return users.SaveAll(ctx, []domain.User{{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: false}})
+			},
+		},
+		{
+			name: "delete user",
+			write: func(_ domain.TeamRepository, users domain.UserRepository) error {
+				_, err := users.Delete(ctx, "u2")
+				return err
+			},
+		},
+		{
+			name: "purge user",
+			write: func(_ domain.TeamRepository, users domain.UserRepository) error {
+				_, err := users.Purge(ctx, "u2")
+				return err
+			},
+		},
+		{
+			name: "delete team",
+			write: func(teams domain.TeamRepository, _ domain.UserRepository) error {
+				_, err := teams.Delete(ctx, "backend")
+				return err
+			},
+		},
+		{
+			name: "save all moves user",
+			write: func(_ domain.TeamRepository, users domain.UserRepository) error {
+				return users.SaveAll(ctx, []domain.User{{ID: "u2", Name: "Bob", TeamName: "frontend", IsActive: true}})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := backend()
+			c := New(time.Hour, newClock())
+			teams, users := c.Teams(db.teams()), c.Users(db)
+
+			// Warm every cache.
+			if _, err := teams.GetByName(ctx, "backend"); err != nil {
+				t.Fatalf("get team: %v", err)
+			}
+			if _, err := users.GetUserByID(ctx, "u2"); err != nil {
+				t.Fatalf("get user: %v", err)
+			}
+			if _, err := users.ListReviewCandidates(ctx, "backend", "u1"); err != nil {
+				t.Fatalf("list candidates: %v", err)
+			}
+
+			if err := tt.write(teams, users); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+
+			want, exists := db.users["u2"]
+			got, err := users.GetUserByID(ctx, "u2")
+			switch {
+			case !exists && err == nil:
+				t.Errorf("user = %+v, want it deleted", *got)
+			case exists && (err != nil || *got != want):
+				t.Errorf("user = %+v, %v, want %+v", got, err, want)
+			}
+
+			candidates, _ := users.ListReviewCandidates(ctx, "backend", "u1")
+			if slices.Contains(ids(candidates), "u2") {
+				t.Errorf("candidates = %v, u2 is no longer an active member", ids(candidates))
+			}
+
+			team, err := teams.GetByName(ctx, "backend")
+			if err != nil {
+				return
+			}
+			for _, m := range team.Members {
+				if m.ID == "u2" && (!exists || m != want) {
+					t.Errorf("team member = %+v, want %+v", m, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCache_CandidatesExcludePerCall(t *testing.T) {
+	ctx := context.Background()
+	db := backend()
+	users := New(time.Hour, newClock()).Users(db)
+
+	got, _ := users.ListReviewCandidates(ctx, "backend", "u1")
+	if want := []string{"u2", "u3"}; !slices.Equal(ids(got), want) {
+		t.Fatalf("candidates = %v, want %v", ids(got), want)
+	}
+	got, _ = users.ListReviewCandidates(ctx, "backend", "u3")
+	if want := []string{"u1", "u2"}; !slices.Equal(ids(got), want) {
+		t.Fatalf("candidates = %v, want %v", ids(got), want)
+	}
+	if db.reads != 1 {
+		t.Fatalf("reads = %d, want 1", db.reads)
+	}
+}
+
+// A read that started before a write must not cache what it read.
+func TestCache_InvalidationDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	db := backend()
+	c := New(time.Hour, newClock())
+	teams, users := c.Teams(db.teams()), c.Users(db)
+
+	db.beforeRead = func() {
+		if _, err := users.SetIsActive(ctx, "u2", false); err != nil {
+			t.Errorf("set is active: %v", err)
+		}
+	}
+
+	stale, err := teams.GetByName(ctx, "backend")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if !stale.Members[1].IsActive {
+		t.Fatalf("the racing read should see the old state")
+	}
+
+	team, _ := teams.GetByName(ctx, "backend")
+	if team.Members[1].IsActive {
+		t.Fatalf("member u2 is active, the stale read was cached")
+	}
+}
+
+func TestCache_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	teams := New(time.Hour, newClock()).Teams(backend().teams())
+
+	team, _ := teams.GetByName(ctx, "backend")
+	team.Members[0].Name = "Mallory"
+	team.Members = team.Members[:1]
+
+	team, _ = teams.GetByName(ctx, "backend")
+	if len(team.Members) != 3 || team.Members[0].Name != "Alice" {
+		t.Fatalf("cached team was changed by a caller: %+v", team.Members)
+	}
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	db := backend()
+	users := New(time.Hour, newClock()).Users(db)
+
+	_, err := users.GetUserByID(ctx, "u9")
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != domain.ErrorCodeNotFound {
+		t.Fatalf("err = %v, want NOT_FOUND", err)
+	}
+
+	_ = db.SaveAll(ctx, []domain.User{{ID: "u9", Name: "Ivan", TeamName: "backend", IsActive: true}})
+	if _, err := users.GetUserByID(ctx, "u9"); err != nil {
+		t.Fatalf("get user after create: %v", err)
+	}
+}
+
+func TestCache_Stats(t *testing.T) {
+	ctx := context.Background()
+	c := New(time.Minute, newClock())
+	users := c.Users(backend())
+
+	_, _ = users.GetUserByID(ctx, "u1")
+	_, _ = users.GetUserByID(ctx, "u1")
+	_, _ = users.GetUserByID(ctx, "u1")
+	_, _ = users.SetIsActive(ctx, "u1", false)
+
+	stats := c.Stats()
+	if !stats.Enabled || stats.TTL != time.Minute {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	var got domain.CacheMetrics
+	for _, m := range stats.Caches {
+		if m.Name == "users" {
+			got = m
+		}
+	}
+	want := domain.CacheMetrics{Name: "users", Hits: 2, Misses: 1, Invalidations: 1, Entries: 0}
+	if got != want {
+		t.Fatalf("users metrics = %+v, want %+v", got, want)
+	}
+	if rate := got.HitRate(); rate < 0.66 || rate > 0.67 {
+		t.Fatalf("hit rate = %v, want 2/3", rate)
+	}
+}
+
+func TestCache_ConcurrentReadsAndWrites(t *testing.T) {
+	ctx := context.Background()
+	db := backend()
+	c := New(time.Hour, newClock())
+	teams, users := c.Teams(db.teams()), c.Users(db)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				if i%2 == 0 {
+					_, _ = users.SetIsActive(ctx, "u2", j%2 == 0)
+					continue
+				}
+				_, _ = teams.GetByName(ctx, "backend")
+				_, _ = users.ListReviewCandidates(ctx, "backend", "u1")
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The last write wins; whatever is cached has to agree with it.
+	want := db.users["u2"].IsActive
+	team, _ := teams.GetByName(ctx, "backend")
+	if team.Members[1].IsActive != want {
+		t.Fatalf("cached u2 active = %v, store has %v", team.Members[1].IsActive, want)
+	}
+	candidates, _ := users.ListReviewCandidates(ctx, "backend", "u1")
+	if slices.Contains(ids(candidates), "u2") != want {
+		t.Fatalf("candidates = %v, store has u2 active = %v", ids(candidates), want)
+	}
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/repository"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+	"github.com/ChernykhITMO/Avito/internal/testutil/pgtest"
+	"github.com/ChernykhITMO/Avito/internal/testutil/repotest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// The cached repositories must behave like the ones they wrap; the clock
+// never moves, so only invalidation keeps them fresh.
+func TestConformance_Cached(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		db := pgtest.NewDB(t)
+		c := New(time.Hour, newClock())
+		return repotest.Repos{
+			Repos: fixtures.Repos{
+				Teams: c.Teams(repository.NewPgxTeamRepository(db.Pool)),
+				Users: c.Users(repository.NewPgxUserRepository(db.Pool)),
+				PRs:   repository.NewPgxPRRepository(db.Pool),
+			},
+			Stats: repository.NewPgxStatsRepository(db.Pool),
+		}
+	})
+}
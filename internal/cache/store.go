@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+// sweepInterval is how often expired entries are dropped.
+const sweepInterval = time.Minute
+
+type entry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// store is a map with per-entry expiry.
+//
+// Every invalidation bumps the generation. A loader reads the generation
+// before going to the database and stores its result only if it has not
+// changed since, so a value read before a concurrent write can never be
+// cached after the write invalidated it.
+type store[K comparable, V any] struct {
+	name  string
+	ttl   time.Duration
+	clock service.Clock
+
+	mu            sync.Mutex
+	entries       map[K]entry[V]
+	gen           uint64
+	lastSweep     time.Time
+	hits, misses  uint64
+	invalidations uint64
+}
+
+func newStore[K comparable, V any](name string, ttl time.Duration, clock service.Clock) *store[K, V] {
+	return &store[K, V]{
+		name:      name,
+		ttl:       ttl,
+		clock:     clock,
+		entries:   make(map[K]entry[V]),
+		lastSweep: clock.Now(),
+	}
+}
+
+// get returns the cached value and, on a miss, the generation to pass to
+// put.
+func (s *store[K, V]) get(key K) (V, uint64, bool) {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if ok && now.Before(e.expires) {
+		s.hits++
+		return e.value, s.gen, true
+	}
+	if ok {
+		delete(s.entries, key)
+	}
+	s.misses++
+
+	var zero V
+	return zero, s.gen, false
+}
+
+func (s *store[K, V]) put(key K, value V, gen uint64) {
+	now := s.clock.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if gen != s.gen {
+		return
+	}
+	s.sweep(now)
+	s.entries[key] = entry[V]{value: value, expires: now.Add(s.ttl)}
+}
+
+func (s *store[K, V]) invalidate(keys ...K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range keys {
+		delete(s.entries, k)
+	}
+	s.gen++
+	s.invalidations++
+}
+
+func (s *store[K, V]) purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.entries)
+	s.gen++
+	s.invalidations++
+}
+
+func (s *store[K, V]) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for k, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+}
+
+func (s *store[K, V]) metrics() domain.CacheMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return domain.CacheMetrics{
+		Name:          s.name,
+		Hits:          s.hits,
+		Misses:        s.misses,
+		Invalidations: s.invalidations,
+		Entries:       len(s.entries),
+	}
+}
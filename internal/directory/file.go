@@ -0,0 +1,95 @@
+// Package directory reads the organisation chart from external directory
+// exports.
+package directory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// File is a directory source backed by a local file. Files ending in .ldif
+// are parsed as LDIF, anything else as a JSON org chart in the
+// /team/import format.
+type File struct {
+	path string
+}
+
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (f *File) String() string {
+	return f.path
+}
+
+// Load reads the file and returns the snapshot together with the SHA-256 of
+// its content, so callers can skip syncs when nothing changed.
+func (f *File) Load(_ context.Context) (domain.OrgSnapshot, string, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return domain.OrgSnapshot{}, "", fmt.Errorf("read directory file: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	version := hex.EncodeToString(sum[:])
+
+	var snapshot domain.OrgSnapshot
+	if strings.EqualFold(filepath.Ext(f.path), ".ldif") {
+		snapshot, err = parseLDIF(data)
+	} else {
+		snapshot, err = parseOrgChart(data)
+	}
+	if err != nil {
+		return domain.OrgSnapshot{}, "", fmt.Errorf("parse %s: %w", f.path, err)
+	}
+
+	return snapshot, version, nil
+}
+
+type orgChart struct {
+	Teams []struct {
+		TeamName string `json:"team_name"`
+		Members  []struct {
+			UserID   string `json:"user_id"`
+			Username string `json:"username"`
+			IsActive *bool  `json:"is_active"`
+		} `json:"members"`
+	} `json:"teams"`
+}
+
+// parseOrgChart decodes the JSON org chart; members without is_active are
+// active.
+func parseOrgChart(data []byte) (domain.OrgSnapshot, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var chart orgChart
+	if err := dec.Decode(&chart); err != nil {
+		return domain.OrgSnapshot{}, fmt.Errorf("decode org chart: %w", err)
+	}
+
+	snapshot := domain.OrgSnapshot{Teams: make([]domain.Team, 0, len(chart.Teams))}
+	for _, t := range chart.Teams {
+		team := domain.Team{Name: t.TeamName, Members: make([]domain.User, 0, len(t.Members))}
+		for _, m := range t.Members {
+			team.Members = append(team.Members, domain.User{
+				ID:       m.UserID,
+				Name:     m.Username,
+				TeamName: t.TeamName,
+				IsActive: m.IsActive == nil || *m.IsActive,
+			})
+		}
+		snapshot.Teams = append(snapshot.Teams, team)
+	}
+
+	return snapshot, nil
+}
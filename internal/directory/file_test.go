@@ -0,0 +1,120 @@
+package directory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+const testLDIF = `version: 1
+
+# teams
+dn: ou=backend,dc=example,dc=com
+objectClass: organizationalUnit
+ou: backend
+
+dn: ou=platform,dc=example,dc=com
+objectClass: organizationalUnit
+ou: platform
+
+dn: uid=u1,ou=backend,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: u1
+cn: Alice Smith
+displayName: Alice
+
+dn: uid=u2,ou=backend,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: u2
+cn:: 0JHQvtGA0LjRgQ==
+nsAccountLock: TRUE
+
+dn: uid=u3,ou=payments,dc=example,dc=com
+objectClass: inetOrgPerson
+uid: u3
+cn: Carol
+ ine
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestFile_LoadLDIF(t *testing.T) {
+	snapshot, version, err := NewFile(writeFile(t, "org.ldif", testLDIF)).Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version == "" {
+		t.Fatal("expected content version")
+	}
+
+	want := domain.OrgSnapshot{Teams: []domain.Team{
+		{Name: "backend", Members: []domain.User{
+			{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{ID: "u2", Name: "Борис", TeamName: "backend", IsActive: false},
+		}},
+		{Name: "platform", Members: []domain.User{}},
+		{Name: "payments", Members: []domain.User{
+			{ID: "u3", Name: "Caroline", TeamName: "payments", IsActive: true},
+		}},
+	}}
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Fatalf("unexpected snapshot:\n got %+v\nwant %+v", snapshot, want)
+	}
+}
+
+func TestFile_LoadJSON(t *testing.T) {
+	path := writeFile(t, "org.json", `{"teams":[{"team_name":"backend","members":[
+		{"user_id":"u1","username":"Alice"},
+		{"user_id":"u2","username":"Bob","is_active":false}
+	]}]}`)
+	f := NewFile(path)
+
+	snapshot, v1, err := f.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	members := snapshot.Teams[0].Members
+	if len(members) != 2 || !members[0].IsActive || members[1].IsActive || members[0].TeamName != "backend" {
+		t.Fatalf("unexpected members: %+v", members)
+	}
+
+	if _, v2, _ := f.Load(context.Background()); v2 != v1 {
+		t.Fatal("version must be stable for unchanged content")
+	}
+	if err := os.WriteFile(path, []byte(`{"teams":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, v3, _ := f.Load(context.Background()); v3 == v1 {
+		t.Fatal("version must change with content")
+	}
+}
+
+func TestFile_LoadErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown.json": `{"teams":[],"groups":[]}`,
+		"nou.ldif":     "dn: uid=u1,dc=example,dc=com\nuid: u1\n",
+		"change.ldif":  "dn: uid=u1,ou=backend,dc=example,dc=com\nchangetype: delete\n",
+		"badb64.ldif":  "dn: uid=u1,ou=backend,dc=example,dc=com\nuid: u1\ncn:: !!!\n",
+		"nocolon.ldif": "dn uid=u1\n",
+	} {
+		if _, _, err := NewFile(writeFile(t, name, content)).Load(context.Background()); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, _, err := NewFile(filepath.Join(t.TempDir(), "missing.json")).Load(context.Background()); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
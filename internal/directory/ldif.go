@@ -0,0 +1,168 @@
+package directory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// ldifEntry holds the attributes of one LDIF record; names are lower-cased.
+type ldifEntry map[string][]string
+
+func (e ldifEntry) first(names ...string) string {
+	for _, name := range names {
+		if v := e[name]; len(v) > 0 && v[0] != "" {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func (e ldifEntry) hasClass(class string) bool {
+	for _, c := range e["objectclass"] {
+		if strings.EqualFold(c, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseLDIF maps an LDIF export to teams:
+//
+//   - entries with objectClass organizationalUnit declare a team named by ou;
+//   - entries with uid are users; the team is the ou attribute or the first
+//     ou= component of the dn, the name is displayName or cn;
+//   - nsAccountLock: true marks a user inactive.
+//
+// Teams keep the order in which they first appear.
+func parseLDIF(data []byte) (domain.OrgSnapshot, error) {
+	entries, err := readLDIF(data)
+	if err != nil {
+		return domain.OrgSnapshot{}, err
+	}
+
+	var snapshot domain.OrgSnapshot
+	index := make(map[string]int)
+	team := func(name string) *domain.Team {
+		i, ok := index[name]
+		if !ok {
+			i = len(snapshot.Teams)
+			index[name] = i
+			snapshot.Teams = append(snapshot.Teams, domain.Team{Name: name, Members: []domain.User{}})
+		}
+		return &snapshot.Teams[i]
+	}
+
+	for _, e := range entries {
+		uid := e.first("uid")
+		if uid == "" {
+			if e.hasClass("organizationalUnit") {
+				if name := e.first("ou"); name != "" {
+					team(name)
+				}
+			}
+			continue
+		}
+
+		teamName := e.first("ou")
+		if teamName == "" {
+			teamName = ouFromDN(e.first("dn"))
+		}
+		if teamName == "" {
+			return domain.OrgSnapshot{}, fmt.Errorf("user %s: no ou in entry or dn", uid)
+		}
+
+		t := team(teamName)
+		t.Members = append(t.Members, domain.User{
+			ID:       uid,
+			Name:     e.first("displayname", "cn", "uid"),
+			TeamName: teamName,
+			IsActive: !strings.EqualFold(e.first("nsaccountlock"), "true"),
+		})
+	}
+
+	return snapshot, nil
+}
+
+// readLDIF splits the input into records, unfolding continuation lines and
+// decoding base64 ("attr:: value") attributes. Change records are not
+// supported.
+func readLDIF(data []byte) ([]ldifEntry, error) {
+	var (
+		entries []ldifEntry
+		current ldifEntry
+		lines   []string
+	)
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, " ") && len(lines) > 0:
+			lines[len(lines)-1] += line[1:]
+		case strings.HasPrefix(line, "#"):
+		default:
+			lines = append(lines, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read ldif: %w", err)
+	}
+
+	for n, line := range lines {
+		if line == "" {
+			if current != nil {
+				entries = append(entries, current)
+				current = nil
+			}
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("ldif line %d: missing ':'", n+1)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "version" && current == nil {
+			continue
+		}
+		if name == "changetype" {
+			return nil, fmt.Errorf("ldif line %d: change records are not supported", n+1)
+		}
+
+		if rest, ok := strings.CutPrefix(value, ":"); ok {
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, fmt.Errorf("ldif line %d: invalid base64: %w", n+1, err)
+			}
+			value = string(decoded)
+		} else {
+			value = strings.TrimSpace(value)
+		}
+
+		if current == nil {
+			current = make(ldifEntry)
+		}
+		current[name] = append(current[name], value)
+	}
+	if current != nil {
+		entries = append(entries, current)
+	}
+
+	return entries, nil
+}
+
+func ouFromDN(dn string) string {
+	for _, rdn := range strings.Split(dn, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(rdn), "=")
+		if ok && strings.EqualFold(name, "ou") {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ArchiveVersion is the archive format written by snapshots. Restore only
+// accepts archives of this version.
+const ArchiveVersion = 1
+
+// Archive is a full copy of the store used to move data between
+// environments. Assignments hold the whole reviewer history; the current
+// reviewers of each pull request are its open assignments. TeamName in
+// assignments and activity events is informational and ignored on restore.
+type Archive struct {
+	Version        int
+	CreatedAt      time.Time
+	Teams          []string
+	Users          []User
+	PullRequests   []PullRequest
+	Assignments    []AssignmentRecord
+	ActivityEvents []ActivityEvent
+}
+
+// Validate checks the archive version and that every reference (user team,
+// author, reviewer, assignment and event subject) points at an entity in
+// the archive.
+func (a *Archive) Validate() error {
+	if a.Version != ArchiveVersion {
+		return fmt.Errorf("unsupported archive version %d, want %d", a.Version, ArchiveVersion)
+	}
+
+	teams := make(map[string]struct{}, len(a.Teams))
+	for _, name := range a.Teams {
+		if name == "" {
+			return fmt.Errorf("empty team name")
+		}
+		if _, ok := teams[name]; ok {
+			return fmt.Errorf("duplicate team %q", name)
+		}
+		teams[name] = struct{}{}
+	}
+
+	users := make(map[string]struct{}, len(a.Users))
+	for _, u := range a.Users {
+		if u.ID == "" {
+			return fmt.Errorf("empty user id")
+		}
+		if _, ok := users[u.ID]; ok {
+			return fmt.Errorf("duplicate user %q", u.ID)
+		}
+		if _, ok := teams[u.TeamName]; !ok && u.TeamName != "" {
+			return fmt.Errorf("user %q: unknown team %q", u.ID, u.TeamName)
+		}
+		users[u.ID] = struct{}{}
+	}
+
+	prs := make(map[string]struct{}, len(a.PullRequests))
+	for _, pr := range a.PullRequests {
+		if pr.ID == "" {
+			return fmt.Errorf("empty pull request id")
+		}
+		if _, ok := prs[pr.ID]; ok {
+			return fmt.Errorf("duplicate pull request %q", pr.ID)
+		}
+		switch pr.Status {
+		case PRStatusDraft, PRStatusOpen, PRStatusMerged, PRStatusClosed:
+		default:
+			return fmt.Errorf("pull request %q: unknown status %q", pr.ID, pr.Status)
+		}
+		if _, ok := users[pr.AuthorID]; !ok {
+			return fmt.Errorf("pull request %q: unknown author %q", pr.ID, pr.AuthorID)
+		}
+		seen := make(map[string]struct{}, len(pr.Reviewers))
+		for _, r := range pr.Reviewers {
+			if _, ok := users[r]; !ok {
+				return fmt.Errorf("pull request %q: unknown reviewer %q", pr.ID, r)
+			}
+			if _, ok := seen[r]; ok {
+				return fmt.Errorf("pull request %q: duplicate reviewer %q", pr.ID, r)
+			}
+			seen[r] = struct{}{}
+		}
+		prs[pr.ID] = struct{}{}
+	}
+
+	for _, rec := range a.Assignments {
+		if _, ok := prs[rec.PullRequestID]; !ok {
+			return fmt.Errorf("assignment: unknown pull request %q", rec.PullRequestID)
+		}
+		if _, ok := users[rec.ReviewerID]; !ok {
+			return fmt.Errorf("assignment on %q: unknown reviewer %q", rec.PullRequestID, rec.ReviewerID)
+		}
+	}
+
+	for _, e := range a.ActivityEvents {
+		if _, ok := users[e.UserID]; !ok {
+			return fmt.Errorf("activity event: unknown user %q", e.UserID)
+		}
+	}
+
+	return nil
+}
+
+type ArchiveRepository interface {
+	// Dump reads every entity from a single consistent view of the store.
+	// Version and CreatedAt are left to the caller.
+	Dump(ctx context.Context) (*Archive, error)
+	// Restore loads the archive in one transaction and fails with
+	// ErrorCodeStoreNotEmpty unless the store holds no teams, users or pull
+	// requests.
+	Restore(ctx context.Context, archive *Archive) error
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// CacheMetrics counts the lookups of one read-through cache since start.
+type CacheMetrics struct {
+	Name          string
+	Hits          uint64
+	Misses        uint64
+	Invalidations uint64
+	Entries       int
+}
+
+// HitRate is the share of lookups answered from the cache, 0 without any.
+func (m CacheMetrics) HitRate() float64 {
+	total := m.Hits + m.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(m.Hits) / float64(total)
+}
+
+// CacheStats describes the repository caches; Enabled is false when the
+// service runs without them.
+type CacheStats struct {
+	Enabled bool
+	TTL     time.Duration
+	Caches  []CacheMetrics
+}
@@ -7,12 +7,16 @@ import (
 type Code string
 
 const (
-	ErrorCodeTeamExists  Code = "TEAM_EXISTS"
-	ErrorCodePRExists    Code = "PR_EXISTS"
-	ErrorCodePRMerged    Code = "PR_MERGED"
-	ErrorCodeNotAssigned Code = "NOT_ASSIGNED"
-	ErrorCodeNoCandidate Code = "NO_CANDIDATE"
-	ErrorCodeNotFound    Code = "NOT_FOUND"
+	ErrorCodeTeamExists        Code = "TEAM_EXISTS"
+	ErrorCodePRExists          Code = "PR_EXISTS"
+	ErrorCodePRMerged          Code = "PR_MERGED"
+	ErrorCodeNotAssigned       Code = "NOT_ASSIGNED"
+	ErrorCodeNoCandidate       Code = "NO_CANDIDATE"
+	ErrorCodeNotFound          Code = "NOT_FOUND"
+	ErrorCodePRNotOpen         Code = "PR_NOT_OPEN"
+	ErrorCodeInvalidTransition Code = "INVALID_TRANSITION"
+	ErrorCodeStoreNotEmpty     Code = "STORE_NOT_EMPTY"
+	ErrorCodeBadRequest        Code = "BAD_REQUEST"
 )
 
 type Error struct {
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StoredResponse is a response recorded for replay under an idempotency key.
+type StoredResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyRecord is what an idempotency key currently holds. Response is
+// nil while the first request made with the key is still in flight.
+type IdempotencyRecord struct {
+	Fingerprint string
+	Response    *StoredResponse
+}
+
+// IdempotencyStore keeps the outcome of requests made with an idempotency
+// key until the key expires.
+type IdempotencyStore interface {
+	// Reserve claims key for a request with the given fingerprint for ttl.
+	// It returns nil if the key was free (or expired) and is now claimed,
+	// and the record held under the key otherwise.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, error)
+	// Complete stores the response of the request that reserved key.
+	Complete(ctx context.Context, key string, resp StoredResponse) error
+	// Release frees a reserved key so that the request can be retried.
+	Release(ctx context.Context, key string) error
+}
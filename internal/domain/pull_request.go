@@ -13,20 +13,41 @@ type PullRequest struct {
 	Reviewers []string
 	MergedAt  time.Time
 	CreatedAt time.Time
+	// ArchivedAt is set for merged pull requests moved to the archive.
+	ArchivedAt time.Time
 }
 
 type PRStatus string
 
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
 type PRRepository interface {
 	Create(ctx context.Context, request PullRequest) (*PullRequest, error)
 	Get(ctx context.Context, id string) (*PullRequest, error)
 	Update(ctx context.Context, id string, status PRStatus) error
+	// SetReviewers replaces the reviewers; reviewers that stay keep their
+	// assignment time. Concurrent calls for one pull request do not mix
+	// their reviewers.
 	SetReviewers(ctx context.Context, id string, reviewers []string) error
+	// ListByReviewer returns pull requests ordered by creation time and id.
 	ListByReviewer(ctx context.Context, reviewerID string) ([]PullRequest, error)
+	// ListReviewers returns reviewers ordered by assignment time and id.
 	ListReviewers(ctx context.Context, prID string) ([]string, error)
+	// ListOverdueReviews returns reviews ordered by assignment time.
+	ListOverdueReviews(ctx context.Context, assignedBefore time.Time, teamName string) ([]ReviewAssignment, error)
+
+	// Archive moves pull requests merged before mergedBefore, with their
+	// reviewers, to the archive and returns how many it moved. The other
+	// methods above do not see archived pull requests.
+	Archive(ctx context.Context, mergedBefore time.Time) (int, error)
+	// GetArchived returns an archived pull request with its reviewers.
+	GetArchived(ctx context.Context, id string) (*PullRequest, error)
+	// ListArchivedByReviewer returns archived pull requests ordered by merge
+	// time and id.
+	ListArchivedByReviewer(ctx context.Context, reviewerID string) ([]PullRequest, error)
 }
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// ReviewAssignment is a single reviewer assigned to an open pull request.
+type ReviewAssignment struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	ReviewerID      string
+	TeamName        string
+	AssignedAt      time.Time
+}
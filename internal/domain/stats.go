@@ -2,24 +2,150 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 type PRStats struct {
 	Total  int `json:"total"`
+	Draft  int `json:"draft"`
 	Open   int `json:"open"`
 	Merged int `json:"merged"`
+	Closed int `json:"closed"`
 }
 
 type UserAssignmentStat struct {
 	UserID string `json:"user_id"`
 	Count  int    `json:"count"`
 }
+
+type Granularity string
+
+const (
+	GranularityDay  Granularity = "day"
+	GranularityWeek Granularity = "week"
+)
+
+// StatsFilter narrows statistics to a team and a half-open [From, To) range.
+// Zero From or To leaves the range unbounded on that side.
+type StatsFilter struct {
+	TeamName    string
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+}
+
+// PRTimeline holds the lifecycle timestamps of a pull request. TeamName is the
+// author's team; MergedAt is zero for pull requests that are not merged.
+type PRTimeline struct {
+	ID        string
+	TeamName  string
+	CreatedAt time.Time
+	MergedAt  time.Time
+}
+
+// AssignmentRecord is one entry of the reviewer assignment history. TeamName is
+// the reviewer's team; UnassignedAt is set once the reviewer has been replaced.
+type AssignmentRecord struct {
+	PullRequestID string
+	ReviewerID    string
+	TeamName      string
+	AssignedAt    time.Time
+	UnassignedAt  time.Time
+}
+
+// ReviewerLoad is the number of open pull requests a reviewer is assigned to.
+type ReviewerLoad struct {
+	UserID   string
+	TeamName string
+	Open     int
+}
+
+// ActivityEvent records a change of a user's activity flag. TeamName is the
+// user's current team.
+type ActivityEvent struct {
+	UserID    string
+	TeamName  string
+	IsActive  bool
+	ChangedAt time.Time
+}
+
+type PeriodPRStats struct {
+	PeriodStart time.Time `json:"period_start"`
+	Opened      int       `json:"opened"`
+	Merged      int       `json:"merged"`
+}
+
+type PeriodAssignmentStat struct {
+	PeriodStart time.Time `json:"period_start"`
+	UserID      string    `json:"user_id"`
+	Count       int       `json:"count"`
+}
+
+type PeriodReassignmentStat struct {
+	PeriodStart   time.Time `json:"period_start"`
+	Assignments   int       `json:"assignments"`
+	Reassignments int       `json:"reassignments"`
+	Rate          float64   `json:"rate"`
+}
+
+type OpenLoadStat struct {
+	UserID string `json:"user_id"`
+	Open   int    `json:"open"`
+}
+
+type TeamStats struct {
+	TeamName                 string                   `json:"team_name,omitempty"`
+	PRsPerPeriod             []PeriodPRStats          `json:"prs_per_period"`
+	MedianTimeToMergeSeconds *float64                 `json:"median_time_to_merge_seconds"`
+	AssignmentsPerPeriod     []PeriodAssignmentStat   `json:"assignments_per_period"`
+	ReassignmentsPerPeriod   []PeriodReassignmentStat `json:"reassignments_per_period"`
+	OpenLoad                 []OpenLoadStat           `json:"open_load"`
+}
+
 type StatsResponse struct {
 	PRStats            PRStats              `json:"pr_stats"`
 	AssignmentsPerUser []UserAssignmentStat `json:"assignments_per_user"`
+	From               time.Time            `json:"from"`
+	To                 time.Time            `json:"to"`
+	Granularity        Granularity          `json:"granularity"`
+	Global             TeamStats            `json:"global"`
+	Teams              []TeamStats          `json:"teams"`
+}
+
+type MemberFairness struct {
+	UserID          string   `json:"user_id"`
+	Assignments     int      `json:"assignments"`
+	ActiveDays      float64  `json:"active_days"`
+	AssignmentShare float64  `json:"assignment_share"`
+	ActiveShare     float64  `json:"active_share"`
+	Ratio           *float64 `json:"ratio"`
+	Outlier         string   `json:"outlier,omitempty"`
+}
+
+// TeamFairness describes how evenly review assignments were spread over the
+// members of a team relative to the time each member was active.
+type TeamFairness struct {
+	TeamName         string           `json:"team_name"`
+	From             time.Time        `json:"from"`
+	To               time.Time        `json:"to"`
+	TotalAssignments int              `json:"total_assignments"`
+	Gini             float64          `json:"gini"`
+	MaxMinRatio      *float64         `json:"max_min_ratio"`
+	Members          []MemberFairness `json:"members"`
 }
 
 type StatsRepository interface {
 	GetPRStats(ctx context.Context) (PRStats, error)
+	// GetAssignmentsStats counts live and archived review assignments per
+	// reviewer, ordered by reviewer id.
 	GetAssignmentsStats(ctx context.Context) ([]UserAssignmentStat, error)
+	ListPRTimelines(ctx context.Context, filter StatsFilter) ([]PRTimeline, error)
+	ListAssignments(ctx context.Context, filter StatsFilter) ([]AssignmentRecord, error)
+	// EachAssignment streams the assignment history in assignment order,
+	// stopping at the first error returned by fn.
+	EachAssignment(ctx context.Context, filter StatsFilter, fn func(AssignmentRecord) error) error
+	ListOpenLoad(ctx context.Context, teamName string) ([]ReviewerLoad, error)
+	// ListActivityEvents returns activity changes recorded before the given
+	// time, ordered by user and time.
+	ListActivityEvents(ctx context.Context, teamName string, before time.Time) ([]ActivityEvent, error)
 }
@@ -1,13 +1,114 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type Team struct {
 	Name    string
 	Members []User
 }
 
+// OrgSnapshot is the desired state of the whole organisation: every team
+// with its full member list. Users missing from the snapshot are
+// deactivated on import.
+type OrgSnapshot struct {
+	Teams []Team
+}
+
+// Validate checks that team names and user ids are set and unique across
+// the snapshot.
+func (s OrgSnapshot) Validate() error {
+	teams := make(map[string]struct{}, len(s.Teams))
+	users := make(map[string]string)
+
+	for _, team := range s.Teams {
+		if team.Name == "" {
+			return fmt.Errorf("team_name is required")
+		}
+		if _, ok := teams[team.Name]; ok {
+			return fmt.Errorf("duplicate team %q", team.Name)
+		}
+		teams[team.Name] = struct{}{}
+
+		for _, m := range team.Members {
+			if m.ID == "" || m.Name == "" {
+				return fmt.Errorf("team %q: user_id and username are required", team.Name)
+			}
+			if other, ok := users[m.ID]; ok {
+				return fmt.Errorf("user %q listed in teams %q and %q", m.ID, other, team.Name)
+			}
+			users[m.ID] = team.Name
+		}
+	}
+	return nil
+}
+
+// UserMove is a user that changes team. User holds the state after the
+// move.
+type UserMove struct {
+	User     User
+	FromTeam string
+}
+
+// OrgDiff lists the changes an import makes to the current state. A user
+// that is both moved and renamed or (de)activated appears in MovedUsers and
+// UpdatedUsers; all entries hold the resulting user state.
+type OrgDiff struct {
+	CreatedTeams     []string
+	CreatedUsers     []User
+	UpdatedUsers     []User
+	MovedUsers       []UserMove
+	DeactivatedUsers []User
+}
+
+func (d OrgDiff) Empty() bool {
+	return len(d.CreatedTeams) == 0 &&
+		len(d.CreatedUsers) == 0 &&
+		len(d.UpdatedUsers) == 0 &&
+		len(d.MovedUsers) == 0 &&
+		len(d.DeactivatedUsers) == 0
+}
+
+// Upserts returns every user whose row has to be written, each once.
+func (d OrgDiff) Upserts() []User {
+	seen := make(map[string]struct{})
+	var users []User
+
+	add := func(u User) {
+		if _, ok := seen[u.ID]; ok {
+			return
+		}
+		seen[u.ID] = struct{}{}
+		users = append(users, u)
+	}
+
+	for _, u := range d.CreatedUsers {
+		add(u)
+	}
+	for _, u := range d.UpdatedUsers {
+		add(u)
+	}
+	for _, m := range d.MovedUsers {
+		add(m.User)
+	}
+	for _, u := range d.DeactivatedUsers {
+		add(u)
+	}
+	return users
+}
+
 type TeamRepository interface {
 	Create(ctx context.Context, team *Team) error
+	// GetByName returns the team with its members ordered by id.
 	GetByName(ctx context.Context, name string) (*Team, error)
+	// List returns all teams ordered by name with members ordered by id.
+	List(ctx context.Context) ([]Team, error)
+	// ApplyDiff writes the diff in a single transaction.
+	ApplyDiff(ctx context.Context, diff OrgDiff) error
+	// Delete hides the team and deletes its members in one transaction. It
+	// returns the team with the members it deleted. Creating the team again
+	// brings it back without them.
+	Delete(ctx context.Context, name string) (*Team, error)
 }
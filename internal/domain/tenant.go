@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+)
+
+// DefaultTenant owns the data of single-tenant deployments, including
+// everything stored before tenants were introduced.
+const DefaultTenant = "default"
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ValidTenantID reports whether id can name a tenant: lowercase letters,
+// digits, '-' and '_', at most 63 characters.
+func ValidTenantID(id string) bool {
+	return tenantIDPattern.MatchString(id)
+}
+
+type tenantKey struct{}
+
+// WithTenant scopes ctx to a tenant; repositories only see and write the
+// data of the tenant in their context.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the tenant ctx is scoped to, DefaultTenant if none.
+func TenantFrom(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok && tenant != "" {
+		return tenant
+	}
+	return DefaultTenant
+}
+
+type TenantRepository interface {
+	// Ensure registers the tenants that do not exist yet.
+	Ensure(ctx context.Context, tenants []string) error
+	// List returns every tenant ordered by id.
+	List(ctx context.Context) ([]string, error)
+}
@@ -1,17 +1,34 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// PurgedUserName replaces the name of a user whose data was purged.
+const PurgedUserName = "deleted user"
 
 type User struct {
 	ID       string
 	Name     string
 	TeamName string
 	IsActive bool
+	// DeletedAt is set for soft-deleted users, which reads no longer return.
+	DeletedAt time.Time
 }
 
+// UserRepository only sees users that are not deleted, except for Purge.
+// Saving a deleted user brings it back.
 type UserRepository interface {
 	SaveAll(ctx context.Context, users []User) error
 	GetUserByID(ctx context.Context, id string) (*User, error)
 	SetIsActive(ctx context.Context, id string, active bool) (*User, error)
+	// ListReviewCandidates returns active members of the team other than
+	// excludeUserID, ordered by id.
 	ListReviewCandidates(ctx context.Context, teamName, excludeUserID string) ([]User, error)
+	// Delete deactivates the user and hides it; its history is kept.
+	Delete(ctx context.Context, id string) (*User, error)
+	// Purge replaces the name of a user, deleted or not, with
+	// PurgedUserName and deletes it.
+	Purge(ctx context.Context, id string) (*User, error)
 }
@@ -0,0 +1,7 @@
+// Package grpcapi contains the protobuf messages and gRPC service stubs
+// generated from proto/reviewer/v1/reviewer.proto. Regenerate with
+// `go generate ./internal/grpcapi` (requires protoc, protoc-gen-go and
+// protoc-gen-go-grpc in PATH); do not edit the *.pb.go files by hand.
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/ChernykhITMO/Avito --go-grpc_out=../.. --go-grpc_opt=module=github.com/ChernykhITMO/Avito reviewer/v1/reviewer.proto
@@ -0,0 +1,215 @@
+package grpcserver
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/grpcapi"
+)
+
+var statusToProto = map[domain.PRStatus]grpcapi.PullRequestStatus{
+	domain.PRStatusDraft:  grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_DRAFT,
+	domain.PRStatusOpen:   grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+	domain.PRStatusMerged: grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+	domain.PRStatusClosed: grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_CLOSED,
+}
+
+func teamToProto(team domain.Team) *grpcapi.Team {
+	members := make([]*grpcapi.TeamMember, 0, len(team.Members))
+
+	for _, m := range team.Members {
+		members = append(members, &grpcapi.TeamMember{
+			UserId:   m.ID,
+			Username: m.Name,
+			IsActive: m.IsActive,
+		})
+	}
+
+	return &grpcapi.Team{
+		TeamName: team.Name,
+		Members:  members,
+	}
+}
+
+func teamFromProto(team *grpcapi.Team) domain.Team {
+	members := make([]domain.User, 0, len(team.GetMembers()))
+
+	for _, m := range team.GetMembers() {
+		members = append(members, domain.User{
+			ID:       m.GetUserId(),
+			Name:     m.GetUsername(),
+			TeamName: team.GetTeamName(),
+			IsActive: m.GetIsActive(),
+		})
+	}
+
+	return domain.Team{
+		Name:    team.GetTeamName(),
+		Members: members,
+	}
+}
+
+func userToProto(user domain.User) *grpcapi.User {
+	return &grpcapi.User{
+		UserId:   user.ID,
+		Username: user.Name,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
+
+func pullRequestToProto(pr domain.PullRequest) *grpcapi.PullRequest {
+	return &grpcapi.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            statusToProto[pr.Status],
+		AssignedReviewers: append([]string(nil), pr.Reviewers...),
+		CreatedAt:         timestampOrNil(pr.CreatedAt),
+		MergedAt:          timestampOrNil(pr.MergedAt),
+	}
+}
+
+func filterFromProto(f *grpcapi.StatsFilter) domain.StatsFilter {
+	filter := domain.StatsFilter{TeamName: f.GetTeamName()}
+	if f.GetFrom() != nil {
+		filter.From = f.GetFrom().AsTime()
+	}
+	if f.GetTo() != nil {
+		filter.To = f.GetTo().AsTime()
+	}
+	return filter
+}
+
+func granularityFromProto(g grpcapi.Granularity) domain.Granularity {
+	switch g {
+	case grpcapi.Granularity_GRANULARITY_DAY:
+		return domain.GranularityDay
+	case grpcapi.Granularity_GRANULARITY_WEEK:
+		return domain.GranularityWeek
+	default:
+		return ""
+	}
+}
+
+func granularityToProto(g domain.Granularity) grpcapi.Granularity {
+	switch g {
+	case domain.GranularityDay:
+		return grpcapi.Granularity_GRANULARITY_DAY
+	case domain.GranularityWeek:
+		return grpcapi.Granularity_GRANULARITY_WEEK
+	default:
+		return grpcapi.Granularity_GRANULARITY_UNSPECIFIED
+	}
+}
+
+func statsToProto(s domain.StatsResponse) *grpcapi.GetStatsResponse {
+	resp := &grpcapi.GetStatsResponse{
+		PrStats: &grpcapi.PRStats{
+			Total:  int64(s.PRStats.Total),
+			Draft:  int64(s.PRStats.Draft),
+			Open:   int64(s.PRStats.Open),
+			Merged: int64(s.PRStats.Merged),
+			Closed: int64(s.PRStats.Closed),
+		},
+		From:        timestamppb.New(s.From),
+		To:          timestamppb.New(s.To),
+		Granularity: granularityToProto(s.Granularity),
+		Global:      teamStatsToProto(s.Global),
+	}
+
+	for _, a := range s.AssignmentsPerUser {
+		resp.AssignmentsPerUser = append(resp.AssignmentsPerUser, &grpcapi.UserAssignmentStat{
+			UserId: a.UserID,
+			Count:  int64(a.Count),
+		})
+	}
+	for _, t := range s.Teams {
+		resp.Teams = append(resp.Teams, teamStatsToProto(t))
+	}
+
+	return resp
+}
+
+func teamStatsToProto(s domain.TeamStats) *grpcapi.TeamStats {
+	stats := &grpcapi.TeamStats{
+		TeamName:                 s.TeamName,
+		MedianTimeToMergeSeconds: s.MedianTimeToMergeSeconds,
+	}
+
+	for _, p := range s.PRsPerPeriod {
+		stats.PrsPerPeriod = append(stats.PrsPerPeriod, &grpcapi.PeriodPRStats{
+			PeriodStart: timestamppb.New(p.PeriodStart),
+			Opened:      int64(p.Opened),
+			Merged:      int64(p.Merged),
+		})
+	}
+	for _, a := range s.AssignmentsPerPeriod {
+		stats.AssignmentsPerPeriod = append(stats.AssignmentsPerPeriod, &grpcapi.PeriodAssignmentStat{
+			PeriodStart: timestamppb.New(a.PeriodStart),
+			UserId:      a.UserID,
+			Count:       int64(a.Count),
+		})
+	}
+	for _, r := range s.ReassignmentsPerPeriod {
+		stats.ReassignmentsPerPeriod = append(stats.ReassignmentsPerPeriod, &grpcapi.PeriodReassignmentStat{
+			PeriodStart:   timestamppb.New(r.PeriodStart),
+			Assignments:   int64(r.Assignments),
+			Reassignments: int64(r.Reassignments),
+			Rate:          r.Rate,
+		})
+	}
+	for _, l := range s.OpenLoad {
+		stats.OpenLoad = append(stats.OpenLoad, &grpcapi.OpenLoadStat{
+			UserId: l.UserID,
+			Open:   int64(l.Open),
+		})
+	}
+
+	return stats
+}
+
+func teamFairnessToProto(f domain.TeamFairness) *grpcapi.TeamFairness {
+	team := &grpcapi.TeamFairness{
+		TeamName:         f.TeamName,
+		From:             timestamppb.New(f.From),
+		To:               timestamppb.New(f.To),
+		TotalAssignments: int64(f.TotalAssignments),
+		Gini:             f.Gini,
+		MaxMinRatio:      f.MaxMinRatio,
+	}
+
+	for _, m := range f.Members {
+		team.Members = append(team.Members, &grpcapi.MemberFairness{
+			UserId:          m.UserID,
+			Assignments:     int64(m.Assignments),
+			ActiveDays:      m.ActiveDays,
+			AssignmentShare: m.AssignmentShare,
+			ActiveShare:     m.ActiveShare,
+			Ratio:           m.Ratio,
+			Outlier:         m.Outlier,
+		})
+	}
+
+	return team
+}
+
+func assignmentRecordToProto(a domain.AssignmentRecord) *grpcapi.AssignmentRecord {
+	return &grpcapi.AssignmentRecord{
+		PullRequestId: a.PullRequestID,
+		ReviewerId:    a.ReviewerID,
+		TeamName:      a.TeamName,
+		AssignedAt:    timestamppb.New(a.AssignedAt),
+		UnassignedAt:  timestampOrNil(a.UnassignedAt),
+	}
+}
+
+// timestampOrNil maps the zero time to an unset field.
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,62 @@
+package grpcserver
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// errorDomain identifies the service in google.rpc.ErrorInfo details.
+const errorDomain = "reviewer.avito"
+
+func codeByDomainCode(code domain.Code) codes.Code {
+	switch code {
+	case domain.ErrorCodeNotFound:
+		return codes.NotFound
+	case domain.ErrorCodeTeamExists:
+		return codes.AlreadyExists
+	case domain.ErrorCodePRExists:
+		return codes.AlreadyExists
+	case domain.ErrorCodePRMerged:
+		return codes.FailedPrecondition
+	case domain.ErrorCodeNotAssigned:
+		return codes.FailedPrecondition
+	case domain.ErrorCodeNoCandidate:
+		return codes.FailedPrecondition
+	case domain.ErrorCodePRNotOpen:
+		return codes.FailedPrecondition
+	case domain.ErrorCodeInvalidTransition:
+		return codes.FailedPrecondition
+	case domain.ErrorCodeBadRequest:
+		return codes.InvalidArgument
+	default:
+		return codes.Internal
+	}
+}
+
+// toStatus converts a service error to a gRPC status. Domain errors keep
+// their code in an ErrorInfo detail; anything else is reported as Internal
+// without leaking the cause.
+func toStatus(err error) error {
+	var derr *domain.Error
+	if !errors.As(err, &derr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	st := status.New(codeByDomainCode(derr.Code), derr.Message)
+	if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(derr.Code),
+		Domain: errorDomain,
+	}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
@@ -0,0 +1,91 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/grpcapi"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type pullRequestServer struct {
+	grpcapi.UnimplementedPullRequestServiceServer
+	serv service.PullRequestService
+}
+
+var _ grpcapi.PullRequestServiceServer = (*pullRequestServer)(nil)
+
+func (s *pullRequestServer) CreatePullRequest(ctx context.Context, req *grpcapi.CreatePullRequestRequest) (*grpcapi.PullRequestResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetPullRequestName() == "" || req.GetAuthorId() == "" {
+		return nil, invalidArgument("pull_request_id, pull_request_name and author_id are required")
+	}
+
+	create := s.serv.Create
+	if req.GetDraft() {
+		create = s.serv.CreateDraft
+	}
+
+	pr, err := create(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &grpcapi.PullRequestResponse{Pr: pullRequestToProto(*pr)}, nil
+}
+
+func (s *pullRequestServer) MarkReady(ctx context.Context, req *grpcapi.PullRequestIdRequest) (*grpcapi.PullRequestResponse, error) {
+	return byID(ctx, req, s.serv.MarkReady)
+}
+
+func (s *pullRequestServer) Merge(ctx context.Context, req *grpcapi.PullRequestIdRequest) (*grpcapi.PullRequestResponse, error) {
+	return byID(ctx, req, s.serv.Merge)
+}
+
+func (s *pullRequestServer) Close(ctx context.Context, req *grpcapi.PullRequestIdRequest) (*grpcapi.PullRequestResponse, error) {
+	return byID(ctx, req, s.serv.Close)
+}
+
+func (s *pullRequestServer) Reopen(ctx context.Context, req *grpcapi.PullRequestIdRequest) (*grpcapi.PullRequestResponse, error) {
+	return byID(ctx, req, s.serv.Reopen)
+}
+
+func (s *pullRequestServer) GetPullRequest(ctx context.Context, req *grpcapi.PullRequestIdRequest) (*grpcapi.PullRequestResponse, error) {
+	return byID(ctx, req, func(ctx context.Context, id string) (*domain.PullRequest, error) {
+		return s.serv.Get(ctx, id, false)
+	})
+}
+
+// byID serves the RPCs that take a PullRequestIdRequest and return the
+// resulting pull request.
+func byID(
+	ctx context.Context,
+	req *grpcapi.PullRequestIdRequest,
+	change func(ctx context.Context, id string) (*domain.PullRequest, error),
+) (*grpcapi.PullRequestResponse, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, invalidArgument("pull_request_id is required")
+	}
+
+	pr, err := change(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &grpcapi.PullRequestResponse{Pr: pullRequestToProto(*pr)}, nil
+}
+
+func (s *pullRequestServer) ReassignReviewer(ctx context.Context, req *grpcapi.ReassignReviewerRequest) (*grpcapi.ReassignReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, invalidArgument("pull_request_id and old_user_id are required")
+	}
+
+	pr, replacedBy, err := s.serv.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &grpcapi.ReassignReviewerResponse{
+		Pr:         pullRequestToProto(*pr),
+		ReplacedBy: replacedBy,
+	}, nil
+}
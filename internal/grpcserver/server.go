@@ -0,0 +1,89 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ChernykhITMO/Avito/internal/grpcapi"
+	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/tenant"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+type Deps struct {
+	TeamService        service.TeamService
+	UserService        service.UserService
+	PullRequestService service.PullRequestService
+	StatsService       service.StatsService
+
+	// Tenants resolves the tenant of every call; nil serves everything as
+	// the default tenant.
+	Tenants *tenant.Resolver
+}
+
+type Server struct {
+	addr string
+	grpc *grpc.Server
+}
+
+func New(addr string, deps Deps) *Server {
+	var opts []grpc.ServerOption
+	if deps.Tenants != nil {
+		opts = append(opts,
+			grpc.UnaryInterceptor(tenantInterceptor(deps.Tenants)),
+			grpc.StreamInterceptor(tenantStreamInterceptor(deps.Tenants)),
+		)
+	}
+	s := grpc.NewServer(opts...)
+
+	grpcapi.RegisterTeamServiceServer(s, &teamServer{serv: deps.TeamService})
+	grpcapi.RegisterUserServiceServer(s, &userServer{serv: deps.UserService})
+	grpcapi.RegisterPullRequestServiceServer(s, &pullRequestServer{serv: deps.PullRequestService})
+	grpcapi.RegisterStatsServiceServer(s, &statsServer{serv: deps.StatsService})
+
+	return &Server{
+		addr: addr,
+		grpc: s,
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", s.addr, err)
+	}
+	return s.Serve(ctx, lis)
+}
+
+// Serve accepts connections on lis until ctx is cancelled, then stops
+// gracefully, cutting off in-flight calls after shutdownTimeout.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+
+		done := make(chan struct{})
+		go func() {
+			s.grpc.GracefulStop()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(shutdownTimeout):
+			s.grpc.Stop()
+		}
+	}()
+
+	if err := s.grpc.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	<-stopped
+	return nil
+}
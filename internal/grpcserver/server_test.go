@@ -0,0 +1,379 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/grpcapi"
+	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/tenant"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+)
+
+type teamServiceStub struct {
+	service.TeamService
+}
+
+func (teamServiceStub) CreateTeam(ctx context.Context, name string, members []domain.User) (*domain.Team, error) {
+	if name == "exists" {
+		return nil, domain.NewError(domain.ErrorCodeTeamExists, "team_name already exists")
+	}
+	return &domain.Team{Name: name, Members: members}, nil
+}
+
+type prServiceStub struct {
+	service.PullRequestService
+	draft bool
+}
+
+func (s *prServiceStub) CreateDraft(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
+	s.draft = true
+	return &domain.PullRequest{ID: id, Name: name, AuthorID: authorID, Status: domain.PRStatusDraft}, nil
+}
+
+func (s *prServiceStub) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+}
+
+func (s *prServiceStub) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+	return nil, "", domain.NewError(domain.ErrorCodePRMerged, "cannot reassign on merged PR")
+}
+
+type statsServiceStub struct {
+	service.StatsService
+	gotFilter domain.StatsFilter
+	gotTenant string
+}
+
+func (s *statsServiceStub) GetStats(ctx context.Context, f domain.StatsFilter) (domain.StatsResponse, error) {
+	s.gotFilter = f
+	s.gotTenant = domain.TenantFrom(ctx)
+	median := 90.0
+	return domain.StatsResponse{
+		PRStats:     domain.PRStats{Total: 2, Open: 1, Merged: 1},
+		From:        f.From,
+		To:          f.To,
+		Granularity: f.Granularity,
+		Global:      domain.TeamStats{MedianTimeToMergeSeconds: &median},
+	}, nil
+}
+
+func (s *statsServiceStub) ExportAssignments(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	at := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	for _, r := range []domain.AssignmentRecord{
+		{PullRequestID: "pr1", ReviewerID: "u2", TeamName: "backend", AssignedAt: at, UnassignedAt: at.Add(time.Hour)},
+		{PullRequestID: "pr1", ReviewerID: "u3", TeamName: "backend", AssignedAt: at.Add(time.Hour)},
+	} {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startServer(t *testing.T, deps Deps) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	served := make(chan error, 1)
+	go func() {
+		served <- New("", deps).Serve(ctx, lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	})
+
+	return conn
+}
+
+func requireStatus(t *testing.T, err error, want codes.Code, reason domain.Code) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expected gRPC status, got %v", err)
+	}
+	if st.Code() != want {
+		t.Fatalf("expected %s, got %s (%s)", want, st.Code(), st.Message())
+	}
+	if reason == "" {
+		return
+	}
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.GetReason() != string(reason) {
+				t.Fatalf("expected reason %s, got %s", reason, info.GetReason())
+			}
+			return
+		}
+	}
+	t.Fatalf("no ErrorInfo detail in %v", st.Details())
+}
+
+func TestTeamService_CreateTeam(t *testing.T) {
+	client := grpcapi.NewTeamServiceClient(startServer(t, Deps{TeamService: teamServiceStub{}}))
+	ctx := context.Background()
+
+	resp, err := client.CreateTeam(ctx, &grpcapi.CreateTeamRequest{Team: &grpcapi.Team{
+		TeamName: "backend",
+		Members:  []*grpcapi.TeamMember{{UserId: "u1", Username: "Alice", IsActive: true}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if resp.GetTeam().GetTeamName() != "backend" || len(resp.GetTeam().GetMembers()) != 1 {
+		t.Fatalf("unexpected team: %v", resp.GetTeam())
+	}
+
+	_, err = client.CreateTeam(ctx, &grpcapi.CreateTeamRequest{Team: &grpcapi.Team{TeamName: "exists"}})
+	requireStatus(t, err, codes.AlreadyExists, domain.ErrorCodeTeamExists)
+
+	_, err = client.CreateTeam(ctx, &grpcapi.CreateTeamRequest{})
+	requireStatus(t, err, codes.InvalidArgument, "")
+}
+
+func TestPullRequestService(t *testing.T) {
+	prs := &prServiceStub{}
+	client := grpcapi.NewPullRequestServiceClient(startServer(t, Deps{PullRequestService: prs}))
+	ctx := context.Background()
+
+	resp, err := client.CreatePullRequest(ctx, &grpcapi.CreatePullRequestRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Add search",
+		AuthorId:        "u1",
+		Draft:           true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !prs.draft || resp.GetPr().GetStatus() != grpcapi.PullRequestStatus_PULL_REQUEST_STATUS_DRAFT {
+		t.Fatalf("expected draft PR, got %v", resp.GetPr())
+	}
+	if resp.GetPr().GetMergedAt() != nil {
+		t.Fatalf("merged_at must be unset for unmerged PR")
+	}
+
+	_, err = client.Merge(ctx, &grpcapi.PullRequestIdRequest{PullRequestId: "pr404"})
+	requireStatus(t, err, codes.NotFound, domain.ErrorCodeNotFound)
+
+	_, err = client.ReassignReviewer(ctx, &grpcapi.ReassignReviewerRequest{PullRequestId: "pr1", OldUserId: "u2"})
+	requireStatus(t, err, codes.FailedPrecondition, domain.ErrorCodePRMerged)
+
+	_, err = client.Close(ctx, &grpcapi.PullRequestIdRequest{})
+	requireStatus(t, err, codes.InvalidArgument, "")
+}
+
+func TestStatsService_GetStats(t *testing.T) {
+	stats := &statsServiceStub{}
+	client := grpcapi.NewStatsServiceClient(startServer(t, Deps{StatsService: stats}))
+
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	resp, err := client.GetStats(context.Background(), &grpcapi.GetStatsRequest{
+		Filter:      &grpcapi.StatsFilter{TeamName: "backend", From: timestamppb.New(from), To: timestamppb.New(to)},
+		Granularity: grpcapi.Granularity_GRANULARITY_WEEK,
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	want := domain.StatsFilter{TeamName: "backend", From: from, To: to, Granularity: domain.GranularityWeek}
+	if stats.gotFilter != want {
+		t.Fatalf("expected filter %+v, got %+v", want, stats.gotFilter)
+	}
+	if resp.GetPrStats().GetTotal() != 2 || resp.GetGlobal().GetMedianTimeToMergeSeconds() != 90 {
+		t.Fatalf("unexpected stats: %v", resp)
+	}
+
+	_, err = client.GetStats(context.Background(), &grpcapi.GetStatsRequest{
+		Filter: &grpcapi.StatsFilter{From: timestamppb.New(to), To: timestamppb.New(from)},
+	})
+	requireStatus(t, err, codes.InvalidArgument, "")
+}
+
+func TestStatsService_ExportAssignments(t *testing.T) {
+	client := grpcapi.NewStatsServiceClient(startServer(t, Deps{StatsService: &statsServiceStub{}}))
+
+	stream, err := client.ExportAssignments(context.Background(), &grpcapi.StatsFilter{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	var got []*grpcapi.AssignmentRecord
+	for {
+		rec, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+		got = append(got, rec)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(got))
+	}
+	if got[0].GetUnassignedAt() == nil || got[1].GetUnassignedAt() != nil {
+		t.Fatalf("unassigned_at must only be set for replaced reviewers: %v", got)
+	}
+}
+
+func TestToStatus_NonDomainErrorIsInternal(t *testing.T) {
+	err := toStatus(errors.New("connection refused"))
+	requireStatus(t, err, codes.Internal, "")
+
+	if st, _ := status.FromError(err); st.Message() == "connection refused" {
+		t.Fatalf("internal error details must not leak to clients")
+	}
+}
+
+func TestTenantInterceptor(t *testing.T) {
+	resolver, err := tenant.New(tenant.Config{
+		Header: tenant.Header,
+		Tokens: map[string]string{"secret-pay": "payments"},
+		Known:  []string{"search"},
+	})
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+
+	stats := &statsServiceStub{}
+	client := grpcapi.NewStatsServiceClient(startServer(t, Deps{StatsService: stats, Tenants: resolver}))
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string
+	}{
+		{name: "token", md: metadata.Pairs("authorization", "Bearer secret-pay"), want: "payments"},
+		{name: "header", md: metadata.Pairs("x-tenant-id", "search"), want: "search"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+			if _, err := client.GetStats(ctx, &grpcapi.GetStatsRequest{}); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if stats.gotTenant != tt.want {
+				t.Fatalf("tenant = %q, want %q", stats.gotTenant, tt.want)
+			}
+		})
+	}
+
+	_, err = client.GetStats(context.Background(), &grpcapi.GetStatsRequest{})
+	requireStatus(t, err, codes.InvalidArgument, "")
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("x-tenant-id", "marketing"))
+	_, err = client.GetStats(ctx, &grpcapi.GetStatsRequest{})
+	requireStatus(t, err, codes.InvalidArgument, "")
+}
+
+func TestTenantInterceptor_Stream(t *testing.T) {
+	resolver, err := tenant.New(tenant.Config{
+		Header: tenant.Header,
+		Tokens: map[string]string{"secret-pay": "payments", "secret-search": "search"},
+	})
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+
+	store := fakes.New()
+	ctx := context.Background()
+	if err := store.Tenants().Ensure(ctx, resolver.Tenants()); err != nil {
+		t.Fatalf("ensure tenants: %v", err)
+	}
+	payments := domain.WithTenant(ctx, "payments")
+	team := &domain.Team{Name: "backend", Members: []domain.User{
+		{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+	}}
+	if err := store.Teams().Create(payments, team); err != nil {
+		t.Fatalf("seed team: %v", err)
+	}
+	if err := store.Users().SaveAll(payments, team.Members); err != nil {
+		t.Fatalf("seed users: %v", err)
+	}
+	if _, err := store.PRs().Create(payments, domain.PullRequest{ID: "pr1", Name: "Add search", AuthorID: "u1", Status: domain.PRStatusOpen}); err != nil {
+		t.Fatalf("seed pr: %v", err)
+	}
+	if err := store.PRs().SetReviewers(payments, "pr1", []string{"u2"}); err != nil {
+		t.Fatalf("seed reviewers: %v", err)
+	}
+
+	stats := service.NewStatsService(store.Stats(), service.SystemClock())
+	client := grpcapi.NewStatsServiceClient(startServer(t, Deps{StatsService: stats, Tenants: resolver}))
+
+	export := func(md metadata.MD) ([]*grpcapi.AssignmentRecord, error) {
+		stream, err := client.ExportAssignments(metadata.NewOutgoingContext(ctx, md), &grpcapi.StatsFilter{})
+		if err != nil {
+			return nil, err
+		}
+		var records []*grpcapi.AssignmentRecord
+		for {
+			rec, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, rec)
+		}
+	}
+
+	records, err := export(metadata.Pairs("authorization", "Bearer secret-pay"))
+	if err != nil {
+		t.Fatalf("export payments: %v", err)
+	}
+	if len(records) != 1 || records[0].GetPullRequestId() != "pr1" {
+		t.Fatalf("unexpected payments records: %v", records)
+	}
+
+	records, err = export(metadata.Pairs("authorization", "Bearer secret-search"))
+	if err != nil {
+		t.Fatalf("export search: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("search received records of payments: %v", records)
+	}
+
+	_, err = export(nil)
+	requireStatus(t, err, codes.InvalidArgument, "")
+
+	_, err = export(metadata.Pairs("authorization", "Bearer stolen"))
+	requireStatus(t, err, codes.InvalidArgument, "")
+}
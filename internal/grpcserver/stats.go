@@ -0,0 +1,79 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/grpcapi"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type statsServer struct {
+	grpcapi.UnimplementedStatsServiceServer
+	serv service.StatsService
+}
+
+var _ grpcapi.StatsServiceServer = (*statsServer)(nil)
+
+func (s *statsServer) GetStats(ctx context.Context, req *grpcapi.GetStatsRequest) (*grpcapi.GetStatsResponse, error) {
+	filter, err := validFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	filter.Granularity = granularityFromProto(req.GetGranularity())
+
+	stats, err := s.serv.GetStats(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return statsToProto(stats), nil
+}
+
+func (s *statsServer) GetFairness(ctx context.Context, req *grpcapi.StatsFilter) (*grpcapi.GetFairnessResponse, error) {
+	filter, err := validFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := s.serv.GetFairness(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &grpcapi.GetFairnessResponse{
+		Teams: make([]*grpcapi.TeamFairness, 0, len(teams)),
+	}
+
+	for _, t := range teams {
+		resp.Teams = append(resp.Teams, teamFairnessToProto(t))
+	}
+
+	return resp, nil
+}
+
+func (s *statsServer) ExportAssignments(req *grpcapi.StatsFilter, stream grpc.ServerStreamingServer[grpcapi.AssignmentRecord]) error {
+	filter, err := validFilter(req)
+	if err != nil {
+		return err
+	}
+
+	err = s.serv.ExportAssignments(stream.Context(), filter, func(a domain.AssignmentRecord) error {
+		return stream.Send(assignmentRecordToProto(a))
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return nil
+}
+
+func validFilter(f *grpcapi.StatsFilter) (domain.StatsFilter, error) {
+	filter := filterFromProto(f)
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, invalidArgument("from must be before to")
+	}
+	return filter, nil
+}
@@ -0,0 +1,43 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/ChernykhITMO/Avito/internal/grpcapi"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type teamServer struct {
+	grpcapi.UnimplementedTeamServiceServer
+	serv service.TeamService
+}
+
+var _ grpcapi.TeamServiceServer = (*teamServer)(nil)
+
+func (s *teamServer) CreateTeam(ctx context.Context, req *grpcapi.CreateTeamRequest) (*grpcapi.CreateTeamResponse, error) {
+	if req.GetTeam().GetTeamName() == "" {
+		return nil, invalidArgument("team.team_name is required")
+	}
+
+	team := teamFromProto(req.GetTeam())
+
+	created, err := s.serv.CreateTeam(ctx, team.Name, team.Members)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &grpcapi.CreateTeamResponse{Team: teamToProto(*created)}, nil
+}
+
+func (s *teamServer) GetTeam(ctx context.Context, req *grpcapi.GetTeamRequest) (*grpcapi.GetTeamResponse, error) {
+	if req.GetTeamName() == "" {
+		return nil, invalidArgument("team_name is required")
+	}
+
+	team, err := s.serv.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &grpcapi.GetTeamResponse{Team: teamToProto(*team)}, nil
+}
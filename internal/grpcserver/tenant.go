@@ -0,0 +1,68 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/tenant"
+)
+
+// tenantInterceptor scopes every unary call to the tenant named by the
+// bearer token in its "authorization" metadata or by the tenant header
+// metadata.
+func tenantInterceptor(r *tenant.Resolver) grpc.UnaryServerInterceptor {
+	header := strings.ToLower(r.Header())
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := withTenant(ctx, r, header)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// tenantStreamInterceptor does the same for streaming calls.
+func tenantStreamInterceptor(r *tenant.Resolver) grpc.StreamServerInterceptor {
+	header := strings.ToLower(r.Header())
+
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withTenant(ss.Context(), r, header)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &tenantStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// tenantStream hands the tenant-scoped context to stream handlers.
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context {
+	return s.ctx
+}
+
+func withTenant(ctx context.Context, r *tenant.Resolver, header string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var token, name string
+	if v := md.Get("authorization"); len(v) > 0 {
+		token, _ = strings.CutPrefix(v[0], "Bearer ")
+	}
+	if v := md.Get(header); header != "" && len(v) > 0 {
+		name = v[0]
+	}
+
+	id, err := r.Resolve(token, name)
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	return domain.WithTenant(ctx, id), nil
+}
@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/ChernykhITMO/Avito/internal/grpcapi"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type userServer struct {
+	grpcapi.UnimplementedUserServiceServer
+	serv service.UserService
+}
+
+var _ grpcapi.UserServiceServer = (*userServer)(nil)
+
+func (s *userServer) SetIsActive(ctx context.Context, req *grpcapi.SetIsActiveRequest) (*grpcapi.SetIsActiveResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	user, err := s.serv.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &grpcapi.SetIsActiveResponse{User: userToProto(*user)}, nil
+}
+
+func (s *userServer) GetUserReviews(ctx context.Context, req *grpcapi.GetUserReviewsRequest) (*grpcapi.GetUserReviewsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, invalidArgument("user_id is required")
+	}
+
+	prs, err := s.serv.GetUserReviewPRs(ctx, req.GetUserId(), false)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &grpcapi.GetUserReviewsResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: make([]*grpcapi.PullRequest, 0, len(prs)),
+	}
+
+	for _, pr := range prs {
+		short := pullRequestToProto(pr)
+		short.AssignedReviewers = nil
+		resp.PullRequests = append(resp.PullRequests, short)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type AdminHandler struct {
+	serv service.AdminService
+}
+
+func NewAdminHandler(serv service.AdminService) *AdminHandler {
+	return &AdminHandler{
+		serv: serv,
+	}
+}
+
+func (h *AdminHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	archive, err := h.serv.Snapshot(r.Context())
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		log.Printf("admin snapshot: %v", err)
+		writeInternal(w)
+		return
+	}
+
+	filename := fmt.Sprintf("snapshot-%s.json", archive.CreatedAt.Format("20060102T150405Z"))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	writeJSON(w, http.StatusOK, archiveToAPI(*archive))
+}
+
+func (h *AdminHandler) RestoreSnapshot(w http.ResponseWriter, r *http.Request, _ api.RestoreSnapshotParams) {
+	var req api.Archive
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+
+	archive := archiveFromAPI(req)
+	if err := h.serv.Restore(r.Context(), archive); err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		log.Printf("admin restore: %v", err)
+		writeInternal(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, api.RestoreResult{
+		Teams:          len(archive.Teams),
+		Users:          len(archive.Users),
+		PullRequests:   len(archive.PullRequests),
+		Assignments:    len(archive.Assignments),
+		ActivityEvents: len(archive.ActivityEvents),
+	})
+}
+
+func (h *AdminHandler) GetCacheStats(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, cacheStatsToAPI(h.serv.CacheStats()))
+}
@@ -0,0 +1,592 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/idempotency"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+const specPath = "../../task/openapi.yaml"
+
+var contractTime = time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+
+type teamServiceFake struct{}
+
+func (teamServiceFake) CreateTeam(ctx context.Context, name string, members []domain.User) (*domain.Team, error) {
+	if name == "exists" {
+		return nil, domain.NewError(domain.ErrorCodeTeamExists, "team_name already exists")
+	}
+	return &domain.Team{Name: name, Members: members}, nil
+}
+
+func (teamServiceFake) GetTeam(ctx context.Context, name string) (*domain.Team, error) {
+	if name == "missing" {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+	}
+	return &domain.Team{Name: name, Members: []domain.User{{ID: "u1", Name: "Alice", TeamName: name, IsActive: true}}}, nil
+}
+
+func (teamServiceFake) ImportTeams(ctx context.Context, snapshot domain.OrgSnapshot, dryRun bool) (domain.OrgDiff, error) {
+	if err := snapshot.Validate(); err != nil {
+		return domain.OrgDiff{}, domain.NewError(domain.ErrorCodeBadRequest, err.Error())
+	}
+	var diff domain.OrgDiff
+	for _, team := range snapshot.Teams {
+		diff.CreatedTeams = append(diff.CreatedTeams, team.Name)
+		diff.CreatedUsers = append(diff.CreatedUsers, team.Members...)
+	}
+	diff.MovedUsers = []domain.UserMove{{User: domain.User{ID: "u2", Name: "Bob", TeamName: "payments", IsActive: true}, FromTeam: "backend"}}
+	return diff, nil
+}
+
+func (teamServiceFake) ExportTeams(ctx context.Context) (domain.OrgSnapshot, error) {
+	return domain.OrgSnapshot{Teams: []domain.Team{
+		{Name: "backend", Members: []domain.User{{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true}}},
+		{Name: "empty"},
+	}}, nil
+}
+
+func (teamServiceFake) DeleteTeam(ctx context.Context, name string) (*domain.Team, error) {
+	if name == "missing" {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+	}
+	return &domain.Team{Name: name, Members: []domain.User{
+		{ID: "u1", Name: "Alice", TeamName: name, DeletedAt: contractTime},
+	}}, nil
+}
+
+type userServiceFake struct{}
+
+func (userServiceFake) SetIsActive(ctx context.Context, userID string, active bool) (*domain.User, error) {
+	if userID == "missing" {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	return &domain.User{ID: userID, Name: "Bob", TeamName: "backend", IsActive: active}, nil
+}
+
+func (userServiceFake) GetUserReviewPRs(ctx context.Context, userID string, includeArchived bool) ([]domain.PullRequest, error) {
+	if userID == "missing" {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	if userID == "idle" {
+		return nil, nil
+	}
+	prs := []domain.PullRequest{{ID: "pr1", Name: "Add search", AuthorID: "u1", Status: domain.PRStatusOpen}}
+	if includeArchived {
+		prs = append(prs, domain.PullRequest{ID: "pr0", Name: "Init", AuthorID: "u1", Status: domain.PRStatusMerged, ArchivedAt: contractTime})
+	}
+	return prs, nil
+}
+
+func (userServiceFake) Delete(ctx context.Context, userID string) (*domain.User, error) {
+	if userID == "missing" {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	return &domain.User{ID: userID, Name: "Bob", TeamName: "backend", DeletedAt: contractTime}, nil
+}
+
+func (userServiceFake) Purge(ctx context.Context, userID string) (*domain.User, error) {
+	if userID == "missing" {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	return &domain.User{ID: userID, Name: domain.PurgedUserName, TeamName: "backend", DeletedAt: contractTime}, nil
+}
+
+// prServiceFake maps well-known pull request ids to domain errors so every
+// documented error response can be produced.
+type prServiceFake struct{}
+
+func (prServiceFake) result(id string, status domain.PRStatus) (*domain.PullRequest, error) {
+	switch id {
+	case "missing":
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+	case "exists":
+		return nil, domain.NewError(domain.ErrorCodePRExists, "PR id already exists")
+	case "conflict":
+		return nil, domain.NewError(domain.ErrorCodeInvalidTransition, "invalid transition")
+	}
+
+	pr := &domain.PullRequest{ID: id, Name: "Add search", AuthorID: "u1", Status: status, CreatedAt: contractTime}
+	switch status {
+	case domain.PRStatusOpen:
+		pr.Reviewers = []string{"u2", "u3"}
+	case domain.PRStatusMerged:
+		pr.Reviewers = []string{"u2"}
+		pr.MergedAt = contractTime.Add(time.Hour)
+	}
+	return pr, nil
+}
+
+func (f prServiceFake) Create(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
+	return f.result(id, domain.PRStatusOpen)
+}
+
+func (f prServiceFake) CreateDraft(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
+	return f.result(id, domain.PRStatusDraft)
+}
+
+func (f prServiceFake) MarkReady(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return f.result(id, domain.PRStatusOpen)
+}
+
+func (f prServiceFake) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return f.result(id, domain.PRStatusMerged)
+}
+
+func (f prServiceFake) Close(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return f.result(id, domain.PRStatusClosed)
+}
+
+func (f prServiceFake) Reopen(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return f.result(id, domain.PRStatusOpen)
+}
+
+func (f prServiceFake) Get(ctx context.Context, id string, includeArchived bool) (*domain.PullRequest, error) {
+	if includeArchived && id == "archived" {
+		pr, err := f.result(id, domain.PRStatusMerged)
+		if err != nil {
+			return nil, err
+		}
+		pr.ArchivedAt = contractTime.Add(24 * time.Hour)
+		return pr, nil
+	}
+	return f.result(id, domain.PRStatusOpen)
+}
+
+func (f prServiceFake) ArchiveMerged(ctx context.Context, mergedBefore time.Time) (int, error) {
+	return 0, nil
+}
+
+func (f prServiceFake) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+	if oldReviewerID == "stranger" {
+		return nil, "", domain.NewError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+	}
+	pr, err := f.result(prID, domain.PRStatusOpen)
+	if err != nil {
+		return nil, "", err
+	}
+	return pr, "u3", nil
+}
+
+type statsServiceFake struct{}
+
+func (statsServiceFake) GetStats(ctx context.Context, f domain.StatsFilter) (domain.StatsResponse, error) {
+	median := 3600.0
+	team := domain.TeamStats{
+		TeamName:                 "backend",
+		PRsPerPeriod:             []domain.PeriodPRStats{{PeriodStart: contractTime, Opened: 2, Merged: 1}},
+		MedianTimeToMergeSeconds: &median,
+		AssignmentsPerPeriod:     []domain.PeriodAssignmentStat{{PeriodStart: contractTime, UserID: "u2", Count: 1}},
+		ReassignmentsPerPeriod:   []domain.PeriodReassignmentStat{{PeriodStart: contractTime, Assignments: 2, Reassignments: 1, Rate: 0.5}},
+		OpenLoad:                 []domain.OpenLoadStat{{UserID: "u2", Open: 1}},
+	}
+
+	return domain.StatsResponse{
+		PRStats:            domain.PRStats{Total: 3, Open: 2, Merged: 1},
+		AssignmentsPerUser: []domain.UserAssignmentStat{{UserID: "u2", Count: 1}},
+		From:               contractTime.AddDate(0, 0, -1),
+		To:                 contractTime,
+		Granularity:        domain.GranularityDay,
+		// The global series is left empty to check that nil slices are
+		// rendered as empty arrays.
+		Global: domain.TeamStats{},
+		Teams:  []domain.TeamStats{team},
+	}, nil
+}
+
+func (statsServiceFake) ExportAssignments(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	records := []domain.AssignmentRecord{
+		{PullRequestID: "pr1", ReviewerID: "u2", TeamName: "backend", AssignedAt: contractTime, UnassignedAt: contractTime.Add(time.Hour)},
+		{PullRequestID: "pr1", ReviewerID: "u3", TeamName: "backend", AssignedAt: contractTime.Add(time.Hour)},
+	}
+	for _, r := range records {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (statsServiceFake) GetFairness(ctx context.Context, f domain.StatsFilter) ([]domain.TeamFairness, error) {
+	ratio := 1.2
+	return []domain.TeamFairness{{
+		TeamName:         "backend",
+		From:             contractTime.AddDate(0, 0, -1),
+		To:               contractTime,
+		TotalAssignments: 2,
+		Gini:             0.1,
+		Members: []domain.MemberFairness{
+			{UserID: "u2", Assignments: 2, ActiveDays: 1, AssignmentShare: 1, ActiveShare: 0.5, Ratio: &ratio, Outlier: "over"},
+			{UserID: "u3", ActiveDays: 1, ActiveShare: 0.5},
+		},
+	}}, nil
+}
+
+type adminServiceFake struct{}
+
+func (adminServiceFake) Snapshot(ctx context.Context) (*domain.Archive, error) {
+	return &domain.Archive{
+		Version:   domain.ArchiveVersion,
+		CreatedAt: contractTime,
+		Teams:     []string{"backend"},
+		Users: []domain.User{
+			{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		},
+		PullRequests: []domain.PullRequest{
+			{ID: "pr1", Name: "Add search", AuthorID: "u1", Status: domain.PRStatusOpen, Reviewers: []string{"u2"}, CreatedAt: contractTime},
+		},
+		Assignments:    []domain.AssignmentRecord{{PullRequestID: "pr1", ReviewerID: "u2", TeamName: "backend", AssignedAt: contractTime}},
+		ActivityEvents: []domain.ActivityEvent{{UserID: "u1", IsActive: true, ChangedAt: contractTime}},
+	}, nil
+}
+
+func (adminServiceFake) Restore(ctx context.Context, archive *domain.Archive) error {
+	if err := archive.Validate(); err != nil {
+		return domain.NewError(domain.ErrorCodeBadRequest, err.Error())
+	}
+	if len(archive.Teams) > 0 && archive.Teams[0] == "exists" {
+		return domain.NewError(domain.ErrorCodeStoreNotEmpty, "restore requires an empty store")
+	}
+	return nil
+}
+
+func (adminServiceFake) CacheStats() domain.CacheStats {
+	return domain.CacheStats{
+		Enabled: true,
+		TTL:     time.Minute,
+		Caches:  []domain.CacheMetrics{{Name: "teams", Hits: 3, Misses: 1, Invalidations: 1, Entries: 1}},
+	}
+}
+
+type healthServiceFake struct{}
+
+func (healthServiceFake) Readiness(ctx context.Context) service.Readiness {
+	return service.Readiness{Migrated: true}
+}
+
+func (healthServiceFake) MarkMigrated() {}
+
+func (healthServiceFake) Drain() {}
+
+type reviewServiceFake struct{}
+
+func (reviewServiceFake) ListOverdue(ctx context.Context, olderThan time.Duration, teamName string) ([]domain.ReviewAssignment, error) {
+	if teamName == "empty" {
+		return nil, nil
+	}
+	return []domain.ReviewAssignment{{
+		PullRequestID:   "pr1",
+		PullRequestName: "Add search",
+		AuthorID:        "u1",
+		ReviewerID:      "u2",
+		TeamName:        "backend",
+		AssignedAt:      contractTime,
+	}}, nil
+}
+
+// ndjsonDecoder validates every line of an NDJSON stream against the
+// response schema, which describes a single line.
+func ndjsonDecoder(body io.Reader, _ http.Header, schema *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (any, error) {
+	var last any
+	lines := 0
+
+	sc := bufio.NewScanner(body)
+	for sc.Scan() {
+		lines++
+		var v any
+		if err := json.Unmarshal(sc.Bytes(), &v); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lines, err)
+		}
+		if err := schema.Value.VisitJSON(v, openapi3.VisitAsResponse()); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lines, err)
+		}
+		last = v
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if lines == 0 {
+		return nil, fmt.Errorf("empty stream")
+	}
+	return last, nil
+}
+
+func loadSpecRouter(t testing.TB) (*openapi3.T, routers.Router) {
+	t.Helper()
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	// Match routes regardless of the host the test server listens on.
+	doc.Servers = nil
+
+	router, err := legacy.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("build router: %v", err)
+	}
+	return doc, router
+}
+
+// restoreBody is a minimal archive with one team, user and pull request.
+func restoreBody(team, author string) string {
+	return `{"version":1,"created_at":"2025-10-24T12:00:00Z","teams":["` + team + `"],` +
+		`"users":[{"user_id":"u1","username":"Alice","team_name":"` + team + `","is_active":true}],` +
+		`"pull_requests":[{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"` + author + `",` +
+		`"status":"MERGED","assigned_reviewers":[],"createdAt":"2025-10-24T12:00:00Z","mergedAt":"2025-10-24T13:00:00Z"}],` +
+		`"assignments":[],"activity_events":[{"user_id":"u1","is_active":true,"changed_at":"2025-10-24T12:00:00Z"}]}`
+}
+
+func TestContract(t *testing.T) {
+	openapi3filter.RegisterBodyDecoder(contentTypeNDJSON, ndjsonDecoder)
+	defer openapi3filter.UnregisterBodyDecoder(contentTypeNDJSON)
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.PlainBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("text/html")
+
+	doc, specRouter := loadSpecRouter(t)
+
+	mux := http.NewServeMux()
+	NewRouter(teamServiceFake{}, userServiceFake{}, prServiceFake{}, statsServiceFake{}, reviewServiceFake{}, adminServiceFake{}, healthServiceFake{}).Register(mux)
+	store := idempotency.NewMemoryStore(service.SystemClock())
+	srv := httptest.NewServer(idempotency.Middleware(store, time.Hour)(mux))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		// contentType defaults to application/json for requests with a body.
+		contentType    string
+		accept         string
+		idempotencyKey string
+		wantStatus     int
+		// badRequest marks requests that intentionally violate the spec, so
+		// only the response is validated.
+		badRequest bool
+	}{
+		{name: "add team", method: http.MethodPost, path: "/team/add",
+			body: `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`, wantStatus: http.StatusCreated},
+		{name: "add team exists", method: http.MethodPost, path: "/team/add",
+			body: `{"team_name":"exists","members":[]}`, wantStatus: http.StatusBadRequest},
+		{name: "add team malformed", method: http.MethodPost, path: "/team/add",
+			body: `{`, wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "get team", method: http.MethodGet, path: "/team/get?team_name=backend", wantStatus: http.StatusOK},
+		{name: "get team missing", method: http.MethodGet, path: "/team/get?team_name=missing", wantStatus: http.StatusNotFound},
+		{name: "get team without name", method: http.MethodGet, path: "/team/get", wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "import teams json", method: http.MethodPost, path: "/team/import",
+			body: `{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`, wantStatus: http.StatusOK},
+		{name: "import teams yaml dry run", method: http.MethodPost, path: "/team/import?dry_run=true", contentType: "application/yaml",
+			body: "teams:\n  - team_name: backend\n    members:\n      - user_id: u1\n        username: Alice\n        is_active: true\n", wantStatus: http.StatusOK},
+		{name: "import teams csv", method: http.MethodPost, path: "/team/import", contentType: "text/csv",
+			body: "team_name,user_id,username,is_active\nbackend,u1,Alice,true\npayments,u2,Bob,\n", wantStatus: http.StatusOK},
+		{name: "import teams duplicate user", method: http.MethodPost, path: "/team/import",
+			body:       `{"teams":[{"team_name":"a","members":[{"user_id":"u1","username":"Alice","is_active":true}]},{"team_name":"b","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`,
+			wantStatus: http.StatusBadRequest},
+		{name: "import teams bad csv", method: http.MethodPost, path: "/team/import", contentType: "text/csv",
+			body: "team,user\nbackend,u1\n", wantStatus: http.StatusBadRequest},
+		{name: "export teams", method: http.MethodGet, path: "/team/export", wantStatus: http.StatusOK},
+		{name: "export teams yaml", method: http.MethodGet, path: "/team/export", accept: "application/yaml", wantStatus: http.StatusOK},
+		{name: "export teams csv", method: http.MethodGet, path: "/team/export?format=csv", wantStatus: http.StatusOK},
+		{name: "export teams bad format", method: http.MethodGet, path: "/team/export?format=xml", wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "delete team", method: http.MethodPost, path: "/team/delete", body: `{"team_name":"backend"}`, wantStatus: http.StatusOK},
+		{name: "delete team missing", method: http.MethodPost, path: "/team/delete", body: `{"team_name":"missing"}`, wantStatus: http.StatusNotFound},
+		{name: "delete team without name", method: http.MethodPost, path: "/team/delete", body: `{}`, wantStatus: http.StatusBadRequest, badRequest: true},
+
+		{name: "set is active", method: http.MethodPost, path: "/users/setIsActive",
+			body: `{"user_id":"u2","is_active":false}`, wantStatus: http.StatusOK},
+		{name: "set is active missing", method: http.MethodPost, path: "/users/setIsActive",
+			body: `{"user_id":"missing","is_active":false}`, wantStatus: http.StatusNotFound},
+		{name: "set is active malformed", method: http.MethodPost, path: "/users/setIsActive",
+			body: `[]`, wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "user reviews", method: http.MethodGet, path: "/users/getReview?user_id=u2", wantStatus: http.StatusOK},
+		{name: "user reviews empty", method: http.MethodGet, path: "/users/getReview?user_id=idle", wantStatus: http.StatusOK},
+		{name: "user reviews missing", method: http.MethodGet, path: "/users/getReview?user_id=missing", wantStatus: http.StatusNotFound},
+		{name: "user reviews without id", method: http.MethodGet, path: "/users/getReview", wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "user reviews archived", method: http.MethodGet, path: "/users/getReview?user_id=u2&include_archived=true", wantStatus: http.StatusOK},
+		{name: "user reviews bad flag", method: http.MethodGet, path: "/users/getReview?user_id=u2&include_archived=maybe", wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "delete user", method: http.MethodPost, path: "/users/delete", body: `{"user_id":"u2"}`, wantStatus: http.StatusOK},
+		{name: "delete user missing", method: http.MethodPost, path: "/users/delete", body: `{"user_id":"missing"}`, wantStatus: http.StatusNotFound},
+		{name: "delete user malformed", method: http.MethodPost, path: "/users/delete", body: `{"user_id":`, wantStatus: http.StatusBadRequest, badRequest: true},
+
+		{name: "create pr", method: http.MethodPost, path: "/pullRequest/create",
+			body: `{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"u1"}`, wantStatus: http.StatusCreated},
+		{name: "create draft pr", method: http.MethodPost, path: "/pullRequest/create",
+			body: `{"pull_request_id":"pr2","pull_request_name":"Add search","author_id":"u1","draft":true}`, wantStatus: http.StatusCreated},
+		{name: "create pr exists", method: http.MethodPost, path: "/pullRequest/create",
+			body: `{"pull_request_id":"exists","pull_request_name":"Add search","author_id":"u1"}`, wantStatus: http.StatusConflict},
+		{name: "create pr missing author", method: http.MethodPost, path: "/pullRequest/create",
+			body: `{"pull_request_id":"missing","pull_request_name":"Add search","author_id":"u1"}`, wantStatus: http.StatusNotFound},
+		{name: "create pr incomplete", method: http.MethodPost, path: "/pullRequest/create",
+			body: `{"pull_request_id":"pr1"}`, wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "reassign with key", method: http.MethodPost, path: "/pullRequest/reassign", idempotencyKey: "retry-1",
+			body: `{"pull_request_id":"pr1","old_user_id":"u2"}`, wantStatus: http.StatusOK},
+		{name: "reassign replayed", method: http.MethodPost, path: "/pullRequest/reassign", idempotencyKey: "retry-1",
+			body: `{"pull_request_id":"pr1","old_user_id":"u2"}`, wantStatus: http.StatusOK},
+		{name: "reassign key reused", method: http.MethodPost, path: "/pullRequest/reassign", idempotencyKey: "retry-1",
+			body: `{"pull_request_id":"pr1","old_user_id":"u3"}`, wantStatus: http.StatusUnprocessableEntity},
+
+		{name: "get pr", method: http.MethodGet, path: "/pullRequest/get?pull_request_id=pr1", wantStatus: http.StatusOK},
+		{name: "get pr missing", method: http.MethodGet, path: "/pullRequest/get?pull_request_id=missing", wantStatus: http.StatusNotFound},
+		{name: "get pr without id", method: http.MethodGet, path: "/pullRequest/get", wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "get archived pr", method: http.MethodGet, path: "/pullRequest/get?pull_request_id=archived&include_archived=true", wantStatus: http.StatusOK},
+
+		{name: "merge", method: http.MethodPost, path: "/pullRequest/merge", body: `{"pull_request_id":"pr1"}`, wantStatus: http.StatusOK},
+		{name: "merge missing", method: http.MethodPost, path: "/pullRequest/merge", body: `{"pull_request_id":"missing"}`, wantStatus: http.StatusNotFound},
+		{name: "merge conflict", method: http.MethodPost, path: "/pullRequest/merge", body: `{"pull_request_id":"conflict"}`, wantStatus: http.StatusConflict},
+		{name: "merge malformed", method: http.MethodPost, path: "/pullRequest/merge", body: `{}`, wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "ready", method: http.MethodPost, path: "/pullRequest/ready", body: `{"pull_request_id":"pr2"}`, wantStatus: http.StatusOK},
+		{name: "ready conflict", method: http.MethodPost, path: "/pullRequest/ready", body: `{"pull_request_id":"conflict"}`, wantStatus: http.StatusConflict},
+		{name: "close", method: http.MethodPost, path: "/pullRequest/close", body: `{"pull_request_id":"pr1"}`, wantStatus: http.StatusOK},
+		{name: "close missing", method: http.MethodPost, path: "/pullRequest/close", body: `{"pull_request_id":"missing"}`, wantStatus: http.StatusNotFound},
+		{name: "reopen", method: http.MethodPost, path: "/pullRequest/reopen", body: `{"pull_request_id":"pr1"}`, wantStatus: http.StatusOK},
+		{name: "reopen conflict", method: http.MethodPost, path: "/pullRequest/reopen", body: `{"pull_request_id":"conflict"}`, wantStatus: http.StatusConflict},
+
+		{name: "reassign", method: http.MethodPost, path: "/pullRequest/reassign",
+			body: `{"pull_request_id":"pr1","old_user_id":"u2"}`, wantStatus: http.StatusOK},
+		{name: "reassign not assigned", method: http.MethodPost, path: "/pullRequest/reassign",
+			body: `{"pull_request_id":"pr1","old_user_id":"stranger"}`, wantStatus: http.StatusConflict},
+		{name: "reassign missing", method: http.MethodPost, path: "/pullRequest/reassign",
+			body: `{"pull_request_id":"missing","old_user_id":"u2"}`, wantStatus: http.StatusNotFound},
+		{name: "reassign incomplete", method: http.MethodPost, path: "/pullRequest/reassign",
+			body: `{"pull_request_id":"pr1"}`, wantStatus: http.StatusBadRequest, badRequest: true},
+
+		{name: "overdue", method: http.MethodGet, path: "/reviews/overdue?older_than=24h", wantStatus: http.StatusOK},
+		{name: "overdue empty", method: http.MethodGet, path: "/reviews/overdue?team_name=empty", wantStatus: http.StatusOK},
+		{name: "overdue bad duration", method: http.MethodGet, path: "/reviews/overdue?older_than=soon", wantStatus: http.StatusBadRequest},
+
+		{name: "stats", method: http.MethodGet, path: "/stats?granularity=week", wantStatus: http.StatusOK},
+		{name: "stats csv", method: http.MethodGet, path: "/stats?format=csv&table=assignments", wantStatus: http.StatusOK},
+		{name: "stats prometheus", method: http.MethodGet, path: "/stats", accept: "text/plain; version=0.0.4", wantStatus: http.StatusOK},
+		{name: "stats bad range", method: http.MethodGet, path: "/stats?from=2025-10-02&to=2025-10-01", wantStatus: http.StatusBadRequest},
+		{name: "stats bad format", method: http.MethodGet, path: "/stats?format=xml", wantStatus: http.StatusBadRequest, badRequest: true},
+		{name: "export assignments", method: http.MethodGet, path: "/stats/assignments/export?team_name=backend", wantStatus: http.StatusOK},
+		{name: "export bad range", method: http.MethodGet, path: "/stats/assignments/export?from=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "fairness", method: http.MethodGet, path: "/stats/fairness?from=2025-10-01", wantStatus: http.StatusOK},
+		{name: "fairness bad range", method: http.MethodGet, path: "/stats/fairness?to=tomorrow", wantStatus: http.StatusBadRequest},
+
+		{name: "snapshot", method: http.MethodGet, path: "/admin/snapshot", wantStatus: http.StatusOK},
+		{name: "cache stats", method: http.MethodGet, path: "/admin/cache", wantStatus: http.StatusOK},
+		{name: "restore", method: http.MethodPost, path: "/admin/restore", body: restoreBody("backend", "u1"), wantStatus: http.StatusOK},
+		{name: "restore not empty", method: http.MethodPost, path: "/admin/restore", body: restoreBody("exists", "u1"), wantStatus: http.StatusConflict},
+		{name: "restore unknown author", method: http.MethodPost, path: "/admin/restore", body: restoreBody("backend", "ghost"), wantStatus: http.StatusBadRequest},
+		{name: "purge user", method: http.MethodPost, path: "/admin/users/purge", body: `{"user_id":"u2"}`, wantStatus: http.StatusOK},
+		{name: "purge user missing", method: http.MethodPost, path: "/admin/users/purge", body: `{"user_id":"missing"}`, wantStatus: http.StatusNotFound},
+		{name: "purge user without id", method: http.MethodPost, path: "/admin/users/purge", body: `{"user_id":""}`, wantStatus: http.StatusBadRequest},
+		{name: "restore malformed", method: http.MethodPost, path: "/admin/restore", body: `{"version":"one"}`,
+			wantStatus: http.StatusBadRequest, badRequest: true},
+
+		{name: "health", method: http.MethodGet, path: "/health", wantStatus: http.StatusOK},
+		{name: "livez", method: http.MethodGet, path: "/livez", wantStatus: http.StatusOK},
+		{name: "readyz", method: http.MethodGet, path: "/readyz", wantStatus: http.StatusOK},
+		{name: "openapi yaml", method: http.MethodGet, path: "/openapi.yaml", wantStatus: http.StatusOK},
+		{name: "openapi json", method: http.MethodGet, path: "/openapi.json", wantStatus: http.StatusOK},
+		{name: "docs", method: http.MethodGet, path: "/docs", wantStatus: http.StatusOK},
+	}
+
+	covered := make(map[string]bool)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			req, err := http.NewRequestWithContext(ctx, tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("new request: %v", err)
+			}
+			if tt.body != "" {
+				contentType := tt.contentType
+				if contentType == "" {
+					contentType = "application/json"
+				}
+				req.Header.Set("Content-Type", contentType)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if tt.idempotencyKey != "" {
+				req.Header.Set(idempotency.Header, tt.idempotencyKey)
+			}
+
+			route, pathParams, err := specRouter.FindRoute(req)
+			if err != nil {
+				t.Fatalf("route not in spec: %v", err)
+			}
+			covered[route.Operation.OperationID] = true
+
+			reqInput := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			}
+			if !tt.badRequest {
+				if err := openapi3filter.ValidateRequest(ctx, reqInput); err != nil {
+					t.Fatalf("request does not match spec: %v", err)
+				}
+				req.Body = io.NopCloser(strings.NewReader(tt.body))
+			}
+
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("do request: %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, resp.StatusCode, body)
+			}
+
+			respInput := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: reqInput,
+				Status:                 resp.StatusCode,
+				Header:                 resp.Header,
+				Body:                   io.NopCloser(bytes.NewReader(body)),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+			}
+			if err := openapi3filter.ValidateResponse(ctx, respInput); err != nil {
+				t.Fatalf("response does not match spec: %v\n%s", err, body)
+			}
+		})
+	}
+
+	var missing []string
+	for _, item := range doc.Paths.Map() {
+		for _, op := range item.Operations() {
+			if !covered[op.OperationID] {
+				missing = append(missing, op.OperationID)
+			}
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		t.Fatalf("operations not covered by contract tests: %v", missing)
+	}
+}
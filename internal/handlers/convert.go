@@ -0,0 +1,376 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+func teamToAPI(team domain.Team) api.Team {
+	members := make([]api.TeamMember, 0, len(team.Members))
+
+	for _, m := range team.Members {
+		members = append(members, api.TeamMember{
+			UserId:   m.ID,
+			Username: m.Name,
+			IsActive: m.IsActive,
+		})
+	}
+
+	return api.Team{
+		TeamName: team.Name,
+		Members:  members,
+	}
+}
+
+func teamFromAPI(team api.Team) domain.Team {
+	members := make([]domain.User, 0, len(team.Members))
+
+	for _, m := range team.Members {
+		members = append(members, domain.User{
+			ID:       m.UserId,
+			Name:     m.Username,
+			TeamName: team.TeamName,
+			IsActive: m.IsActive,
+		})
+	}
+
+	return domain.Team{
+		Name:    team.TeamName,
+		Members: members,
+	}
+}
+
+func userToAPI(user domain.User) api.User {
+	return api.User{
+		UserId:    user.ID,
+		Username:  user.Name,
+		TeamName:  user.TeamName,
+		IsActive:  user.IsActive,
+		DeletedAt: optionalTime(user.DeletedAt),
+	}
+}
+
+func pullRequestToAPI(pr domain.PullRequest) api.PullRequest {
+	return api.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            api.PullRequestStatus(pr.Status),
+		AssignedReviewers: append([]string{}, pr.Reviewers...),
+		CreatedAt:         optionalTime(pr.CreatedAt),
+		MergedAt:          optionalTime(pr.MergedAt),
+		ArchivedAt:        optionalTime(pr.ArchivedAt),
+	}
+}
+
+func pullRequestToShortAPI(pr domain.PullRequest) api.PullRequestShort {
+	return api.PullRequestShort{
+		PullRequestId:   pr.ID,
+		PullRequestName: pr.Name,
+		AuthorId:        pr.AuthorID,
+		Status:          api.PullRequestShortStatus(pr.Status),
+		ArchivedAt:      optionalTime(pr.ArchivedAt),
+	}
+}
+
+func reviewAssignmentToAPI(a domain.ReviewAssignment) api.ReviewAssignment {
+	return api.ReviewAssignment{
+		PullRequestId:   a.PullRequestID,
+		PullRequestName: a.PullRequestName,
+		AuthorId:        a.AuthorID,
+		ReviewerId:      a.ReviewerID,
+		TeamName:        a.TeamName,
+		AssignedAt:      a.AssignedAt,
+	}
+}
+
+func assignmentRecordToAPI(a domain.AssignmentRecord) api.AssignmentRecord {
+	return api.AssignmentRecord{
+		PullRequestId: a.PullRequestID,
+		ReviewerId:    a.ReviewerID,
+		TeamName:      a.TeamName,
+		AssignedAt:    a.AssignedAt,
+		UnassignedAt:  optionalTime(a.UnassignedAt),
+	}
+}
+
+func statsToAPI(s domain.StatsResponse) api.StatsResponse {
+	resp := api.StatsResponse{
+		PrStats: api.PRStats{
+			Total:  s.PRStats.Total,
+			Draft:  s.PRStats.Draft,
+			Open:   s.PRStats.Open,
+			Merged: s.PRStats.Merged,
+			Closed: s.PRStats.Closed,
+		},
+		AssignmentsPerUser: make([]api.UserAssignmentStat, 0, len(s.AssignmentsPerUser)),
+		From:               s.From,
+		To:                 s.To,
+		Granularity:        api.StatsResponseGranularity(s.Granularity),
+		Global:             teamStatsToAPI(s.Global),
+		Teams:              make([]api.TeamStats, 0, len(s.Teams)),
+	}
+
+	for _, a := range s.AssignmentsPerUser {
+		resp.AssignmentsPerUser = append(resp.AssignmentsPerUser, api.UserAssignmentStat{UserId: a.UserID, Count: a.Count})
+	}
+	for _, t := range s.Teams {
+		resp.Teams = append(resp.Teams, teamStatsToAPI(t))
+	}
+
+	return resp
+}
+
+func teamStatsToAPI(s domain.TeamStats) api.TeamStats {
+	stats := api.TeamStats{
+		PrsPerPeriod:             make([]api.PeriodPRStats, 0, len(s.PRsPerPeriod)),
+		MedianTimeToMergeSeconds: s.MedianTimeToMergeSeconds,
+		AssignmentsPerPeriod:     make([]api.PeriodAssignmentStat, 0, len(s.AssignmentsPerPeriod)),
+		ReassignmentsPerPeriod:   make([]api.PeriodReassignmentStat, 0, len(s.ReassignmentsPerPeriod)),
+		OpenLoad:                 make([]api.OpenLoadStat, 0, len(s.OpenLoad)),
+	}
+	if s.TeamName != "" {
+		name := s.TeamName
+		stats.TeamName = &name
+	}
+
+	for _, p := range s.PRsPerPeriod {
+		stats.PrsPerPeriod = append(stats.PrsPerPeriod, api.PeriodPRStats{
+			PeriodStart: p.PeriodStart,
+			Opened:      p.Opened,
+			Merged:      p.Merged,
+		})
+	}
+	for _, a := range s.AssignmentsPerPeriod {
+		stats.AssignmentsPerPeriod = append(stats.AssignmentsPerPeriod, api.PeriodAssignmentStat{
+			PeriodStart: a.PeriodStart,
+			UserId:      a.UserID,
+			Count:       a.Count,
+		})
+	}
+	for _, r := range s.ReassignmentsPerPeriod {
+		stats.ReassignmentsPerPeriod = append(stats.ReassignmentsPerPeriod, api.PeriodReassignmentStat{
+			PeriodStart:   r.PeriodStart,
+			Assignments:   r.Assignments,
+			Reassignments: r.Reassignments,
+			Rate:          r.Rate,
+		})
+	}
+	for _, l := range s.OpenLoad {
+		stats.OpenLoad = append(stats.OpenLoad, api.OpenLoadStat{UserId: l.UserID, Open: l.Open})
+	}
+
+	return stats
+}
+
+func teamFairnessToAPI(f domain.TeamFairness) api.TeamFairness {
+	team := api.TeamFairness{
+		TeamName:         f.TeamName,
+		From:             f.From,
+		To:               f.To,
+		TotalAssignments: f.TotalAssignments,
+		Gini:             f.Gini,
+		MaxMinRatio:      f.MaxMinRatio,
+		Members:          make([]api.MemberFairness, 0, len(f.Members)),
+	}
+
+	for _, m := range f.Members {
+		member := api.MemberFairness{
+			UserId:          m.UserID,
+			Assignments:     m.Assignments,
+			ActiveDays:      m.ActiveDays,
+			AssignmentShare: m.AssignmentShare,
+			ActiveShare:     m.ActiveShare,
+			Ratio:           m.Ratio,
+		}
+		if m.Outlier != "" {
+			outlier := api.MemberFairnessOutlier(m.Outlier)
+			member.Outlier = &outlier
+		}
+		team.Members = append(team.Members, member)
+	}
+
+	return team
+}
+
+// optionalTime maps the zero time to an absent value.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func snapshotToAPI(snapshot domain.OrgSnapshot) api.OrgSnapshot {
+	teams := make([]api.Team, 0, len(snapshot.Teams))
+	for _, t := range snapshot.Teams {
+		teams = append(teams, teamToAPI(t))
+	}
+	return api.OrgSnapshot{Teams: teams}
+}
+
+func snapshotFromAPI(snapshot api.OrgSnapshot) domain.OrgSnapshot {
+	teams := make([]domain.Team, 0, len(snapshot.Teams))
+	for _, t := range snapshot.Teams {
+		teams = append(teams, teamFromAPI(t))
+	}
+	return domain.OrgSnapshot{Teams: teams}
+}
+
+func importResultToAPI(diff domain.OrgDiff, dryRun bool) api.ImportResult {
+	res := api.ImportResult{
+		DryRun:           dryRun,
+		CreatedTeams:     append([]string{}, diff.CreatedTeams...),
+		CreatedUsers:     usersToAPI(diff.CreatedUsers),
+		UpdatedUsers:     usersToAPI(diff.UpdatedUsers),
+		MovedUsers:       make([]api.UserMove, 0, len(diff.MovedUsers)),
+		DeactivatedUsers: usersToAPI(diff.DeactivatedUsers),
+	}
+	for _, m := range diff.MovedUsers {
+		res.MovedUsers = append(res.MovedUsers, api.UserMove{
+			UserId:   m.User.ID,
+			Username: m.User.Name,
+			FromTeam: m.FromTeam,
+			ToTeam:   m.User.TeamName,
+			IsActive: m.User.IsActive,
+		})
+	}
+	return res
+}
+
+func usersToAPI(users []domain.User) []api.User {
+	out := make([]api.User, 0, len(users))
+	for _, u := range users {
+		out = append(out, userToAPI(u))
+	}
+	return out
+}
+
+func archiveToAPI(a domain.Archive) api.Archive {
+	res := api.Archive{
+		Version:        a.Version,
+		CreatedAt:      a.CreatedAt,
+		Teams:          append([]string{}, a.Teams...),
+		Users:          usersToAPI(a.Users),
+		PullRequests:   make([]api.PullRequest, 0, len(a.PullRequests)),
+		Assignments:    make([]api.AssignmentRecord, 0, len(a.Assignments)),
+		ActivityEvents: make([]api.ActivityEvent, 0, len(a.ActivityEvents)),
+	}
+	for _, pr := range a.PullRequests {
+		res.PullRequests = append(res.PullRequests, pullRequestToAPI(pr))
+	}
+	for _, rec := range a.Assignments {
+		res.Assignments = append(res.Assignments, assignmentRecordToAPI(rec))
+	}
+	for _, e := range a.ActivityEvents {
+		res.ActivityEvents = append(res.ActivityEvents, api.ActivityEvent{
+			UserId:    e.UserID,
+			IsActive:  e.IsActive,
+			ChangedAt: e.ChangedAt,
+		})
+	}
+	return res
+}
+
+func archiveFromAPI(a api.Archive) *domain.Archive {
+	res := &domain.Archive{
+		Version:        a.Version,
+		CreatedAt:      a.CreatedAt,
+		Teams:          a.Teams,
+		Users:          make([]domain.User, 0, len(a.Users)),
+		PullRequests:   make([]domain.PullRequest, 0, len(a.PullRequests)),
+		Assignments:    make([]domain.AssignmentRecord, 0, len(a.Assignments)),
+		ActivityEvents: make([]domain.ActivityEvent, 0, len(a.ActivityEvents)),
+	}
+	for _, u := range a.Users {
+		res.Users = append(res.Users, domain.User{
+			ID:        u.UserId,
+			Name:      u.Username,
+			TeamName:  u.TeamName,
+			IsActive:  u.IsActive,
+			DeletedAt: timeOrZero(u.DeletedAt),
+		})
+	}
+	for _, pr := range a.PullRequests {
+		res.PullRequests = append(res.PullRequests, domain.PullRequest{
+			ID:         pr.PullRequestId,
+			Name:       pr.PullRequestName,
+			AuthorID:   pr.AuthorId,
+			Status:     domain.PRStatus(pr.Status),
+			Reviewers:  pr.AssignedReviewers,
+			CreatedAt:  timeOrZero(pr.CreatedAt),
+			MergedAt:   timeOrZero(pr.MergedAt),
+			ArchivedAt: timeOrZero(pr.ArchivedAt),
+		})
+	}
+	for _, rec := range a.Assignments {
+		res.Assignments = append(res.Assignments, domain.AssignmentRecord{
+			PullRequestID: rec.PullRequestId,
+			ReviewerID:    rec.ReviewerId,
+			TeamName:      rec.TeamName,
+			AssignedAt:    rec.AssignedAt,
+			UnassignedAt:  timeOrZero(rec.UnassignedAt),
+		})
+	}
+	for _, e := range a.ActivityEvents {
+		res.ActivityEvents = append(res.ActivityEvents, domain.ActivityEvent{
+			UserID:    e.UserId,
+			IsActive:  e.IsActive,
+			ChangedAt: e.ChangedAt,
+		})
+	}
+	return res
+}
+
+func cacheStatsToAPI(s domain.CacheStats) api.CacheStats {
+	res := api.CacheStats{
+		Enabled:    s.Enabled,
+		TtlSeconds: float32(s.TTL.Seconds()),
+		Caches:     make([]api.CacheMetrics, 0, len(s.Caches)),
+	}
+	for _, m := range s.Caches {
+		res.Caches = append(res.Caches, api.CacheMetrics{
+			Name:          m.Name,
+			Hits:          int64(m.Hits),
+			Misses:        int64(m.Misses),
+			HitRate:       float32(m.HitRate()),
+			Invalidations: int64(m.Invalidations),
+			Entries:       m.Entries,
+		})
+	}
+	return res
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func readinessToAPI(r service.Readiness) api.Readiness {
+	var resp api.Readiness
+
+	resp.Status = api.Ready
+	if !r.Ready() {
+		resp.Status = api.NotReady
+	}
+
+	resp.Checks.Database = "ok"
+	if r.Database != nil {
+		resp.Checks.Database = r.Database.Error()
+	}
+	resp.Checks.Migrations = api.Pending
+	if r.Migrated {
+		resp.Checks.Migrations = api.Applied
+	}
+	resp.Checks.Lifecycle = api.Serving
+	if r.Draining {
+		resp.Checks.Lifecycle = api.Draining
+	}
+
+	return resp
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/ChernykhITMO/Avito/task"
+)
+
+//go:embed docs/index.html
+var docsPage []byte
+
+// DocsHandler serves the OpenAPI spec embedded into the binary and a
+// self-contained HTML page that renders it.
+type DocsHandler struct {
+	specs func() (specDocuments, error)
+}
+
+type specDocuments struct {
+	json []byte
+	yaml []byte
+}
+
+func NewDocsHandler() *DocsHandler {
+	return &DocsHandler{
+		specs: sync.OnceValues(loadSpecDocuments),
+	}
+}
+
+func (h *DocsHandler) GetOpenAPIJSON(w http.ResponseWriter, _ *http.Request) {
+	h.writeSpec(w, "application/json", func(d specDocuments) []byte { return d.json })
+}
+
+func (h *DocsHandler) GetOpenAPIYAML(w http.ResponseWriter, _ *http.Request) {
+	h.writeSpec(w, "application/yaml", func(d specDocuments) []byte { return d.yaml })
+}
+
+func (h *DocsHandler) GetDocs(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(docsPage)
+}
+
+func (h *DocsHandler) writeSpec(w http.ResponseWriter, contentType string, pick func(specDocuments) []byte) {
+	docs, err := h.specs()
+	if err != nil {
+		log.Printf("load embedded spec: %v", err)
+		writeInternal(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pick(docs))
+}
+
+// loadSpecDocuments serves the YAML spec verbatim and derives the JSON
+// variant from it.
+func loadSpecDocuments() (specDocuments, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(task.OpenAPI)
+	if err != nil {
+		return specDocuments{}, fmt.Errorf("load spec: %w", err)
+	}
+
+	raw, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return specDocuments{}, fmt.Errorf("marshal json: %w", err)
+	}
+
+	return specDocuments{json: raw, yaml: task.OpenAPI}, nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// recordingMux captures the patterns registered by Router.Register.
+type recordingMux struct {
+	*http.ServeMux
+	patterns []string
+}
+
+func (m *recordingMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.patterns = append(m.patterns, pattern)
+	m.ServeMux.HandleFunc(pattern, handler)
+}
+
+func newRecordingMux() *recordingMux {
+	return &recordingMux{ServeMux: http.NewServeMux()}
+}
+
+func servedSpec(t *testing.T, mux http.Handler) *openapi3.T {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json: status %d", rec.Code)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("parse served spec: %v", err)
+	}
+	return doc
+}
+
+func TestRouterRegistrationsMatchServedSpec(t *testing.T) {
+	mux := newRecordingMux()
+	NewRouter(nil, nil, nil, nil, nil, nil, nil).Register(mux)
+
+	doc := servedSpec(t, mux)
+
+	var documented []string
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(documented)
+	sort.Strings(mux.patterns)
+
+	if strings.Join(documented, "\n") != strings.Join(mux.patterns, "\n") {
+		t.Fatalf("registered routes and served spec differ:\nregistered:\n%s\n\ndocumented:\n%s",
+			strings.Join(mux.patterns, "\n"), strings.Join(documented, "\n"))
+	}
+}
+
+func TestServedYAMLMatchesJSON(t *testing.T) {
+	mux := http.NewServeMux()
+	NewRouter(nil, nil, nil, nil, nil, nil, nil).Register(mux)
+
+	get := func(path string) []byte {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", path, rec.Code)
+		}
+		return rec.Body.Bytes()
+	}
+
+	loader := openapi3.NewLoader()
+	fromJSON, err := loader.LoadFromData(get("/openapi.json"))
+	if err != nil {
+		t.Fatalf("load json: %v", err)
+	}
+	fromYAML, err := loader.LoadFromData(get("/openapi.yaml"))
+	if err != nil {
+		t.Fatalf("load yaml: %v", err)
+	}
+
+	a, _ := json.Marshal(fromJSON)
+	b, _ := json.Marshal(fromYAML)
+	if !bytes.Equal(a, b) {
+		t.Fatalf("openapi.yaml and openapi.json describe different documents")
+	}
+}
@@ -4,29 +4,32 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/ChernykhITMO/Avito/internal/api"
 	"github.com/ChernykhITMO/Avito/internal/domain"
 )
 
-type errorResponse struct {
-	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-	} `json:"error"`
-}
-
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	_ = json.NewEncoder(w).Encode(v)
 }
 
-func writeAPIError(w http.ResponseWriter, status int, code, msg string) {
-	var resp errorResponse
+func writeAPIError(w http.ResponseWriter, status int, code api.ErrorResponseErrorCode, msg string) {
+	var resp api.ErrorResponse
 	resp.Error.Code = code
 	resp.Error.Message = msg
 	writeJSON(w, status, resp)
 }
 
+func writeBadRequest(w http.ResponseWriter, msg string) {
+	writeAPIError(w, http.StatusBadRequest, api.BADREQUEST, msg)
+}
+
+// writeParamError reports query parameters rejected by the generated router.
+func writeParamError(w http.ResponseWriter, _ *http.Request, err error) {
+	writeBadRequest(w, err.Error())
+}
+
 func writeInternal(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusInternalServerError)
 }
@@ -45,6 +48,14 @@ func statusByDomainCode(code domain.Code) int {
 		return http.StatusConflict
 	case domain.ErrorCodeNoCandidate:
 		return http.StatusConflict
+	case domain.ErrorCodePRNotOpen:
+		return http.StatusConflict
+	case domain.ErrorCodeInvalidTransition:
+		return http.StatusConflict
+	case domain.ErrorCodeStoreNotEmpty:
+		return http.StatusConflict
+	case domain.ErrorCodeBadRequest:
+		return http.StatusBadRequest
 	default:
 		return http.StatusInternalServerError
 	}
@@ -52,5 +63,5 @@ func statusByDomainCode(code domain.Code) int {
 
 func writeDomainError(w http.ResponseWriter, derr *domain.Error) {
 	status := statusByDomainCode(derr.Code)
-	writeAPIError(w, status, string(derr.Code), derr.Message)
+	writeAPIError(w, status, api.ErrorResponseErrorCode(derr.Code), derr.Message)
 }
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+func TestStatusByDomainCode(t *testing.T) {
+	doc, _ := loadSpecRouter(t)
+	schema := doc.Components.Schemas["ErrorResponse"].Value
+
+	// Every code of internal/domain/errors.go is listed.
+	tests := []struct {
+		code domain.Code
+		want int
+	}{
+		{code: domain.ErrorCodeNotFound, want: http.StatusNotFound},
+		{code: domain.ErrorCodeTeamExists, want: http.StatusBadRequest},
+		{code: domain.ErrorCodePRExists, want: http.StatusConflict},
+		{code: domain.ErrorCodePRMerged, want: http.StatusConflict},
+		{code: domain.ErrorCodeNotAssigned, want: http.StatusConflict},
+		{code: domain.ErrorCodeNoCandidate, want: http.StatusConflict},
+		{code: domain.ErrorCodePRNotOpen, want: http.StatusConflict},
+		{code: domain.ErrorCodeInvalidTransition, want: http.StatusConflict},
+		{code: domain.ErrorCodeStoreNotEmpty, want: http.StatusConflict},
+		{code: domain.ErrorCodeBadRequest, want: http.StatusBadRequest},
+		{code: "SOMETHING_NEW", want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			if got := statusByDomainCode(tt.code); got != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, got)
+			}
+
+			rec := httptest.NewRecorder()
+			writeDomainError(rec, domain.NewError(tt.code, "details"))
+			if rec.Code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("expected JSON, got %q", ct)
+			}
+
+			var body struct {
+				Error struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Error.Code != string(tt.code) || body.Error.Message != "details" {
+				t.Fatalf("unexpected body: %s", rec.Body)
+			}
+
+			// Codes the spec does not know are only possible with a 500.
+			if tt.want == http.StatusInternalServerError {
+				return
+			}
+			var v any
+			_ = json.Unmarshal(rec.Body.Bytes(), &v)
+			if err := schema.VisitJSON(v, openapi3.VisitAsResponse()); err != nil {
+				t.Fatalf("body does not match ErrorResponse: %v", err)
+			}
+		})
+	}
+}
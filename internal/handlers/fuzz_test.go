@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+// FuzzJSONBody posts arbitrary bodies to every operation with a JSON
+// request body. Whatever the body, the handler must answer with a status
+// and a response the spec documents for the operation.
+func FuzzJSONBody(f *testing.F) {
+	doc, specRouter := loadSpecRouter(f)
+	paths := jsonBodyPaths(doc)
+	mux := newFakeMux()
+
+	seeds := []string{
+		"",
+		"{",
+		"null",
+		`{}`,
+		`{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`,
+		`{"team_name":"missing"}`,
+		`{"user_id":"u2","is_active":false}`,
+		`{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"u1","draft":true}`,
+		`{"pull_request_id":"conflict","old_user_id":"stranger"}`,
+		`{"teams":[{"team_name":"a","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`,
+		restoreBody("backend", "u1"),
+		restoreBody("exists", "ghost"),
+	}
+	for i := range paths {
+		for _, body := range seeds {
+			f.Add(uint8(i), body)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, route uint8, body string) {
+		path := paths[int(route)%len(paths)]
+		ctx := context.Background()
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		// A 500 means a body reached a service the fakes cannot handle.
+		if rec.Code == http.StatusInternalServerError {
+			t.Fatalf("%s: internal error for %q", path, body)
+		}
+
+		found, pathParams, err := specRouter.FindRoute(req)
+		if err != nil {
+			t.Fatalf("route not in spec: %v", err)
+		}
+		respInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      found,
+			},
+			Status:  rec.Code,
+			Header:  rec.Header(),
+			Body:    io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+			Options: &openapi3filter.Options{IncludeResponseStatus: true},
+		}
+		if err := openapi3filter.ValidateResponse(ctx, respInput); err != nil {
+			t.Fatalf("%s: response to %q does not match spec: %v\n%s", path, body, err, rec.Body)
+		}
+	})
+}
+
+// FuzzDecodeSnapshot feeds arbitrary bodies to the team import decoder in
+// every supported format. A decoded snapshot must survive a JSON round
+// trip unchanged, as the export returns it in that form.
+func FuzzDecodeSnapshot(f *testing.F) {
+	contentTypes := []string{"application/json", "application/yaml", "text/csv"}
+
+	seeds := []string{
+		"",
+		`{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`,
+		`{"teams":null}`,
+		"teams:\n  - team_name: backend\n    members:\n      - user_id: u1\n        username: Alice\n        is_active: false\n",
+		"teams: [[]]\n",
+		"team_name,user_id,username,is_active\nbackend,u1,Alice,true\npayments,u2,\"Bob, Jr.\",\n",
+		"team_name,user_id,username\nbackend,u1\n",
+	}
+	for i := range contentTypes {
+		for _, body := range seeds {
+			f.Add(uint8(i), body)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, format uint8, body string) {
+		contentType := contentTypes[int(format)%len(contentTypes)]
+
+		req := httptest.NewRequest(http.MethodPost, "/team/import", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", contentType)
+
+		snapshot, err := decodeSnapshot(req)
+		if err != nil || !utf8.ValidString(body) {
+			// JSON cannot carry invalid UTF-8, so only valid input is
+			// expected to round-trip.
+			return
+		}
+
+		encoded, err := json.Marshal(snapshot)
+		if err != nil {
+			t.Fatalf("encode %+v: %v", snapshot, err)
+		}
+
+		again := httptest.NewRequest(http.MethodPost, "/team/import", bytes.NewReader(encoded))
+		again.Header.Set("Content-Type", "application/json")
+		decoded, err := decodeSnapshot(again)
+		if err != nil {
+			t.Fatalf("decode %s: %v", encoded, err)
+		}
+		if !reflect.DeepEqual(decoded, snapshot) {
+			t.Fatalf("%s round trip mismatch:\n got %+v\nwant %+v", contentType, decoded, snapshot)
+		}
+	})
+}
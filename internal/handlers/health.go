@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type HealthHandler struct {
+	serv service.HealthService
+}
+
+func NewHealthHandler(serv service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		serv: serv,
+	}
+}
+
+// Livez only tells that the process serves HTTP, so a database outage does
+// not get the instance restarted.
+func (h *HealthHandler) Livez(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	readiness := h.serv.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !readiness.Ready() {
+		status = http.StatusServiceUnavailable
+		if readiness.Database != nil {
+			log.Printf("readiness: %v", readiness.Database)
+		}
+	}
+	writeJSON(w, status, readinessToAPI(readiness))
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type readinessStub struct {
+	healthServiceFake
+	readiness service.Readiness
+}
+
+func (s readinessStub) Readiness(ctx context.Context) service.Readiness {
+	return s.readiness
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		readiness  service.Readiness
+		wantStatus int
+		wantChecks string
+	}{
+		{name: "ready", readiness: service.Readiness{Migrated: true},
+			wantStatus: http.StatusOK, wantChecks: "ok/applied/serving"},
+		{name: "database down", readiness: service.Readiness{Database: errors.New("connection refused"), Migrated: true},
+			wantStatus: http.StatusServiceUnavailable, wantChecks: "connection refused/applied/serving"},
+		{name: "migrations pending", readiness: service.Readiness{},
+			wantStatus: http.StatusServiceUnavailable, wantChecks: "ok/pending/serving"},
+		{name: "draining", readiness: service.Readiness{Migrated: true, Draining: true},
+			wantStatus: http.StatusServiceUnavailable, wantChecks: "ok/applied/draining"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(readinessStub{readiness: tt.readiness})
+			rec := httptest.NewRecorder()
+
+			h.Readyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var resp api.Readiness
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			wantStatus := api.Ready
+			if tt.wantStatus != http.StatusOK {
+				wantStatus = api.NotReady
+			}
+			got := resp.Checks.Database + "/" + string(resp.Checks.Migrations) + "/" + string(resp.Checks.Lifecycle)
+			if resp.Status != wantStatus || got != tt.wantChecks {
+				t.Fatalf("readiness = %s %s, want %s %s", resp.Status, got, wantStatus, tt.wantChecks)
+			}
+		})
+	}
+}
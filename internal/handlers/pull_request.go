@@ -1,12 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 
+	"github.com/ChernykhITMO/Avito/internal/api"
 	"github.com/ChernykhITMO/Avito/internal/domain"
-	"github.com/ChernykhITMO/Avito/internal/handlers/dto"
 	"github.com/ChernykhITMO/Avito/internal/service"
 )
 
@@ -18,36 +19,47 @@ func NewPullRequestHandler(serv service.PullRequestService) *PullRequestHandler
 	return &PullRequestHandler{serv: serv}
 }
 
-func (h *PullRequestHandler) Register(mux *http.ServeMux) {
-	mux.HandleFunc("/pullRequest/create", h.handleCreate)
-	mux.HandleFunc("/pullRequest/merge", h.handleMerge)
-	mux.HandleFunc("/pullRequest/reassign", h.handleReassign)
-}
-
-type createPRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-}
+func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Request, _ api.CreatePullRequestParams) {
+	var req api.CreatePullRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
 
-func (h *PullRequestHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+	if req.PullRequestId == "" || req.PullRequestName == "" || req.AuthorId == "" {
+		writeBadRequest(w, "pull_request_id, pull_request_name and author_id are required")
 		return
 	}
 
-	var req createPRRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	create := h.serv.Create
+	if req.Draft != nil && *req.Draft {
+		create = h.serv.CreateDraft
+	}
+
+	pr, err := create(r.Context(), req.PullRequestId, req.PullRequestName, req.AuthorId)
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
 		return
 	}
 
-	if req.PullRequestID == "" || req.PullRequestName == "" || req.AuthorID == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	writeJSON(w, http.StatusCreated, api.PullRequestResponse{Pr: pullRequestToAPI(*pr)})
+}
+
+func (h *PullRequestHandler) GetPullRequest(w http.ResponseWriter, r *http.Request, params api.GetPullRequestParams) {
+	if params.PullRequestId == "" {
+		writeBadRequest(w, "pull_request_id is required")
 		return
 	}
 
-	pr, err := h.serv.Create(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	includeArchived := params.IncludeArchived != nil && *params.IncludeArchived
+
+	pr, err := h.serv.Get(r.Context(), params.PullRequestId, includeArchived)
 	if err != nil {
 		var derr *domain.Error
 		if errors.As(err, &derr) {
@@ -59,37 +71,44 @@ func (h *PullRequestHandler) handleCreate(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	resp := struct {
-		PR *dto.PullRequest `json:"pr"`
-	}{
-		PR: dto.PullRequestToDTO(*pr),
-	}
+	writeJSON(w, http.StatusOK, api.PullRequestResponse{Pr: pullRequestToAPI(*pr)})
+}
 
-	writeJSON(w, http.StatusCreated, resp)
+func (h *PullRequestHandler) MergePullRequest(w http.ResponseWriter, r *http.Request, _ api.MergePullRequestParams) {
+	h.changeStatus(w, r, h.serv.Merge)
 }
 
-type mergePRRequest struct {
-	PullRequestID string `json:"pull_request_id"`
+func (h *PullRequestHandler) MarkPullRequestReady(w http.ResponseWriter, r *http.Request, _ api.MarkPullRequestReadyParams) {
+	h.changeStatus(w, r, h.serv.MarkReady)
 }
 
-func (h *PullRequestHandler) handleMerge(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		return
-	}
+func (h *PullRequestHandler) ClosePullRequest(w http.ResponseWriter, r *http.Request, _ api.ClosePullRequestParams) {
+	h.changeStatus(w, r, h.serv.Close)
+}
+
+func (h *PullRequestHandler) ReopenPullRequest(w http.ResponseWriter, r *http.Request, _ api.ReopenPullRequestParams) {
+	h.changeStatus(w, r, h.serv.Reopen)
+}
 
-	var req mergePRRequest
+// changeStatus serves the endpoints that move a pull request through its
+// lifecycle and share the PullRequestIdRequest/PullRequestResponse shape.
+func (h *PullRequestHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, id string) (*domain.PullRequest, error),
+) {
+	var req api.PullRequestIdRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeBadRequest(w, "invalid request body")
 		return
 	}
 
-	if req.PullRequestID == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	if req.PullRequestId == "" {
+		writeBadRequest(w, "pull_request_id is required")
 		return
 	}
 
-	pr, err := h.serv.Merge(r.Context(), req.PullRequestID)
+	pr, err := change(r.Context(), req.PullRequestId)
 	if err != nil {
 		var derr *domain.Error
 		if errors.As(err, &derr) {
@@ -101,38 +120,22 @@ func (h *PullRequestHandler) handleMerge(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp := struct {
-		PR *dto.PullRequest `json:"pr"`
-	}{
-		PR: dto.PullRequestToDTO(*pr),
-	}
-
-	writeJSON(w, http.StatusOK, resp)
+	writeJSON(w, http.StatusOK, api.PullRequestResponse{Pr: pullRequestToAPI(*pr)})
 }
 
-type reassignRequest struct {
-	PullRequestID string `json:"pull_request_id"`
-	OldUserID     string `json:"old_user_id"`
-}
-
-func (h *PullRequestHandler) handleReassign(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		return
-	}
-
-	var req reassignRequest
+func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request, _ api.ReassignReviewerParams) {
+	var req api.ReassignRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeBadRequest(w, "invalid request body")
 		return
 	}
 
-	if req.PullRequestID == "" || req.OldUserID == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	if req.PullRequestId == "" || req.OldUserId == "" {
+		writeBadRequest(w, "pull_request_id and old_user_id are required")
 		return
 	}
 
-	pr, replacedBy, err := h.serv.ReassignReviewer(r.Context(), req.PullRequestID, req.OldUserID)
+	pr, replacedBy, err := h.serv.ReassignReviewer(r.Context(), req.PullRequestId, req.OldUserId)
 	if err != nil {
 		var derr *domain.Error
 		if errors.As(err, &derr) {
@@ -144,13 +147,8 @@ func (h *PullRequestHandler) handleReassign(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	resp := struct {
-		PR         *dto.PullRequest `json:"pr"`
-		ReplacedBy string           `json:"replaced_by"`
-	}{
-		PR:         dto.PullRequestToDTO(*pr),
+	writeJSON(w, http.StatusOK, api.ReassignResponse{
+		Pr:         pullRequestToAPI(*pr),
 		ReplacedBy: replacedBy,
-	}
-
-	writeJSON(w, http.StatusOK, resp)
+	})
 }
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type ReviewHandler struct {
+	serv service.ReviewService
+}
+
+func NewReviewHandler(serv service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		serv: serv,
+	}
+}
+
+func (h *ReviewHandler) ListOverdueReviews(w http.ResponseWriter, r *http.Request, params api.ListOverdueReviewsParams) {
+	var olderThan time.Duration
+	if params.OlderThan != nil && *params.OlderThan != "" {
+		d, err := time.ParseDuration(*params.OlderThan)
+		if err != nil || d <= 0 {
+			writeBadRequest(w, "invalid query parameter: older_than")
+			return
+		}
+		olderThan = d
+	}
+
+	var teamName string
+	if params.TeamName != nil {
+		teamName = *params.TeamName
+	}
+
+	reviews, err := h.serv.ListOverdue(r.Context(), olderThan, teamName)
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
+		return
+	}
+
+	resp := api.OverdueReviewsResponse{
+		Reviews: make([]api.ReviewAssignment, 0, len(reviews)),
+	}
+
+	for _, a := range reviews {
+		resp.Reviews = append(resp.Reviews, reviewAssignmentToAPI(a))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -3,38 +3,56 @@ package handlers
 import (
 	"net/http"
 
+	"github.com/ChernykhITMO/Avito/internal/api"
 	"github.com/ChernykhITMO/Avito/internal/service"
 )
 
+// Router implements the generated api.ServerInterface by composing the
+// per-resource handlers.
 type Router struct {
-	team  *TeamHandler
-	user  *UserHandler
-	pr    *PullRequestHandler
-	stats *StatsHandler
+	*TeamHandler
+	*UserHandler
+	*PullRequestHandler
+	*StatsHandler
+	*ReviewHandler
+	*AdminHandler
+	*HealthHandler
+	*DocsHandler
 }
 
+var _ api.ServerInterface = (*Router)(nil)
+
 func NewRouter(
 	teamSvc service.TeamService,
 	userSvc service.UserService,
 	prSvc service.PullRequestService,
 	handler service.StatsService,
+	reviewSvc service.ReviewService,
+	adminSvc service.AdminService,
+	healthSvc service.HealthService,
 ) *Router {
 	return &Router{
-		team:  NewTeamHandler(teamSvc),
-		user:  NewUserHandler(userSvc),
-		pr:    NewPullRequestHandler(prSvc),
-		stats: NewStatsHandler(handler),
+		TeamHandler:        NewTeamHandler(teamSvc),
+		UserHandler:        NewUserHandler(userSvc),
+		PullRequestHandler: NewPullRequestHandler(prSvc),
+		StatsHandler:       NewStatsHandler(handler),
+		ReviewHandler:      NewReviewHandler(reviewSvc),
+		AdminHandler:       NewAdminHandler(adminSvc),
+		HealthHandler:      NewHealthHandler(healthSvc),
+		DocsHandler:        NewDocsHandler(),
 	}
 }
 
-func (r *Router) Register(mux *http.ServeMux) {
-	r.team.Register(mux)
-	r.user.Register(mux)
-	r.pr.Register(mux)
-	r.stats.Register(mux)
-
-	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
+// Register mounts every operation of the spec on mux.
+func (r *Router) Register(mux api.ServeMux) {
+	api.HandlerWithOptions(r, api.StdHTTPServerOptions{
+		BaseRouter:       mux,
+		ErrorHandlerFunc: writeParamError,
 	})
 }
+
+func (r *Router) Health(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+var errBroken = errors.New("storage is down")
+
+type teamServiceBroken struct{}
+
+func (teamServiceBroken) CreateTeam(ctx context.Context, name string, members []domain.User) (*domain.Team, error) {
+	return nil, errBroken
+}
+
+func (teamServiceBroken) GetTeam(ctx context.Context, name string) (*domain.Team, error) {
+	return nil, errBroken
+}
+
+func (teamServiceBroken) ImportTeams(ctx context.Context, snapshot domain.OrgSnapshot, dryRun bool) (domain.OrgDiff, error) {
+	return domain.OrgDiff{}, errBroken
+}
+
+func (teamServiceBroken) ExportTeams(ctx context.Context) (domain.OrgSnapshot, error) {
+	return domain.OrgSnapshot{}, errBroken
+}
+
+func (teamServiceBroken) DeleteTeam(ctx context.Context, name string) (*domain.Team, error) {
+	return nil, errBroken
+}
+
+type userServiceBroken struct{}
+
+func (userServiceBroken) SetIsActive(ctx context.Context, userID string, active bool) (*domain.User, error) {
+	return nil, errBroken
+}
+
+func (userServiceBroken) GetUserReviewPRs(ctx context.Context, userID string, includeArchived bool) ([]domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (userServiceBroken) Delete(ctx context.Context, userID string) (*domain.User, error) {
+	return nil, errBroken
+}
+
+func (userServiceBroken) Purge(ctx context.Context, userID string) (*domain.User, error) {
+	return nil, errBroken
+}
+
+type prServiceBroken struct{}
+
+func (prServiceBroken) Create(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (prServiceBroken) CreateDraft(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (prServiceBroken) MarkReady(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (prServiceBroken) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (prServiceBroken) Close(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (prServiceBroken) Reopen(ctx context.Context, id string) (*domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (prServiceBroken) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+	return nil, "", errBroken
+}
+
+func (prServiceBroken) Get(ctx context.Context, id string, includeArchived bool) (*domain.PullRequest, error) {
+	return nil, errBroken
+}
+
+func (prServiceBroken) ArchiveMerged(ctx context.Context, mergedBefore time.Time) (int, error) {
+	return 0, errBroken
+}
+
+type statsServiceBroken struct{}
+
+func (statsServiceBroken) GetStats(ctx context.Context, f domain.StatsFilter) (domain.StatsResponse, error) {
+	return domain.StatsResponse{}, errBroken
+}
+
+func (statsServiceBroken) ExportAssignments(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	return errBroken
+}
+
+func (statsServiceBroken) GetFairness(ctx context.Context, f domain.StatsFilter) ([]domain.TeamFairness, error) {
+	return nil, errBroken
+}
+
+type reviewServiceBroken struct{}
+
+func (reviewServiceBroken) ListOverdue(ctx context.Context, olderThan time.Duration, teamName string) ([]domain.ReviewAssignment, error) {
+	return nil, errBroken
+}
+
+type adminServiceBroken struct {
+	adminServiceFake
+}
+
+func (adminServiceBroken) Snapshot(ctx context.Context) (*domain.Archive, error) {
+	return nil, errBroken
+}
+
+func (adminServiceBroken) Restore(ctx context.Context, archive *domain.Archive) error {
+	return errBroken
+}
+
+// newFakeMux serves the API over the contract fakes.
+func newFakeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	NewRouter(teamServiceFake{}, userServiceFake{}, prServiceFake{}, statsServiceFake{}, reviewServiceFake{}, adminServiceFake{}, healthServiceFake{}).Register(mux)
+	return mux
+}
+
+// newBrokenMux serves the API over services that fail every call.
+func newBrokenMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	NewRouter(teamServiceBroken{}, userServiceBroken{}, prServiceBroken{}, statsServiceBroken{}, reviewServiceBroken{}, adminServiceBroken{}, healthServiceFake{}).Register(mux)
+	return mux
+}
+
+// jsonBodyPaths returns the paths of the spec whose operations accept a
+// JSON request body.
+func jsonBodyPaths(doc *openapi3.T) []string {
+	var paths []string
+	for path, item := range doc.Paths.Map() {
+		for _, op := range item.Operations() {
+			if op.RequestBody != nil && op.RequestBody.Value.Content.Get("application/json") != nil {
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	doc, _ := loadSpecRouter(t)
+	mux := newFakeMux()
+
+	methods := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+	for path, item := range doc.Paths.Map() {
+		allowed := item.Operations()
+		for _, method := range methods {
+			if _, ok := allowed[method]; ok {
+				continue
+			}
+
+			t.Run(method+" "+path, func(t *testing.T) {
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(`{}`)))
+
+				if rec.Code != http.StatusMethodNotAllowed {
+					t.Fatalf("expected 405, got %d: %s", rec.Code, rec.Body)
+				}
+				allow := rec.Header().Get("Allow")
+				for m := range allowed {
+					if !strings.Contains(allow, m) {
+						t.Fatalf("Allow %q does not list %s", allow, m)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestHandlers_MalformedBody(t *testing.T) {
+	doc, _ := loadSpecRouter(t)
+	schema := doc.Components.Schemas["ErrorResponse"].Value
+	mux := newFakeMux()
+
+	bodies := map[string]string{
+		"empty":     "",
+		"truncated": `{"team_name":`,
+		"array":     `[]`,
+		"string":    `"text"`,
+		// Every request body has at least one of these fields.
+		"wrong types": `{"team_name":[],"user_id":[],"pull_request_id":[],"teams":{},"version":[]}`,
+	}
+
+	paths := jsonBodyPaths(doc)
+	if len(paths) == 0 {
+		t.Fatal("no operations with a JSON body in the spec")
+	}
+
+	for _, path := range paths {
+		for name, body := range bodies {
+			t.Run(path+" "+name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, req)
+
+				if rec.Code != http.StatusBadRequest {
+					t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body)
+				}
+
+				var v any
+				if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+					t.Fatalf("decode body: %v", err)
+				}
+				if err := schema.VisitJSON(v, openapi3.VisitAsResponse()); err != nil {
+					t.Fatalf("body does not match ErrorResponse: %v\n%s", err, rec.Body)
+				}
+				if code := v.(map[string]any)["error"].(map[string]any)["code"]; code != "BAD_REQUEST" {
+					t.Fatalf("expected BAD_REQUEST, got %v", code)
+				}
+			})
+		}
+	}
+}
+
+func TestHandlers_InternalError(t *testing.T) {
+	doc, specRouter := loadSpecRouter(t)
+	mux := newBrokenMux()
+
+	// Operations that do not call a failing service.
+	infallible := []string{"health", "livez", "readyz", "getOpenAPIYAML", "getOpenAPIJSON", "getDocs", "getCacheStats"}
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{method: http.MethodPost, path: "/team/add", body: `{"team_name":"backend","members":[]}`},
+		{method: http.MethodGet, path: "/team/get?team_name=backend"},
+		{method: http.MethodPost, path: "/team/import", body: `{"teams":[]}`},
+		{method: http.MethodGet, path: "/team/export"},
+		{method: http.MethodPost, path: "/team/delete", body: `{"team_name":"backend"}`},
+		{method: http.MethodPost, path: "/users/setIsActive", body: `{"user_id":"u1","is_active":true}`},
+		{method: http.MethodGet, path: "/users/getReview?user_id=u1"},
+		{method: http.MethodPost, path: "/users/delete", body: `{"user_id":"u1"}`},
+		{method: http.MethodPost, path: "/admin/users/purge", body: `{"user_id":"u1"}`},
+		{method: http.MethodPost, path: "/pullRequest/create", body: `{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"u1"}`},
+		{method: http.MethodGet, path: "/pullRequest/get?pull_request_id=pr1"},
+		{method: http.MethodPost, path: "/pullRequest/merge", body: `{"pull_request_id":"pr1"}`},
+		{method: http.MethodPost, path: "/pullRequest/ready", body: `{"pull_request_id":"pr1"}`},
+		{method: http.MethodPost, path: "/pullRequest/close", body: `{"pull_request_id":"pr1"}`},
+		{method: http.MethodPost, path: "/pullRequest/reopen", body: `{"pull_request_id":"pr1"}`},
+		{method: http.MethodPost, path: "/pullRequest/reassign", body: `{"pull_request_id":"pr1","old_user_id":"u2"}`},
+		{method: http.MethodGet, path: "/reviews/overdue"},
+		{method: http.MethodGet, path: "/stats"},
+		{method: http.MethodGet, path: "/stats/assignments/export"},
+		{method: http.MethodGet, path: "/stats/fairness"},
+		{method: http.MethodGet, path: "/admin/snapshot"},
+		{method: http.MethodPost, path: "/admin/restore", body: restoreBody("backend", "u1")},
+	}
+
+	covered := make(map[string]bool)
+	for _, id := range infallible {
+		covered[id] = true
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			route, _, err := specRouter.FindRoute(req)
+			if err != nil {
+				t.Fatalf("route not in spec: %v", err)
+			}
+			covered[route.Operation.OperationID] = true
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusInternalServerError {
+				t.Fatalf("expected 500, got %d: %s", rec.Code, rec.Body)
+			}
+			// Internal errors are not described to clients.
+			if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+				t.Fatalf("expected an empty response, got %q (%s)", rec.Body, rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+
+	var missing []string
+	for _, item := range doc.Paths.Map() {
+		for _, op := range item.Operations() {
+			if !covered[op.OperationID] {
+				missing = append(missing, op.OperationID)
+			}
+		}
+	}
+	slices.Sort(missing)
+	if len(missing) > 0 {
+		t.Fatalf("operations without an internal error case: %v", missing)
+	}
+}
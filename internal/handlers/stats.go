@@ -1,9 +1,12 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
 	"github.com/ChernykhITMO/Avito/internal/service"
 )
 
@@ -16,25 +19,120 @@ func NewStatsHandler(s service.StatsService) *StatsHandler {
 		service: s}
 }
 
-func (h *StatsHandler) Register(mux *http.ServeMux) {
-	mux.HandleFunc("/stats", h.handleStats)
-}
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request, params api.GetStatsParams) {
+	format, ok := statsFormat(r, params.Format)
+	if !ok {
+		writeBadRequest(w, errBadQuery("format").Error())
+		return
+	}
 
-func (h *StatsHandler) handleStats(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	filter, err := parseStatsFilter(params.TeamName, params.From, params.To)
+	if err != nil {
+		writeBadRequest(w, err.Error())
 		return
 	}
 
-	stats, err := h.service.GetStats(r.Context())
+	if params.Granularity != nil {
+		filter.Granularity = domain.Granularity(*params.Granularity)
+	}
+	switch filter.Granularity {
+	case "", domain.GranularityDay, domain.GranularityWeek:
+	default:
+		writeBadRequest(w, errBadQuery("granularity").Error())
+		return
+	}
+
+	stats, err := h.service.GetStats(r.Context(), filter)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(stats); err != nil {
-		http.Error(w, "failed to encode stats", http.StatusInternalServerError)
+	switch format {
+	case statsFormatCSV:
+		var table api.GetStatsParamsTable
+		if params.Table != nil {
+			table = *params.Table
+		}
+		writeStatsCSV(w, table, stats)
+		return
+	case statsFormatPrometheus:
+		writeStatsPrometheus(w, stats)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, statsToAPI(stats))
+}
+
+func (h *StatsHandler) GetFairness(w http.ResponseWriter, r *http.Request, params api.GetFairnessParams) {
+	filter, err := parseStatsFilter(params.TeamName, params.From, params.To)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	teams, err := h.service.GetFairness(r.Context(), filter)
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
 		return
 	}
+
+	resp := api.FairnessResponse{
+		Teams: make([]api.TeamFairness, 0, len(teams)),
+	}
+
+	for _, t := range teams {
+		resp.Teams = append(resp.Teams, teamFairnessToAPI(t))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func parseStatsFilter(teamName, from, to *string) (domain.StatsFilter, error) {
+	var filter domain.StatsFilter
+	if teamName != nil {
+		filter.TeamName = *teamName
+	}
+
+	var err error
+	if filter.From, err = parseStatsTime(from); err != nil {
+		return filter, errBadQuery("from")
+	}
+	if filter.To, err = parseStatsTime(to); err != nil {
+		return filter, errBadQuery("to")
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, errBadQuery("from")
+	}
+
+	return filter, nil
+}
+
+// parseStatsTime accepts either an RFC 3339 timestamp or a plain date.
+func parseStatsTime(raw *string) (time.Time, error) {
+	if raw == nil || *raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, *raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, *raw)
+}
+
+type errBadQuery string
+
+func (e errBadQuery) Error() string {
+	return "invalid query parameter: " + string(e)
 }
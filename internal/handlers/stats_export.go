@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+const (
+	statsFormatJSON       = "json"
+	statsFormatCSV        = "csv"
+	statsFormatPrometheus = "prometheus"
+
+	contentTypeCSV        = "text/csv; charset=utf-8"
+	contentTypePrometheus = "text/plain; version=0.0.4; charset=utf-8"
+	contentTypeNDJSON     = "application/x-ndjson"
+)
+
+// statsFormat picks the response format: an explicit ?format= wins over the
+// Accept header, JSON is the default.
+func statsFormat(r *http.Request, format *api.GetStatsParamsFormat) (string, bool) {
+	if format != nil {
+		switch f := string(*format); f {
+		case statsFormatJSON, statsFormatCSV, statsFormatPrometheus:
+			return f, true
+		case "":
+		default:
+			return "", false
+		}
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return statsFormatCSV, true
+	case strings.Contains(accept, "version=0.0.4"), strings.Contains(accept, "application/openmetrics-text"):
+		return statsFormatPrometheus, true
+	default:
+		return statsFormatJSON, true
+	}
+}
+
+// writeStatsCSV writes one of the stats tables selected by ?table=.
+func writeStatsCSV(w http.ResponseWriter, table api.GetStatsParamsTable, stats domain.StatsResponse) {
+	var rows [][]string
+
+	switch table {
+	case "", api.PrStats:
+		rows = [][]string{
+			{"total", "draft", "open", "merged", "closed"},
+			{
+				strconv.Itoa(stats.PRStats.Total),
+				strconv.Itoa(stats.PRStats.Draft),
+				strconv.Itoa(stats.PRStats.Open),
+				strconv.Itoa(stats.PRStats.Merged),
+				strconv.Itoa(stats.PRStats.Closed),
+			},
+		}
+	case api.Assignments:
+		rows = [][]string{{"user_id", "count"}}
+		for _, a := range stats.AssignmentsPerUser {
+			rows = append(rows, []string{a.UserID, strconv.Itoa(a.Count)})
+		}
+	default:
+		writeBadRequest(w, errBadQuery("table").Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeCSV)
+	w.WriteHeader(http.StatusOK)
+	_ = csv.NewWriter(w).WriteAll(rows)
+}
+
+func writeStatsPrometheus(w http.ResponseWriter, stats domain.StatsResponse) {
+	w.Header().Set("Content-Type", contentTypePrometheus)
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = io.WriteString(w, "# HELP pull_requests Number of pull requests by status.\n")
+	_, _ = io.WriteString(w, "# TYPE pull_requests gauge\n")
+	for _, s := range []struct {
+		status string
+		count  int
+	}{
+		{"draft", stats.PRStats.Draft},
+		{"open", stats.PRStats.Open},
+		{"merged", stats.PRStats.Merged},
+		{"closed", stats.PRStats.Closed},
+	} {
+		_, _ = fmt.Fprintf(w, "pull_requests{status=%q} %d\n", s.status, s.count)
+	}
+
+	_, _ = io.WriteString(w, "# HELP reviewer_assignments Number of pull requests a user is assigned to review.\n")
+	_, _ = io.WriteString(w, "# TYPE reviewer_assignments gauge\n")
+	for _, a := range stats.AssignmentsPerUser {
+		_, _ = fmt.Fprintf(w, "reviewer_assignments{user_id=%q} %d\n", a.UserID, a.Count)
+	}
+}
+
+func (h *StatsHandler) ExportAssignments(w http.ResponseWriter, r *http.Request, params api.ExportAssignmentsParams) {
+	filter, err := parseStatsFilter(params.TeamName, params.From, params.To)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeNDJSON)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+
+	const flushEvery = 100
+	written := 0
+
+	err = h.service.ExportAssignments(r.Context(), filter, func(a domain.AssignmentRecord) error {
+		if err := enc.Encode(assignmentRecordToAPI(a)); err != nil {
+			return err
+		}
+		written++
+		if flusher != nil && written%flushEvery == 0 {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if written == 0 {
+			w.Header().Del("Content-Type")
+			var derr *domain.Error
+			if errors.As(err, &derr) {
+				writeDomainError(w, derr)
+				return
+			}
+			writeInternal(w)
+		}
+		// Headers are already sent; the truncated stream is the only signal left.
+		return
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+)
+
+func TestStats_RangeRejectedByService(t *testing.T) {
+	doc, _ := loadSpecRouter(t)
+	schema := doc.Components.Schemas["ErrorResponse"].Value
+
+	stats := service.NewStatsService(fakes.New().Stats(), service.SystemClock())
+	mux := http.NewServeMux()
+	NewRouter(teamServiceFake{}, userServiceFake{}, prServiceFake{}, stats, reviewServiceFake{}, adminServiceFake{}, healthServiceFake{}).Register(mux)
+
+	// Alone, each bound is valid; the range up to now is not.
+	tests := []string{
+		"/stats?from=2000-01-01",
+		"/stats?from=2099-01-01",
+		"/stats/fairness?from=2000-01-01",
+		"/stats/fairness?from=2099-01-01",
+	}
+
+	for _, path := range tests {
+		t.Run(path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body)
+			}
+			var v any
+			if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if err := schema.VisitJSON(v, openapi3.VisitAsResponse()); err != nil {
+				t.Fatalf("body does not match ErrorResponse: %v\n%s", err, rec.Body)
+			}
+			if code := v.(map[string]any)["error"].(map[string]any)["code"]; code != "BAD_REQUEST" {
+				t.Fatalf("expected BAD_REQUEST, got %v", code)
+			}
+		})
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/ChernykhITMO/Avito/internal/api"
 	"github.com/ChernykhITMO/Avito/internal/domain"
-	"github.com/ChernykhITMO/Avito/internal/handlers/dto"
 	"github.com/ChernykhITMO/Avito/internal/service"
 )
 
@@ -20,24 +20,19 @@ func NewTeamHandler(serv service.TeamService) *TeamHandler {
 	}
 }
 
-func (h *TeamHandler) Register(mux *http.ServeMux) {
-	mux.HandleFunc("/team/add", h.handleAddTeam)
-	mux.HandleFunc("/team/get", h.handleGetTeam)
-}
-
-func (h *TeamHandler) handleAddTeam(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request, _ api.AddTeamParams) {
+	var req api.Team
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
 		return
 	}
 
-	var teamDTO dto.Team
-	if err := json.NewDecoder(r.Body).Decode(&teamDTO); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if req.TeamName == "" {
+		writeBadRequest(w, "team_name is required")
 		return
 	}
 
-	team := dto.TeamDTOToDomain(teamDTO)
+	team := teamFromAPI(req)
 
 	created, err := h.serv.CreateTeam(r.Context(), team.Name, team.Members)
 	if err != nil {
@@ -51,28 +46,43 @@ func (h *TeamHandler) handleAddTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := struct {
-		Team dto.Team `json:"team"`
-	}{
-		Team: dto.TeamToDTO(*created),
+	writeJSON(w, http.StatusCreated, api.TeamResponse{Team: teamToAPI(*created)})
+}
+
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request, params api.GetTeamParams) {
+	if params.TeamName == "" {
+		writeBadRequest(w, "team_name is required")
+		return
+	}
+
+	team, err := h.serv.GetTeam(r.Context(), params.TeamName)
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
+		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	writeJSON(w, http.StatusOK, teamToAPI(*team))
 }
 
-func (h *TeamHandler) handleGetTeam(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request, _ api.DeleteTeamParams) {
+	var req api.TeamNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
 		return
 	}
 
-	name := r.URL.Query().Get("team_name")
-	if name == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	if req.TeamName == "" {
+		writeBadRequest(w, "team_name is required")
 		return
 	}
 
-	team, err := h.serv.GetTeam(r.Context(), name)
+	team, err := h.serv.DeleteTeam(r.Context(), req.TeamName)
 	if err != nil {
 		var derr *domain.Error
 		if errors.As(err, &derr) {
@@ -84,5 +94,5 @@ func (h *TeamHandler) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, dto.TeamToDTO(*team))
+	writeJSON(w, http.StatusOK, api.TeamResponse{Team: teamToAPI(*team)})
 }
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+const (
+	teamsFormatJSON = "json"
+	teamsFormatYAML = "yaml"
+	teamsFormatCSV  = "csv"
+
+	contentTypeYAML = "application/yaml"
+)
+
+// MaxImportBodyBytes bounds org snapshots; a few thousand users fit easily.
+const MaxImportBodyBytes = 8 << 20
+
+var teamsCSVHeader = []string{"team_name", "user_id", "username", "is_active"}
+
+func (h *TeamHandler) ImportTeams(w http.ResponseWriter, r *http.Request, params api.ImportTeamsParams) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportBodyBytes)
+
+	snapshot, err := decodeSnapshot(r)
+	if err != nil {
+		// A cut-off snapshot must not be applied: it would deactivate
+		// everyone past the cut.
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeAPIError(w, http.StatusRequestEntityTooLarge, api.PAYLOADTOOLARGE, "request body is too large")
+			return
+		}
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	dryRun := params.DryRun != nil && *params.DryRun
+
+	diff, err := h.serv.ImportTeams(r.Context(), snapshotFromAPI(snapshot), dryRun)
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, importResultToAPI(diff, dryRun))
+}
+
+func (h *TeamHandler) ExportTeams(w http.ResponseWriter, r *http.Request, params api.ExportTeamsParams) {
+	format, ok := teamsFormat(r, params.Format)
+	if !ok {
+		writeBadRequest(w, errBadQuery("format").Error())
+		return
+	}
+
+	orgSnapshot, err := h.serv.ExportTeams(r.Context())
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
+		return
+	}
+
+	snapshot := snapshotToAPI(orgSnapshot)
+
+	switch format {
+	case teamsFormatYAML:
+		w.Header().Set("Content-Type", contentTypeYAML)
+		w.WriteHeader(http.StatusOK)
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		_ = enc.Encode(snapshotToYAML(snapshot))
+		_ = enc.Close()
+	case teamsFormatCSV:
+		w.Header().Set("Content-Type", contentTypeCSV)
+		w.WriteHeader(http.StatusOK)
+		_ = csv.NewWriter(w).WriteAll(snapshotToCSV(snapshot))
+	default:
+		writeJSON(w, http.StatusOK, snapshot)
+	}
+}
+
+// teamsFormat picks the export format: an explicit ?format= wins over the
+// Accept header, JSON is the default.
+func teamsFormat(r *http.Request, format *api.ExportTeamsParamsFormat) (string, bool) {
+	if format != nil {
+		switch f := string(*format); f {
+		case teamsFormatJSON, teamsFormatYAML, teamsFormatCSV:
+			return f, true
+		case "":
+		default:
+			return "", false
+		}
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "yaml"):
+		return teamsFormatYAML, true
+	case strings.Contains(accept, "text/csv"):
+		return teamsFormatCSV, true
+	default:
+		return teamsFormatJSON, true
+	}
+}
+
+// decodeSnapshot reads the request body in the format named by
+// Content-Type; a missing Content-Type is treated as JSON.
+func decodeSnapshot(r *http.Request) (api.OrgSnapshot, error) {
+	mediaType := "application/json"
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mt, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return api.OrgSnapshot{}, fmt.Errorf("invalid Content-Type")
+		}
+		mediaType = mt
+	}
+
+	switch mediaType {
+	case "application/json":
+		var snapshot api.OrgSnapshot
+		if err := json.NewDecoder(r.Body).Decode(&snapshot); err != nil {
+			return api.OrgSnapshot{}, invalidBody(err, "invalid request body")
+		}
+		return snapshot, nil
+
+	case contentTypeYAML, "application/x-yaml", "text/yaml":
+		var doc yamlSnapshot
+		if err := yaml.NewDecoder(r.Body).Decode(&doc); err != nil {
+			return api.OrgSnapshot{}, invalidBody(err, "invalid request body")
+		}
+		return doc.toAPI(), nil
+
+	case "text/csv":
+		return snapshotFromCSV(r.Body)
+
+	default:
+		return api.OrgSnapshot{}, fmt.Errorf("unsupported Content-Type %q", mediaType)
+	}
+}
+
+// snapshotFromCSV reads one member per row. Columns are matched by header
+// name, is_active may be omitted or empty and then means true. Teams keep
+// the order of their first row.
+func snapshotFromCSV(r io.Reader) (api.OrgSnapshot, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return api.OrgSnapshot{}, invalidBody(err, "invalid csv: missing header")
+	}
+
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		col[strings.TrimSpace(name)] = i
+	}
+	for _, name := range teamsCSVHeader[:3] {
+		if _, ok := col[name]; !ok {
+			return api.OrgSnapshot{}, fmt.Errorf("invalid csv: missing column %s", name)
+		}
+	}
+
+	snapshot := api.OrgSnapshot{Teams: []api.Team{}}
+	index := make(map[string]int)
+
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return api.OrgSnapshot{}, invalidBody(err, "invalid csv: "+err.Error())
+		}
+
+		member := api.TeamMember{
+			UserId:   rec[col["user_id"]],
+			Username: rec[col["username"]],
+			IsActive: true,
+		}
+		if i, ok := col["is_active"]; ok && rec[i] != "" {
+			active, err := strconv.ParseBool(rec[i])
+			if err != nil {
+				return api.OrgSnapshot{}, fmt.Errorf("invalid csv: line %d: invalid is_active %q", line, rec[i])
+			}
+			member.IsActive = active
+		}
+
+		teamName := rec[col["team_name"]]
+		i, ok := index[teamName]
+		if !ok {
+			i = len(snapshot.Teams)
+			index[teamName] = i
+			snapshot.Teams = append(snapshot.Teams, api.Team{TeamName: teamName, Members: []api.TeamMember{}})
+		}
+		snapshot.Teams[i].Members = append(snapshot.Teams[i].Members, member)
+	}
+
+	return snapshot, nil
+}
+
+// snapshotToCSV writes one row per member, so teams without members are
+// not part of the CSV export.
+func snapshotToCSV(snapshot api.OrgSnapshot) [][]string {
+	rows := [][]string{teamsCSVHeader}
+	for _, t := range snapshot.Teams {
+		for _, m := range t.Members {
+			rows = append(rows, []string{t.TeamName, m.UserId, m.Username, strconv.FormatBool(m.IsActive)})
+		}
+	}
+	return rows
+}
+
+// yamlSnapshot mirrors api.OrgSnapshot with yaml tags so both formats use
+// the same field names.
+type yamlSnapshot struct {
+	Teams []yamlTeam `yaml:"teams"`
+}
+
+type yamlTeam struct {
+	TeamName string       `yaml:"team_name"`
+	Members  []yamlMember `yaml:"members"`
+}
+
+type yamlMember struct {
+	UserID   string `yaml:"user_id"`
+	Username string `yaml:"username"`
+	IsActive bool   `yaml:"is_active"`
+}
+
+func (s yamlSnapshot) toAPI() api.OrgSnapshot {
+	snapshot := api.OrgSnapshot{Teams: make([]api.Team, 0, len(s.Teams))}
+	for _, t := range s.Teams {
+		team := api.Team{TeamName: t.TeamName, Members: make([]api.TeamMember, 0, len(t.Members))}
+		for _, m := range t.Members {
+			team.Members = append(team.Members, api.TeamMember{UserId: m.UserID, Username: m.Username, IsActive: m.IsActive})
+		}
+		snapshot.Teams = append(snapshot.Teams, team)
+	}
+	return snapshot
+}
+
+func snapshotToYAML(snapshot api.OrgSnapshot) yamlSnapshot {
+	doc := yamlSnapshot{Teams: make([]yamlTeam, 0, len(snapshot.Teams))}
+	for _, t := range snapshot.Teams {
+		team := yamlTeam{TeamName: t.TeamName, Members: make([]yamlMember, 0, len(t.Members))}
+		for _, m := range t.Members {
+			team.Members = append(team.Members, yamlMember{UserID: m.UserId, Username: m.Username, IsActive: m.IsActive})
+		}
+		doc.Teams = append(doc.Teams, team)
+	}
+	return doc
+}
+
+// invalidBody replaces a decoding error with msg, unless the body was cut
+// off by the size limit, which the caller reports on its own.
+func invalidBody(err error, msg string) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	return errors.New(msg)
+}
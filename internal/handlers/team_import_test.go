@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	want := api.OrgSnapshot{Teams: []api.Team{
+		{TeamName: "backend", Members: []api.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob, Jr.", IsActive: false},
+		}},
+		{TeamName: "payments", Members: []api.TeamMember{
+			{UserId: "u3", Username: "Carol", IsActive: true},
+		}},
+	}}
+
+	var yamlBody bytes.Buffer
+	if err := yaml.NewEncoder(&yamlBody).Encode(snapshotToYAML(want)); err != nil {
+		t.Fatalf("encode yaml: %v", err)
+	}
+
+	var csvBody bytes.Buffer
+	if err := csv.NewWriter(&csvBody).WriteAll(snapshotToCSV(want)); err != nil {
+		t.Fatalf("encode csv: %v", err)
+	}
+
+	for contentType, body := range map[string]string{
+		"application/yaml":        yamlBody.String(),
+		"text/csv; charset=utf-8": csvBody.String(),
+	} {
+		req := httptest.NewRequest("POST", "/team/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+
+		got, err := decodeSnapshot(req)
+		if err != nil {
+			t.Fatalf("%s: decode: %v", contentType, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: round trip mismatch:\n got %+v\nwant %+v", contentType, got, want)
+		}
+	}
+}
+
+func TestSnapshotFromCSV_Errors(t *testing.T) {
+	for name, body := range map[string]string{
+		"empty":          "",
+		"missing column": "team_name,user_id\nbackend,u1\n",
+		"bad is_active":  "team_name,user_id,username,is_active\nbackend,u1,Alice,maybe\n",
+		"ragged row":     "team_name,user_id,username\nbackend,u1\n",
+	} {
+		if _, err := snapshotFromCSV(strings.NewReader(body)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestImportTeams_OversizedBody(t *testing.T) {
+	ctx := context.Background()
+	store := fakes.New()
+	members := []domain.User{
+		{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+	}
+	if err := store.Teams().Create(ctx, &domain.Team{Name: "backend"}); err != nil {
+		t.Fatalf("seed team: %v", err)
+	}
+	if err := store.Users().SaveAll(ctx, members); err != nil {
+		t.Fatalf("seed users: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	teams := service.NewTeamService(store.Teams(), store.Users())
+	NewRouter(teams, userServiceFake{}, prServiceFake{}, statsServiceFake{}, reviewServiceFake{}, adminServiceFake{}, healthServiceFake{}).Register(mux)
+
+	// Every prefix of the body is a valid snapshot without u1 and u2.
+	var body strings.Builder
+	body.WriteString("team_name,user_id,username,is_active\n")
+	for i := 0; body.Len() <= MaxImportBodyBytes; i++ {
+		fmt.Fprintf(&body, "backend,n%d,User%d,true\n", i, i)
+	}
+
+	for _, known := range []bool{true, false} {
+		t.Run(fmt.Sprintf("known length %v", known), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/team/import", strings.NewReader(body.String()))
+			req.Header.Set("Content-Type", "text/csv")
+			if !known {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusRequestEntityTooLarge || !strings.Contains(rec.Body.String(), string(api.PAYLOADTOOLARGE)) {
+				t.Fatalf("expected 413 PAYLOAD_TOO_LARGE, got %d: %s", rec.Code, rec.Body)
+			}
+		})
+	}
+
+	team, err := store.Teams().GetByName(ctx, "backend")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if !reflect.DeepEqual(team.Members, members) {
+		t.Fatalf("oversized import changed the team: %+v", team.Members)
+	}
+	if n := store.Calls("Teams.ApplyDiff"); n != 0 {
+		t.Fatalf("expected no diff applied, got %d", n)
+	}
+}
@@ -1,12 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 
+	"github.com/ChernykhITMO/Avito/internal/api"
 	"github.com/ChernykhITMO/Avito/internal/domain"
-	"github.com/ChernykhITMO/Avito/internal/handlers/dto"
 	"github.com/ChernykhITMO/Avito/internal/service"
 )
 
@@ -20,34 +21,42 @@ func NewUserHandler(serv service.UserService) *UserHandler {
 	}
 }
 
-func (h *UserHandler) Register(mux *http.ServeMux) {
-	mux.HandleFunc("/users/setIsActive", h.handleSetIsActive)
-	mux.HandleFunc("/users/getReview", h.handleGetReview)
-}
-
-type setIsActiveRequest struct {
-	UserID   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
-}
+func (h *UserHandler) SetUserIsActive(w http.ResponseWriter, r *http.Request, _ api.SetUserIsActiveParams) {
+	var req api.SetIsActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
 
-func (h *UserHandler) handleSetIsActive(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+	if req.UserId == "" {
+		writeBadRequest(w, "user_id is required")
 		return
 	}
 
-	var req setIsActiveRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	user, err := h.serv.SetIsActive(r.Context(), req.UserId, req.IsActive)
+	if err != nil {
+		var derr *domain.Error
+		if errors.As(err, &derr) {
+			writeDomainError(w, derr)
+			return
+		}
+
+		writeInternal(w)
 		return
 	}
 
-	if req.UserID == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	writeJSON(w, http.StatusOK, api.UserResponse{User: userToAPI(*user)})
+}
+
+func (h *UserHandler) GetUserReviews(w http.ResponseWriter, r *http.Request, params api.GetUserReviewsParams) {
+	if params.UserId == "" {
+		writeBadRequest(w, "user_id is required")
 		return
 	}
 
-	user, err := h.serv.SetIsActive(r.Context(), req.UserID, req.IsActive)
+	includeArchived := params.IncludeArchived != nil && *params.IncludeArchived
+
+	prs, err := h.serv.GetUserReviewPRs(r.Context(), params.UserId, includeArchived)
 	if err != nil {
 		var derr *domain.Error
 		if errors.As(err, &derr) {
@@ -59,28 +68,45 @@ func (h *UserHandler) handleSetIsActive(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp := struct {
-		User dto.User `json:"user"`
-	}{
-		User: dto.UserToDTO(*user),
+	resp := api.UserReviewsResponse{
+		UserId:       params.UserId,
+		PullRequests: make([]api.PullRequestShort, 0, len(prs)),
+	}
+
+	for _, pr := range prs {
+		resp.PullRequests = append(resp.PullRequests, pullRequestToShortAPI(pr))
 	}
 
 	writeJSON(w, http.StatusOK, resp)
 }
 
-func (h *UserHandler) handleGetReview(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		w.WriteHeader(http.StatusMethodNotAllowed)
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request, _ api.DeleteUserParams) {
+	h.removeUser(w, r, h.serv.Delete)
+}
+
+// PurgeUser is served here rather than by AdminHandler, as it is a change
+// of a single user.
+func (h *UserHandler) PurgeUser(w http.ResponseWriter, r *http.Request, _ api.PurgeUserParams) {
+	h.removeUser(w, r, h.serv.Purge)
+}
+
+func (h *UserHandler) removeUser(
+	w http.ResponseWriter,
+	r *http.Request,
+	remove func(ctx context.Context, userID string) (*domain.User, error),
+) {
+	var req api.UserIdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
 		return
 	}
 
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	if req.UserId == "" {
+		writeBadRequest(w, "user_id is required")
 		return
 	}
 
-	prs, err := h.serv.GetUserReviewPRs(r.Context(), userID)
+	user, err := remove(r.Context(), req.UserId)
 	if err != nil {
 		var derr *domain.Error
 		if errors.As(err, &derr) {
@@ -92,17 +118,5 @@ func (h *UserHandler) handleGetReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := struct {
-		UserID       string                 `json:"user_id"`
-		PullRequests []dto.PullRequestShort `json:"pull_requests"`
-	}{
-		UserID:       userID,
-		PullRequests: make([]dto.PullRequestShort, 0, len(prs)),
-	}
-
-	for _, pr := range prs {
-		resp.PullRequests = append(resp.PullRequests, *dto.PullRequestToShortDTO(pr))
-	}
-
-	writeJSON(w, http.StatusOK, resp)
+	writeJSON(w, http.StatusOK, api.UserResponse{User: userToAPI(*user)})
 }
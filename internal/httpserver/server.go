@@ -2,11 +2,18 @@ package httpserver
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"time"
 
+	"github.com/ChernykhITMO/Avito/internal/domain"
 	"github.com/ChernykhITMO/Avito/internal/handlers"
+	"github.com/ChernykhITMO/Avito/internal/idempotency"
+	"github.com/ChernykhITMO/Avito/internal/ratelimit"
 	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/tenant"
 )
 
 type Deps struct {
@@ -14,10 +21,40 @@ type Deps struct {
 	UserService        service.UserService
 	PullRequestService service.PullRequestService
 	StatsService       service.StatsService
+	ReviewService      service.ReviewService
+	AdminService       service.AdminService
+	HealthService      service.HealthService
+
+	// IdempotencyStore enables Idempotency-Key support when set; stored
+	// responses are kept for IdempotencyTTL.
+	IdempotencyStore domain.IdempotencyStore
+	IdempotencyTTL   time.Duration
+
+	// Limits guards the server against overload; nil disables all limits.
+	Limits *ratelimit.Config
+
+	// Tenants resolves the tenant of every request; nil serves everything
+	// as the default tenant.
+	Tenants *tenant.Resolver
+
+	// DrainDelay is how long the server keeps serving with /readyz failing
+	// before it stops accepting connections, so that load balancers notice.
+	DrainDelay time.Duration
 }
 
+const (
+	readHeaderTimeout = 5 * time.Second
+	readTimeout       = 30 * time.Second
+	// writeTimeout leaves room for the streaming export endpoints.
+	writeTimeout    = 2 * time.Minute
+	idleTimeout     = 2 * time.Minute
+	shutdownTimeout = 15 * time.Second
+)
+
 type Server struct {
-	http *http.Server
+	http       *http.Server
+	health     service.HealthService
+	drainDelay time.Duration
 }
 
 func New(addr string, deps Deps) *Server {
@@ -28,29 +65,71 @@ func New(addr string, deps Deps) *Server {
 		deps.UserService,
 		deps.PullRequestService,
 		deps.StatsService,
+		deps.ReviewService,
+		deps.AdminService,
+		deps.HealthService,
 	)
 	router.Register(mux)
 
+	var handler http.Handler = mux
+	if deps.IdempotencyStore != nil {
+		handler = idempotency.Middleware(deps.IdempotencyStore, deps.IdempotencyTTL)(handler)
+	}
+	if deps.Tenants != nil {
+		handler = tenant.Middleware(deps.Tenants)(handler)
+	}
+	if deps.Limits != nil {
+		handler = ratelimit.Middleware(*deps.Limits, service.SystemClock())(handler)
+	}
+	handler = recoverPanics(handler)
+
 	return &Server{
 		http: &http.Server{
-			Addr:    addr,
-			Handler: mux,
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: readHeaderTimeout,
+			ReadTimeout:       readTimeout,
+			WriteTimeout:      writeTimeout,
+			IdleTimeout:       idleTimeout,
 		},
+		health:     deps.HealthService,
+		drainDelay: deps.DrainDelay,
 	}
 }
 
+// Run serves until ctx is cancelled, then drains: /readyz starts failing,
+// and after DrainDelay the server stops accepting connections and waits for
+// in-flight requests.
 func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve is Run on an existing listener, which it closes on return.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	shutdownErr := make(chan error, 1)
 	go func() {
 		<-ctx.Done()
 
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if s.health != nil {
+			s.health.Drain()
+		}
+		time.Sleep(s.drainDelay)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 		defer cancel()
 
-		_ = s.http.Shutdown(shutdownCtx)
+		shutdownErr <- s.http.Shutdown(shutdownCtx)
 	}()
 
-	if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := s.http.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
+	if err := <-shutdownErr; err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,70 @@
+package httpserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type pingerStub struct{}
+
+func (pingerStub) PingContext(context.Context) error {
+	return nil
+}
+
+func TestRun_DrainsBeforeShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+
+	health := service.NewHealthService(pingerStub{})
+	health.MarkMigrated()
+	srv := New(addr, Deps{HealthService: health, DrainDelay: 300 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	url := "http://" + addr + "/readyz"
+	waitStatus(t, url, http.StatusOK)
+
+	cancel()
+	// During the drain delay the server still answers, but not ready.
+	waitStatus(t, url, http.StatusServiceUnavailable)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("server did not stop")
+	}
+}
+
+func waitStatus(t *testing.T, url string, want int) {
+	t.Helper()
+
+	// Keep-alive connections would hold up Shutdown.
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := client.Get(url)
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == want {
+				return
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s did not return %d (last err %v)", url, want, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
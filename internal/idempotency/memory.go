@@ -0,0 +1,97 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+var _ domain.IdempotencyStore = (*MemoryStore)(nil)
+
+// memorySweepInterval is how often Reserve drops every expired entry.
+// In between, expired keys are only replaced when they are reserved again.
+const memorySweepInterval = time.Minute
+
+// memoryKey scopes idempotency keys to the tenant that made the request.
+type memoryKey struct {
+	tenant, key string
+}
+
+type memoryEntry struct {
+	record    domain.IdempotencyRecord
+	expiresAt time.Time
+}
+
+// MemoryStore is an in-process IdempotencyStore. Keys are not shared
+// between replicas and do not survive restarts.
+type MemoryStore struct {
+	clock service.Clock
+
+	mu        sync.Mutex
+	entries   map[memoryKey]*memoryEntry
+	nextSweep time.Time
+}
+
+func NewMemoryStore(clock service.Clock) domain.IdempotencyStore {
+	return &MemoryStore{
+		clock:   clock,
+		entries: make(map[memoryKey]*memoryEntry),
+	}
+}
+
+func (s *MemoryStore) Reserve(
+	ctx context.Context,
+	key, fingerprint string,
+	ttl time.Duration,
+) (*domain.IdempotencyRecord, error) {
+	now := s.clock.Now()
+	id := memoryKey{tenant: domain.TenantFrom(ctx), key: key}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !now.Before(s.nextSweep) {
+		for k, e := range s.entries {
+			if !now.Before(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+		s.nextSweep = now.Add(memorySweepInterval)
+	}
+
+	if e, ok := s.entries[id]; ok && now.Before(e.expiresAt) {
+		rec := e.record
+		return &rec, nil
+	}
+
+	s.entries[id] = &memoryEntry{
+		record:    domain.IdempotencyRecord{Fingerprint: fingerprint},
+		expiresAt: now.Add(ttl),
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) Complete(ctx context.Context, key string, resp domain.StoredResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[memoryKey{tenant: domain.TenantFrom(ctx), key: key}]; ok {
+		e.record.Response = &resp
+	}
+	return nil
+}
+
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	id := memoryKey{tenant: domain.TenantFrom(ctx), key: key}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok && e.record.Response == nil {
+		delete(s.entries, id)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+func TestMemoryStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore(clock)
+
+	if rec, err := store.Reserve(ctx, "k1", "fp1", time.Hour); err != nil || rec != nil {
+		t.Fatalf("first reserve = %+v, %v; want nil, nil", rec, err)
+	}
+
+	rec, err := store.Reserve(ctx, "k1", "fp2", time.Hour)
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if rec == nil || rec.Fingerprint != "fp1" || rec.Response != nil {
+		t.Fatalf("pending record = %+v, want fp1 without response", rec)
+	}
+
+	resp := domain.StoredResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}
+	if err := store.Complete(ctx, "k1", resp); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	// A completed key is kept: only pending reservations are released.
+	if err := store.Release(ctx, "k1"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+
+	rec, err = store.Reserve(ctx, "k1", "fp1", time.Hour)
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if rec == nil || rec.Response == nil || rec.Response.StatusCode != 201 {
+		t.Fatalf("completed record = %+v, want stored 201", rec)
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	if rec, err := store.Reserve(ctx, "k1", "fp3", time.Hour); err != nil || rec != nil {
+		t.Fatalf("reserve after expiry = %+v, %v; want nil, nil", rec, err)
+	}
+}
+
+func TestMemoryStore_SweepsExpired(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	store := NewMemoryStore(clock).(*MemoryStore)
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		if _, err := store.Reserve(ctx, key, "fp", time.Second); err != nil {
+			t.Fatalf("reserve %s: %v", key, err)
+		}
+	}
+
+	// Between sweeps expired keys stay until reserved again.
+	clock.now = clock.now.Add(2 * time.Second)
+	if rec, err := store.Reserve(ctx, "k1", "fp", time.Hour); err != nil || rec != nil {
+		t.Fatalf("reserve after expiry = %+v, %v; want nil, nil", rec, err)
+	}
+	if len(store.entries) != 3 {
+		t.Fatalf("expected 3 entries before the sweep, got %d", len(store.entries))
+	}
+
+	clock.now = clock.now.Add(memorySweepInterval)
+	if _, err := store.Reserve(ctx, "k4", "fp", time.Hour); err != nil {
+		t.Fatalf("reserve k4: %v", err)
+	}
+	if len(store.entries) != 2 {
+		t.Fatalf("expected k1 and k4 after the sweep, got %v", store.entries)
+	}
+}
+
+func TestMemoryStore_ReleasePending(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(&fakeClock{now: time.Now()})
+
+	if _, err := store.Reserve(ctx, "k1", "fp1", time.Hour); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if err := store.Release(ctx, "k1"); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if rec, err := store.Reserve(ctx, "k1", "fp2", time.Hour); err != nil || rec != nil {
+		t.Fatalf("reserve after release = %+v, %v; want nil, nil", rec, err)
+	}
+}
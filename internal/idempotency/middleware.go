@@ -0,0 +1,163 @@
+// Package idempotency lets clients safely retry mutating requests: the first
+// response to a request carrying an Idempotency-Key header is stored and
+// replayed for repeats of the same request.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+const (
+	// Header carries the client-chosen idempotency key.
+	Header = "Idempotency-Key"
+	// ReplayedHeader marks responses served from the store.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+)
+
+// Middleware makes POST requests with an Idempotency-Key header
+// idempotent. A repeat with the same key and payload gets the stored
+// response, a repeat with a different payload gets 422, and a repeat
+// arriving while the first request is still running gets 409. Responses
+// with a 5xx status are not stored, so such requests can be retried.
+func Middleware(store domain.IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxKeyLength {
+				writeError(w, http.StatusBadRequest, api.BADREQUEST, "idempotency key is too long")
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, api.BADREQUEST, "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := requestFingerprint(r, body)
+			rec, err := store.Reserve(r.Context(), key, fingerprint, ttl)
+			if err != nil {
+				log.Printf("idempotency: reserve key %q: %v", key, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			if rec != nil {
+				replay(w, rec, fingerprint)
+				return
+			}
+
+			serve(w, r, next, store, key)
+		})
+	}
+}
+
+// serve runs the request that reserved key and stores its response.
+func serve(w http.ResponseWriter, r *http.Request, next http.Handler, store domain.IdempotencyStore, key string) {
+	// The client may have given up already; the outcome must still be
+	// recorded for its retry.
+	ctx := context.WithoutCancel(r.Context())
+
+	rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := store.Release(ctx, key); err != nil {
+			log.Printf("idempotency: release key %q: %v", key, err)
+		}
+	}()
+
+	next.ServeHTTP(rec, r)
+
+	if rec.status >= http.StatusInternalServerError {
+		return
+	}
+	resp := domain.StoredResponse{
+		StatusCode:  rec.status,
+		ContentType: rec.Header().Get("Content-Type"),
+		Body:        rec.body.Bytes(),
+	}
+	if err := store.Complete(ctx, key, resp); err != nil {
+		log.Printf("idempotency: complete key %q: %v", key, err)
+		return
+	}
+	completed = true
+}
+
+func replay(w http.ResponseWriter, rec *domain.IdempotencyRecord, fingerprint string) {
+	switch {
+	case rec.Fingerprint != fingerprint:
+		writeError(w, http.StatusUnprocessableEntity, api.IDEMPOTENCYKEYREUSED,
+			"idempotency key was used with a different request")
+	case rec.Response == nil:
+		writeError(w, http.StatusConflict, api.REQUESTINPROGRESS,
+			"a request with this idempotency key is in progress")
+	default:
+		if rec.Response.ContentType != "" {
+			w.Header().Set("Content-Type", rec.Response.ContentType)
+		}
+		w.Header().Set(ReplayedHeader, "true")
+		w.WriteHeader(rec.Response.StatusCode)
+		_, _ = w.Write(rec.Response.Body)
+	}
+}
+
+// requestFingerprint identifies the payload of a request: its method,
+// path with query and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	_, _ = h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recorder passes the response through while keeping a copy for the store.
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rw *recorder) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *recorder) Write(p []byte) (int, error) {
+	rw.wroteHeader = true
+	rw.body.Write(p)
+	return rw.ResponseWriter.Write(p)
+}
+
+func writeError(w http.ResponseWriter, status int, code api.ErrorResponseErrorCode, msg string) {
+	var resp api.ErrorResponse
+	resp.Error.Code = code
+	resp.Error.Message = msg
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,194 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// countingHandler answers with the number of times it has been called, so
+// replays are distinguishable from fresh executions.
+type countingHandler struct {
+	calls  atomic.Int32
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	n := h.calls.Add(1)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(h.status)
+	_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(int(n)) + `}`))
+}
+
+func do(t *testing.T, h http.Handler, method, key, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, "/pullRequest/reassign", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(Header, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) api.ErrorResponseErrorCode {
+	t.Helper()
+	var resp api.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	return resp.Error.Code
+}
+
+func TestMiddleware_ReplaysSameRequest(t *testing.T) {
+	next := &countingHandler{status: http.StatusOK}
+	h := Middleware(NewMemoryStore(&fakeClock{now: time.Now()}), time.Hour)(next)
+
+	first := do(t, h, http.MethodPost, "k1", `{"pull_request_id":"pr1"}`)
+	second := do(t, h, http.MethodPost, "k1", `{"pull_request_id":"pr1"}`)
+
+	if next.calls.Load() != 1 {
+		t.Fatalf("handler called %d times, want 1", next.calls.Load())
+	}
+	if second.Code != first.Code || second.Body.String() != first.Body.String() {
+		t.Fatalf("replay = %d %q, want %d %q", second.Code, second.Body, first.Code, first.Body)
+	}
+	if second.Header().Get(ReplayedHeader) != "true" {
+		t.Fatalf("replay is not marked with %s", ReplayedHeader)
+	}
+	if second.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("replay content type = %q", second.Header().Get("Content-Type"))
+	}
+}
+
+func TestMiddleware_ReplaysClientErrors(t *testing.T) {
+	next := &countingHandler{status: http.StatusConflict}
+	h := Middleware(NewMemoryStore(&fakeClock{now: time.Now()}), time.Hour)(next)
+
+	do(t, h, http.MethodPost, "k1", `{}`)
+	rec := do(t, h, http.MethodPost, "k1", `{}`)
+
+	if rec.Code != http.StatusConflict || next.calls.Load() != 1 {
+		t.Fatalf("got %d after %d calls, want replayed 409", rec.Code, next.calls.Load())
+	}
+}
+
+func TestMiddleware_DifferentPayload(t *testing.T) {
+	next := &countingHandler{status: http.StatusOK}
+	h := Middleware(NewMemoryStore(&fakeClock{now: time.Now()}), time.Hour)(next)
+
+	do(t, h, http.MethodPost, "k1", `{"pull_request_id":"pr1"}`)
+	rec := do(t, h, http.MethodPost, "k1", `{"pull_request_id":"pr2"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want 422", rec.Code)
+	}
+	if code := errorCode(t, rec); code != api.IDEMPOTENCYKEYREUSED {
+		t.Fatalf("code = %s, want %s", code, api.IDEMPOTENCYKEYREUSED)
+	}
+}
+
+func TestMiddleware_InFlight(t *testing.T) {
+	store := NewMemoryStore(&fakeClock{now: time.Now()})
+	var h http.Handler
+	var inner *httptest.ResponseRecorder
+	h = Middleware(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner = do(t, h, http.MethodPost, "k1", `{}`)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	if rec := do(t, h, http.MethodPost, "k1", `{}`); rec.Code != http.StatusOK {
+		t.Fatalf("first status = %d, want 200", rec.Code)
+	}
+	if inner.Code != http.StatusConflict {
+		t.Fatalf("concurrent status = %d, want 409", inner.Code)
+	}
+	if code := errorCode(t, inner); code != api.REQUESTINPROGRESS {
+		t.Fatalf("code = %s, want %s", code, api.REQUESTINPROGRESS)
+	}
+}
+
+func TestMiddleware_ServerErrorReleasesKey(t *testing.T) {
+	next := &countingHandler{status: http.StatusInternalServerError}
+	h := Middleware(NewMemoryStore(&fakeClock{now: time.Now()}), time.Hour)(next)
+
+	do(t, h, http.MethodPost, "k1", `{}`)
+	next.status = http.StatusOK
+	rec := do(t, h, http.MethodPost, "k1", `{}`)
+
+	if rec.Code != http.StatusOK || next.calls.Load() != 2 {
+		t.Fatalf("got %d after %d calls, want a fresh 200", rec.Code, next.calls.Load())
+	}
+}
+
+func TestMiddleware_PanicReleasesKey(t *testing.T) {
+	store := NewMemoryStore(&fakeClock{now: time.Now()})
+	h := Middleware(store, time.Hour)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	func() {
+		defer func() { _ = recover() }()
+		do(t, h, http.MethodPost, "k1", `{}`)
+	}()
+
+	rec, err := store.Reserve(context.Background(), "k1", "fp", time.Hour)
+	if err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if rec != nil {
+		t.Fatalf("key is still held after a panic: %+v", rec)
+	}
+}
+
+func TestMiddleware_PassThrough(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		key    string
+	}{
+		{name: "without key", method: http.MethodPost},
+		{name: "get with key", method: http.MethodGet, key: "k1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &countingHandler{status: http.StatusOK}
+			h := Middleware(NewMemoryStore(&fakeClock{now: time.Now()}), time.Hour)(next)
+
+			do(t, h, tt.method, tt.key, `{}`)
+			rec := do(t, h, tt.method, tt.key, `{}`)
+
+			if next.calls.Load() != 2 || rec.Header().Get(ReplayedHeader) != "" {
+				t.Fatalf("request was replayed, want pass-through")
+			}
+		})
+	}
+}
+
+func TestMiddleware_KeyTooLong(t *testing.T) {
+	next := &countingHandler{status: http.StatusOK}
+	h := Middleware(NewMemoryStore(&fakeClock{now: time.Now()}), time.Hour)(next)
+
+	rec := do(t, h, http.MethodPost, strings.Repeat("k", maxKeyLength+1), `{}`)
+
+	if rec.Code != http.StatusBadRequest || next.calls.Load() != 0 {
+		t.Fatalf("got %d after %d calls, want 400 without calling the handler", rec.Code, next.calls.Load())
+	}
+}
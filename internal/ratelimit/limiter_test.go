@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestLimiter_BurstThenRefill(t *testing.T) {
+	clock := newClock()
+	l := NewLimiter(2, 3, clock)
+
+	for i := range 3 {
+		if ok, _ := l.Allow("c1"); !ok {
+			t.Fatalf("request %d rejected within burst", i+1)
+		}
+	}
+
+	ok, wait := l.Allow("c1")
+	if ok {
+		t.Fatalf("request beyond burst allowed")
+	}
+	if wait != 500*time.Millisecond {
+		t.Fatalf("wait = %v, want 500ms", wait)
+	}
+
+	clock.now = clock.now.Add(wait)
+	if ok, _ := l.Allow("c1"); !ok {
+		t.Fatalf("request rejected after refill")
+	}
+	if ok, _ := l.Allow("c1"); ok {
+		t.Fatalf("refill gave more than one token")
+	}
+}
+
+func TestLimiter_KeysAreIndependent(t *testing.T) {
+	l := NewLimiter(1, 1, newClock())
+
+	if ok, _ := l.Allow("c1"); !ok {
+		t.Fatalf("c1 rejected")
+	}
+	if ok, _ := l.Allow("c1"); ok {
+		t.Fatalf("c1 allowed beyond burst")
+	}
+	if ok, _ := l.Allow("c2"); !ok {
+		t.Fatalf("c2 rejected because of c1")
+	}
+}
+
+func TestLimiter_RefillIsCappedAtBurst(t *testing.T) {
+	clock := newClock()
+	l := NewLimiter(10, 2, clock)
+
+	l.Allow("c1")
+	clock.now = clock.now.Add(time.Hour)
+
+	for i := range 2 {
+		if ok, _ := l.Allow("c1"); !ok {
+			t.Fatalf("request %d rejected", i+1)
+		}
+	}
+	if ok, _ := l.Allow("c1"); ok {
+		t.Fatalf("bucket refilled beyond burst")
+	}
+}
+
+func TestLimiter_SweepsIdleClients(t *testing.T) {
+	clock := newClock()
+	l := NewLimiter(1, 5, clock)
+
+	l.Allow("idle")
+	clock.now = clock.now.Add(sweepInterval)
+	l.Allow("active")
+
+	if got := l.size(); got != 1 {
+		t.Fatalf("tracked clients = %d, want 1", got)
+	}
+}
@@ -0,0 +1,199 @@
+// Package ratelimit protects the service from clients that send too much:
+// per-client token buckets, per-route concurrency limits and request body
+// size caps, all rejected with an ErrorResponse body.
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+// concurrencyRetryAfter is suggested to clients rejected because a route
+// is saturated; such requests usually finish within this time.
+const concurrencyRetryAfter = time.Second
+
+type Config struct {
+	// Rate is the sustained number of requests per second allowed to one
+	// client, Burst the number it may send at once. Zero Rate disables
+	// rate limiting.
+	Rate  float64
+	Burst int
+
+	// RouteConcurrency limits in-flight requests to the listed paths.
+	// Requests to every other path share MaxConcurrent. Zero means no limit.
+	MaxConcurrent    int
+	RouteConcurrency map[string]int
+
+	// MaxBodyBytes caps request bodies, RouteMaxBodyBytes overrides it for
+	// the listed paths. Zero means no limit.
+	MaxBodyBytes      int64
+	RouteMaxBodyBytes map[string]int64
+
+	// ClientTokens are the bearer tokens that identify a client. Requests
+	// with any other token are keyed by address, so that made-up tokens
+	// cannot get a fresh bucket each.
+	ClientTokens []string
+
+	// TrustProxy identifies anonymous clients by the first address in
+	// X-Forwarded-For instead of the connection address.
+	TrustProxy bool
+
+	// ExemptPaths are served without any limits, e.g. health checks.
+	ExemptPaths []string
+}
+
+// Middleware applies cfg to every request. Over-limit requests get 429
+// with Retry-After, oversized bodies get 413.
+func Middleware(cfg Config, clock service.Clock) func(http.Handler) http.Handler {
+	var limiter *Limiter
+	if cfg.Rate > 0 {
+		limiter = NewLimiter(cfg.Rate, cfg.Burst, clock)
+	}
+
+	tokens := make(map[string]struct{}, len(cfg.ClientTokens))
+	for _, token := range cfg.ClientTokens {
+		tokens[token] = struct{}{}
+	}
+
+	shared := newSemaphore(cfg.MaxConcurrent)
+	routes := make(map[string]semaphore, len(cfg.RouteConcurrency))
+	for path, n := range cfg.RouteConcurrency {
+		routes[path] = newSemaphore(n)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(cfg.ExemptPaths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if limiter != nil {
+				if ok, wait := limiter.Allow(clientKey(r, tokens, cfg.TrustProxy)); !ok {
+					writeTooManyRequests(w, wait, "rate limit exceeded")
+					return
+				}
+			}
+
+			sem, ok := routes[r.URL.Path]
+			if !ok {
+				sem = shared
+			}
+			if !sem.tryAcquire() {
+				writeTooManyRequests(w, concurrencyRetryAfter, "too many concurrent requests")
+				return
+			}
+			defer sem.release()
+
+			limit := cfg.MaxBodyBytes
+			if n, ok := cfg.RouteMaxBodyBytes[r.URL.Path]; ok {
+				limit = n
+			}
+			if limit > 0 {
+				if r.ContentLength > limit {
+					writeError(w, http.StatusRequestEntityTooLarge, api.PAYLOADTOOLARGE, "request body is too large")
+					return
+				}
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+				// A body of unknown length is read here, so that going over
+				// the limit is a 413 rather than a decoding error.
+				if r.ContentLength < 0 {
+					body, err := io.ReadAll(r.Body)
+					var tooLarge *http.MaxBytesError
+					if errors.As(err, &tooLarge) {
+						writeError(w, http.StatusRequestEntityTooLarge, api.PAYLOADTOOLARGE, "request body is too large")
+						return
+					}
+					if err != nil {
+						writeError(w, http.StatusBadRequest, api.BADREQUEST, "cannot read request body")
+						return
+					}
+					r.Body = io.NopCloser(bytes.NewReader(body))
+					r.ContentLength = int64(len(body))
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientKey identifies the client: by its bearer token when it is one of
+// tokens, by IP address otherwise.
+func clientKey(r *http.Request, tokens map[string]struct{}, trustProxy bool) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if _, known := tokens[token]; known && token != "" {
+			return "token:" + token
+		}
+	}
+
+	if trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			ip, _, _ := strings.Cut(fwd, ",")
+			return "ip:" + strings.TrimSpace(ip)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// semaphore bounds concurrent requests; a nil semaphore admits everything.
+type semaphore chan struct{}
+
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+	return make(semaphore, n)
+}
+
+func (s semaphore) tryAcquire() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case s <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+func writeTooManyRequests(w http.ResponseWriter, wait time.Duration, msg string) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+	writeError(w, http.StatusTooManyRequests, api.TOOMANYREQUESTS, msg)
+}
+
+func writeError(w http.ResponseWriter, status int, code api.ErrorResponseErrorCode, msg string) {
+	var resp api.ErrorResponse
+	resp.Error.Code = code
+	resp.Error.Message = msg
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,190 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	if _, err := io.ReadAll(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+})
+
+func request(method, path, remoteAddr, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+func serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func assertError(t *testing.T, rec *httptest.ResponseRecorder, status int, code api.ErrorResponseErrorCode) {
+	t.Helper()
+
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d", rec.Code, status)
+	}
+	var resp api.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if resp.Error.Code != code {
+		t.Fatalf("code = %s, want %s", resp.Error.Code, code)
+	}
+}
+
+func TestMiddleware_RateLimit(t *testing.T) {
+	clock := newClock()
+	h := Middleware(Config{Rate: 0.5, Burst: 1}, clock)(okHandler)
+
+	if rec := serve(h, request(http.MethodGet, "/users/getReview", "10.0.0.1:5000", "")); rec.Code != http.StatusOK {
+		t.Fatalf("first request status = %d", rec.Code)
+	}
+
+	rec := serve(h, request(http.MethodGet, "/users/getReview", "10.0.0.1:5001", ""))
+	assertError(t, rec, http.StatusTooManyRequests, api.TOOMANYREQUESTS)
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("Retry-After = %q, want 2", got)
+	}
+
+	if rec := serve(h, request(http.MethodGet, "/users/getReview", "10.0.0.2:5000", "")); rec.Code != http.StatusOK {
+		t.Fatalf("another client was limited: %d", rec.Code)
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     http.Header
+		trustProxy bool
+		want       string
+	}{
+		{name: "ip", want: "ip:10.0.0.1"},
+		{name: "bearer token", header: http.Header{"Authorization": {"Bearer ci-bot"}}, want: "token:ci-bot"},
+		{name: "unknown bearer token", header: http.Header{"Authorization": {"Bearer made-up"}}, want: "ip:10.0.0.1"},
+		{name: "other auth scheme", header: http.Header{"Authorization": {"Basic eDp5"}}, want: "ip:10.0.0.1"},
+		{name: "untrusted proxy", header: http.Header{"X-Forwarded-For": {"203.0.113.7"}}, want: "ip:10.0.0.1"},
+		{name: "trusted proxy", header: http.Header{"X-Forwarded-For": {"203.0.113.7, 10.0.0.1"}}, trustProxy: true,
+			want: "ip:203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := request(http.MethodGet, "/", "10.0.0.1:5000", "")
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			if got := clientKey(req, map[string]struct{}{"ci-bot": {}}, tt.trustProxy); got != tt.want {
+				t.Fatalf("clientKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_RateLimitUnknownTokens(t *testing.T) {
+	h := Middleware(Config{Rate: 0.5, Burst: 1, ClientTokens: []string{"ci-bot"}}, newClock())(okHandler)
+
+	get := func(token string) *httptest.ResponseRecorder {
+		req := request(http.MethodGet, "/users/getReview", "10.0.0.1:5000", "")
+		req.Header.Set("Authorization", "Bearer "+token)
+		return serve(h, req)
+	}
+
+	if rec := get("bogus-1"); rec.Code != http.StatusOK {
+		t.Fatalf("first request status = %d", rec.Code)
+	}
+	for _, token := range []string{"bogus-2", "bogus-3"} {
+		assertError(t, get(token), http.StatusTooManyRequests, api.TOOMANYREQUESTS)
+	}
+
+	if rec := get("ci-bot"); rec.Code != http.StatusOK {
+		t.Fatalf("known token shares the address bucket: %d", rec.Code)
+	}
+}
+
+func TestMiddleware_RouteConcurrency(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var once sync.Once
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stats" {
+			once.Do(func() { close(started) })
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	h := Middleware(Config{RouteConcurrency: map[string]int{"/stats": 1}}, newClock())(slow)
+
+	done := make(chan int)
+	go func() {
+		done <- serve(h, request(http.MethodGet, "/stats", "10.0.0.1:5000", "")).Code
+	}()
+	<-started
+
+	rec := serve(h, request(http.MethodGet, "/stats", "10.0.0.2:5000", ""))
+	assertError(t, rec, http.StatusTooManyRequests, api.TOOMANYREQUESTS)
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("Retry-After is not set")
+	}
+	if rec := serve(h, request(http.MethodGet, "/team/get", "10.0.0.2:5000", "")); rec.Code != http.StatusOK {
+		t.Fatalf("other route was limited: %d", rec.Code)
+	}
+
+	close(release)
+	if code := <-done; code != http.StatusOK {
+		t.Fatalf("in-flight request status = %d", code)
+	}
+	if rec := serve(h, request(http.MethodGet, "/stats", "10.0.0.2:5000", "")); rec.Code != http.StatusOK {
+		t.Fatalf("slot was not released: %d", rec.Code)
+	}
+}
+
+func TestMiddleware_BodyLimit(t *testing.T) {
+	cfg := Config{
+		MaxBodyBytes:      8,
+		RouteMaxBodyBytes: map[string]int64{"/team/import": 64},
+	}
+	h := Middleware(cfg, newClock())(okHandler)
+
+	rec := serve(h, request(http.MethodPost, "/team/add", "10.0.0.1:5000", `{"team_name":"backend"}`))
+	assertError(t, rec, http.StatusRequestEntityTooLarge, api.PAYLOADTOOLARGE)
+
+	if rec := serve(h, request(http.MethodPost, "/team/import", "10.0.0.1:5000", `{"teams":[]}`)); rec.Code != http.StatusOK {
+		t.Fatalf("route override ignored: %d", rec.Code)
+	}
+
+	// Without Content-Length the size is only known once the body is read.
+	req := request(http.MethodPost, "/team/add", "10.0.0.1:5000", `{"team_name":"backend"}`)
+	req.ContentLength = -1
+	assertError(t, serve(h, req), http.StatusRequestEntityTooLarge, api.PAYLOADTOOLARGE)
+
+	req = request(http.MethodPost, "/team/import", "10.0.0.1:5000", `{"teams":[]}`)
+	req.ContentLength = -1
+	if rec := serve(h, req); rec.Code != http.StatusOK {
+		t.Fatalf("streamed body within the limit status = %d", rec.Code)
+	}
+}
+
+func TestMiddleware_ExemptPaths(t *testing.T) {
+	h := Middleware(Config{Rate: 1, Burst: 1, ExemptPaths: []string{"/health"}}, newClock())(okHandler)
+
+	for i := range 3 {
+		if rec := serve(h, request(http.MethodGet, "/health", "10.0.0.1:5000", "")); rec.Code != http.StatusOK {
+			t.Fatalf("health check %d limited: %d", i+1, rec.Code)
+		}
+	}
+}
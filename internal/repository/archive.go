@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+const (
+	queryDumpTeams = `SELECT name FROM teams WHERE tenant_id = $1 AND deleted_at IS NULL ORDER BY name`
+	// queryDumpUsers keeps deleted users, as pull requests and history refer
+	// to them, but drops their membership in deleted teams.
+	queryDumpUsers = `
+	SELECT u.id, u.name, CASE WHEN t.deleted_at IS NULL THEN COALESCE(u.team_name, '') ELSE '' END,
+	u.is_active, u.deleted_at
+	FROM users AS u
+	LEFT JOIN teams AS t ON t.tenant_id = u.tenant_id AND t.name = u.team_name
+	WHERE u.tenant_id = $1 ORDER BY u.id`
+	queryDumpPRs = `
+	SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, archived_at
+	FROM all_pull_requests WHERE tenant_id = $1 ORDER BY pull_request_id`
+	queryDumpReviewers = `
+	SELECT pull_request_id, reviewer_id FROM (
+	    SELECT pull_request_id, reviewer_id, assigned_at FROM pull_request_reviewers WHERE tenant_id = $1
+	    UNION ALL
+	    SELECT pull_request_id, reviewer_id, assigned_at FROM archived_pull_request_reviewers WHERE tenant_id = $1
+	) AS r
+	ORDER BY pull_request_id, assigned_at, reviewer_id`
+	queryDumpAssignments = `
+	SELECT a.pull_request_id, a.reviewer_id, COALESCE(u.team_name, ''), a.assigned_at, a.unassigned_at
+	FROM reviewer_assignments AS a
+	JOIN users AS u ON u.tenant_id = a.tenant_id AND u.id = a.reviewer_id
+	WHERE a.tenant_id = $1
+	ORDER BY a.id`
+	queryDumpEvents = `
+	SELECT e.user_id, COALESCE(u.team_name, ''), e.is_active, e.changed_at
+	FROM user_activity_events AS e
+	JOIN users AS u ON u.tenant_id = e.tenant_id AND u.id = e.user_id
+	WHERE e.tenant_id = $1
+	ORDER BY e.id`
+
+	queryStoreNotEmpty = `
+	SELECT EXISTS (SELECT 1 FROM teams WHERE tenant_id = $1)
+	    OR EXISTS (SELECT 1 FROM users WHERE tenant_id = $1)
+	    OR EXISTS (SELECT 1 FROM pull_requests WHERE tenant_id = $1)
+	    OR EXISTS (SELECT 1 FROM archived_pull_requests WHERE tenant_id = $1)`
+	queryRestoreTeam = `INSERT INTO teams (tenant_id, name) VALUES ($1, $2)`
+	queryRestoreUser = `
+	INSERT INTO users (tenant_id, id, name, team_name, is_active, deleted_at)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)`
+	queryRestorePR = `
+	INSERT INTO pull_requests (tenant_id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+	VALUES ($1, $2, $3, $4, $5, COALESCE($6, NOW()), $7)`
+	queryRestoreReviewer = `
+	INSERT INTO pull_request_reviewers (tenant_id, pull_request_id, reviewer_id, assigned_at)
+	VALUES ($1, $2, $3, COALESCE($4, NOW()))`
+	queryRestoreArchivedPR = `
+	INSERT INTO archived_pull_requests
+	    (tenant_id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at, archived_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	queryRestoreArchivedReviewer = `
+	INSERT INTO archived_pull_request_reviewers (tenant_id, pull_request_id, reviewer_id, assigned_at)
+	VALUES ($1, $2, $3, COALESCE($4, NOW()))`
+	queryRestoreAssignment = `
+	INSERT INTO reviewer_assignments (tenant_id, pull_request_id, reviewer_id, assigned_at, unassigned_at)
+	VALUES ($1, $2, $3, $4, $5)`
+	queryRestoreEvent = `
+	INSERT INTO user_activity_events (tenant_id, user_id, is_active, changed_at)
+	VALUES ($1, $2, $3, $4)`
+)
+
+var _ domain.ArchiveRepository = (*ArchiveRepository)(nil)
+
+type ArchiveRepository struct {
+	db *sql.DB
+}
+
+func NewArchiveRepository(db *sql.DB) domain.ArchiveRepository {
+	return &ArchiveRepository{
+		db: db,
+	}
+}
+
+func (r *ArchiveRepository) Dump(ctx context.Context) (*domain.Archive, error) {
+	// Repeatable read gives every query below the same snapshot.
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx for dump: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	var archive domain.Archive
+
+	err = eachRow(ctx, tx, queryDumpTeams, tenant, func(rows *sql.Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		archive.Teams = append(archive.Teams, name)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump teams: %w", err)
+	}
+
+	err = eachRow(ctx, tx, queryDumpUsers, tenant, func(rows *sql.Rows) error {
+		var (
+			u         domain.User
+			deletedAt sql.NullTime
+		)
+		if err := rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive, &deletedAt); err != nil {
+			return err
+		}
+		u.DeletedAt = deletedAt.Time
+		archive.Users = append(archive.Users, u)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump users: %w", err)
+	}
+
+	index := make(map[string]int)
+	err = eachRow(ctx, tx, queryDumpPRs, tenant, func(rows *sql.Rows) error {
+		var (
+			pr                              domain.PullRequest
+			createdAt, mergedAt, archivedAt sql.NullTime
+		)
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt, &archivedAt); err != nil {
+			return err
+		}
+		pr.CreatedAt = createdAt.Time
+		pr.MergedAt = mergedAt.Time
+		pr.ArchivedAt = archivedAt.Time
+		index[pr.ID] = len(archive.PullRequests)
+		archive.PullRequests = append(archive.PullRequests, pr)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump pull requests: %w", err)
+	}
+
+	err = eachRow(ctx, tx, queryDumpReviewers, tenant, func(rows *sql.Rows) error {
+		var prID, reviewerID string
+		if err := rows.Scan(&prID, &reviewerID); err != nil {
+			return err
+		}
+		if i, ok := index[prID]; ok {
+			archive.PullRequests[i].Reviewers = append(archive.PullRequests[i].Reviewers, reviewerID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump reviewers: %w", err)
+	}
+
+	err = eachRow(ctx, tx, queryDumpAssignments, tenant, func(rows *sql.Rows) error {
+		var (
+			a            domain.AssignmentRecord
+			unassignedAt sql.NullTime
+		)
+		if err := rows.Scan(&a.PullRequestID, &a.ReviewerID, &a.TeamName, &a.AssignedAt, &unassignedAt); err != nil {
+			return err
+		}
+		a.UnassignedAt = unassignedAt.Time
+		archive.Assignments = append(archive.Assignments, a)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump assignments: %w", err)
+	}
+
+	err = eachRow(ctx, tx, queryDumpEvents, tenant, func(rows *sql.Rows) error {
+		var e domain.ActivityEvent
+		if err := rows.Scan(&e.UserID, &e.TeamName, &e.IsActive, &e.ChangedAt); err != nil {
+			return err
+		}
+		archive.ActivityEvents = append(archive.ActivityEvents, e)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump activity events: %w", err)
+	}
+
+	return &archive, nil
+}
+
+func (r *ArchiveRepository) Restore(ctx context.Context, archive *domain.Archive) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return fmt.Errorf("begin tx for restore: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	var nonEmpty bool
+	if err := tx.QueryRowContext(ctx, queryStoreNotEmpty, tenant).Scan(&nonEmpty); err != nil {
+		return fmt.Errorf("check store is empty: %w", err)
+	}
+	if nonEmpty {
+		return domain.NewError(domain.ErrorCodeStoreNotEmpty, "restore requires an empty store")
+	}
+
+	for _, name := range archive.Teams {
+		if _, err := tx.ExecContext(ctx, queryRestoreTeam, tenant, name); err != nil {
+			return fmt.Errorf("restore team %s: %w", name, err)
+		}
+	}
+
+	for _, u := range archive.Users {
+		if _, err := tx.ExecContext(ctx, queryRestoreUser,
+			tenant, u.ID, u.Name, u.TeamName, u.IsActive, nullTime(u.DeletedAt),
+		); err != nil {
+			return fmt.Errorf("restore user %s: %w", u.ID, err)
+		}
+	}
+
+	// Current reviewers keep the assigned_at of their open assignment.
+	openSince := make(map[[2]string]time.Time)
+	for _, a := range archive.Assignments {
+		if a.UnassignedAt.IsZero() {
+			openSince[[2]string{a.PullRequestID, a.ReviewerID}] = a.AssignedAt
+		}
+	}
+
+	for _, pr := range archive.PullRequests {
+		queryPR, queryReviewer := queryRestorePR, queryRestoreReviewer
+		args := []any{tenant, pr.ID, pr.Name, pr.AuthorID, pr.Status, nullTime(pr.CreatedAt), nullTime(pr.MergedAt)}
+		if !pr.ArchivedAt.IsZero() {
+			queryPR, queryReviewer = queryRestoreArchivedPR, queryRestoreArchivedReviewer
+			args = append(args, pr.ArchivedAt)
+		}
+		if _, err := tx.ExecContext(ctx, queryPR, args...); err != nil {
+			return fmt.Errorf("restore pull request %s: %w", pr.ID, err)
+		}
+
+		for _, reviewerID := range pr.Reviewers {
+			assignedAt := openSince[[2]string{pr.ID, reviewerID}]
+			if _, err := tx.ExecContext(ctx, queryReviewer, tenant, pr.ID, reviewerID, nullTime(assignedAt)); err != nil {
+				return fmt.Errorf("restore reviewer %s of %s: %w", reviewerID, pr.ID, err)
+			}
+		}
+	}
+
+	for _, a := range archive.Assignments {
+		if _, err := tx.ExecContext(ctx, queryRestoreAssignment,
+			tenant, a.PullRequestID, a.ReviewerID, a.AssignedAt, nullTime(a.UnassignedAt),
+		); err != nil {
+			return fmt.Errorf("restore assignment %s/%s: %w", a.PullRequestID, a.ReviewerID, err)
+		}
+	}
+
+	for _, e := range archive.ActivityEvents {
+		if _, err := tx.ExecContext(ctx, queryRestoreEvent, tenant, e.UserID, e.IsActive, e.ChangedAt); err != nil {
+			return fmt.Errorf("restore activity of %s: %w", e.UserID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx for restore: %w", err)
+	}
+
+	return nil
+}
+
+// eachRow runs query for tenant inside tx and calls fn for every row.
+func eachRow(ctx context.Context, tx *sql.Tx, query, tenant string, fn func(*sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, tenant)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
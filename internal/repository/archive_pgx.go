@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ domain.ArchiveRepository = (*PgxArchiveRepository)(nil)
+
+type PgxArchiveRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxArchiveRepository(pool *pgxpool.Pool) domain.ArchiveRepository {
+	return &PgxArchiveRepository{
+		pool: pool,
+	}
+}
+
+func (r *PgxArchiveRepository) Dump(ctx context.Context) (*domain.Archive, error) {
+	// Repeatable read gives every query below the same snapshot.
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx for dump: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	var archive domain.Archive
+
+	var name string
+	err = eachPgxRow(ctx, tx, queryDumpTeams, tenant, []any{&name}, func() error {
+		archive.Teams = append(archive.Teams, name)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump teams: %w", err)
+	}
+
+	var (
+		u         domain.User
+		deletedAt sql.NullTime
+	)
+	err = eachPgxRow(ctx, tx, queryDumpUsers, tenant, []any{&u.ID, &u.Name, &u.TeamName, &u.IsActive, &deletedAt}, func() error {
+		u.DeletedAt = deletedAt.Time
+		archive.Users = append(archive.Users, u)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump users: %w", err)
+	}
+
+	var (
+		pr                              domain.PullRequest
+		createdAt, mergedAt, archivedAt sql.NullTime
+		index                           = make(map[string]int)
+	)
+	err = eachPgxRow(ctx, tx, queryDumpPRs, tenant, []any{&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt, &archivedAt}, func() error {
+		pr.CreatedAt = createdAt.Time
+		pr.MergedAt = mergedAt.Time
+		pr.ArchivedAt = archivedAt.Time
+		index[pr.ID] = len(archive.PullRequests)
+		archive.PullRequests = append(archive.PullRequests, pr)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump pull requests: %w", err)
+	}
+
+	var prID, reviewerID string
+	err = eachPgxRow(ctx, tx, queryDumpReviewers, tenant, []any{&prID, &reviewerID}, func() error {
+		if i, ok := index[prID]; ok {
+			archive.PullRequests[i].Reviewers = append(archive.PullRequests[i].Reviewers, reviewerID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump reviewers: %w", err)
+	}
+
+	var (
+		a            domain.AssignmentRecord
+		unassignedAt sql.NullTime
+	)
+	err = eachPgxRow(ctx, tx, queryDumpAssignments, tenant, []any{&a.PullRequestID, &a.ReviewerID, &a.TeamName, &a.AssignedAt, &unassignedAt}, func() error {
+		a.UnassignedAt = unassignedAt.Time
+		archive.Assignments = append(archive.Assignments, a)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump assignments: %w", err)
+	}
+
+	var e domain.ActivityEvent
+	err = eachPgxRow(ctx, tx, queryDumpEvents, tenant, []any{&e.UserID, &e.TeamName, &e.IsActive, &e.ChangedAt}, func() error {
+		archive.ActivityEvents = append(archive.ActivityEvents, e)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dump activity events: %w", err)
+	}
+
+	return &archive, nil
+}
+
+// Restore bulk-loads the tables without defaults to fill in with COPY and
+// sends pull requests and current reviewers as one batch.
+func (r *PgxArchiveRepository) Restore(ctx context.Context, archive *domain.Archive) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return fmt.Errorf("begin tx for restore: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	var nonEmpty bool
+	if err := tx.QueryRow(ctx, queryStoreNotEmpty, tenant).Scan(&nonEmpty); err != nil {
+		return fmt.Errorf("check store is empty: %w", err)
+	}
+	if nonEmpty {
+		return domain.NewError(domain.ErrorCodeStoreNotEmpty, "restore requires an empty store")
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"teams"}, []string{"tenant_id", "name"},
+		pgx.CopyFromSlice(len(archive.Teams), func(i int) ([]any, error) {
+			return []any{tenant, archive.Teams[i]}, nil
+		}))
+	if err != nil {
+		return fmt.Errorf("restore teams: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"users"}, []string{"tenant_id", "id", "name", "team_name", "is_active", "deleted_at"},
+		pgx.CopyFromSlice(len(archive.Users), func(i int) ([]any, error) {
+			u := archive.Users[i]
+			return []any{tenant, u.ID, u.Name, nullString(u.TeamName), u.IsActive, nullTime(u.DeletedAt)}, nil
+		}))
+	if err != nil {
+		return fmt.Errorf("restore users: %w", err)
+	}
+
+	// Current reviewers keep the assigned_at of their open assignment.
+	openSince := make(map[[2]string]time.Time)
+	for _, a := range archive.Assignments {
+		if a.UnassignedAt.IsZero() {
+			openSince[[2]string{a.PullRequestID, a.ReviewerID}] = a.AssignedAt
+		}
+	}
+
+	b := &pgx.Batch{}
+	for _, pr := range archive.PullRequests {
+		queryPR, queryReviewer := queryRestorePR, queryRestoreReviewer
+		args := []any{tenant, pr.ID, pr.Name, pr.AuthorID, pr.Status, nullTime(pr.CreatedAt), nullTime(pr.MergedAt)}
+		if !pr.ArchivedAt.IsZero() {
+			queryPR, queryReviewer = queryRestoreArchivedPR, queryRestoreArchivedReviewer
+			args = append(args, pr.ArchivedAt)
+		}
+		b.Queue(queryPR, args...)
+		for _, reviewerID := range pr.Reviewers {
+			b.Queue(queryReviewer, tenant, pr.ID, reviewerID, nullTime(openSince[[2]string{pr.ID, reviewerID}]))
+		}
+	}
+
+	br := tx.SendBatch(ctx, b)
+	for _, pr := range archive.PullRequests {
+		if _, err := br.Exec(); err != nil {
+			_ = br.Close()
+			return fmt.Errorf("restore pull request %s: %w", pr.ID, err)
+		}
+		for _, reviewerID := range pr.Reviewers {
+			if _, err := br.Exec(); err != nil {
+				_ = br.Close()
+				return fmt.Errorf("restore reviewer %s of %s: %w", reviewerID, pr.ID, err)
+			}
+		}
+	}
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("close restore batch: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"reviewer_assignments"},
+		[]string{"tenant_id", "pull_request_id", "reviewer_id", "assigned_at", "unassigned_at"},
+		pgx.CopyFromSlice(len(archive.Assignments), func(i int) ([]any, error) {
+			a := archive.Assignments[i]
+			return []any{tenant, a.PullRequestID, a.ReviewerID, a.AssignedAt, nullTime(a.UnassignedAt)}, nil
+		}))
+	if err != nil {
+		return fmt.Errorf("restore assignments: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"user_activity_events"}, []string{"tenant_id", "user_id", "is_active", "changed_at"},
+		pgx.CopyFromSlice(len(archive.ActivityEvents), func(i int) ([]any, error) {
+			e := archive.ActivityEvents[i]
+			return []any{tenant, e.UserID, e.IsActive, e.ChangedAt}, nil
+		}))
+	if err != nil {
+		return fmt.Errorf("restore activity events: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx for restore: %w", err)
+	}
+
+	return nil
+}
+
+// eachPgxRow runs query for tenant inside tx, scans every row into scans
+// and calls fn.
+func eachPgxRow(ctx context.Context, tx pgx.Tx, query, tenant string, scans []any, fn func() error) error {
+	rows, err := tx.Query(ctx, query, tenant)
+	if err != nil {
+		return err
+	}
+
+	_, err = pgx.ForEachRow(rows, scans, fn)
+	return err
+}
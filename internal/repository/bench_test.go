@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/pgtest"
+)
+
+// The benchmarks compare the database/sql and pgx pool repositories on the
+// same schema:
+//
+//	go test -run '^$' -bench . ./internal/repository
+
+type benchTarget struct {
+	name string
+	team domain.TeamRepository
+	user domain.UserRepository
+	pr   domain.PRRepository
+}
+
+func benchTargets(b *testing.B) []benchTarget {
+	b.Helper()
+
+	db := pgtest.NewDB(b)
+	return []benchTarget{
+		{"sql", NewTeamRepository(db.SQL), NewUserRepository(db.SQL), NewPRRepository(db.SQL)},
+		{"pgx", NewPgxTeamRepository(db.Pool), NewPgxUserRepository(db.Pool), NewPgxPRRepository(db.Pool)},
+	}
+}
+
+// benchPrefix names the rows of one benchmark round; b.Run calls the
+// function once per b.N it tries.
+func benchPrefix(target string) string {
+	return fmt.Sprintf("bench-%s-%d", target, time.Now().UnixNano())
+}
+
+func benchTeam(b *testing.B, t benchTarget, size int) (string, []domain.User) {
+	b.Helper()
+
+	ctx := context.Background()
+	name := benchPrefix(t.name)
+	if err := t.team.Create(ctx, &domain.Team{Name: name}); err != nil {
+		b.Fatalf("create team: %v", err)
+	}
+
+	users := make([]domain.User, size)
+	for i := range users {
+		users[i] = domain.User{
+			ID:       fmt.Sprintf("%s-u%d", name, i),
+			Name:     fmt.Sprintf("User %d", i),
+			TeamName: name,
+			IsActive: true,
+		}
+	}
+	if err := t.user.SaveAll(ctx, users); err != nil {
+		b.Fatalf("save users: %v", err)
+	}
+	return name, users
+}
+
+func BenchmarkSaveAll(b *testing.B) {
+	for _, t := range benchTargets(b) {
+		b.Run(t.name, func(b *testing.B) {
+			ctx := context.Background()
+			_, users := benchTeam(b, t, 100)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := range users {
+					users[j].IsActive = i%2 == 0
+				}
+				if err := t.user.SaveAll(ctx, users); err != nil {
+					b.Fatalf("save users: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSetReviewers(b *testing.B) {
+	for _, t := range benchTargets(b) {
+		b.Run(t.name, func(b *testing.B) {
+			ctx := context.Background()
+			name, users := benchTeam(b, t, 5)
+
+			prID := name + "-pr"
+			_, err := t.pr.Create(ctx, domain.PullRequest{
+				ID:       prID,
+				Name:     "bench",
+				AuthorID: users[0].ID,
+				Status:   domain.PRStatusOpen,
+			})
+			if err != nil {
+				b.Fatalf("create pull request: %v", err)
+			}
+
+			sets := [][]string{
+				{users[1].ID, users[2].ID},
+				{users[3].ID, users[4].ID},
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := t.pr.SetReviewers(ctx, prID, sets[i%2]); err != nil {
+					b.Fatalf("set reviewers: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetByName(b *testing.B) {
+	for _, t := range benchTargets(b) {
+		b.Run(t.name, func(b *testing.B) {
+			ctx := context.Background()
+			name, _ := benchTeam(b, t, 20)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := t.team.GetByName(ctx, name); err != nil {
+					b.Fatalf("get team: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+	"github.com/ChernykhITMO/Avito/internal/testutil/pgtest"
+	"github.com/ChernykhITMO/Avito/internal/testutil/repotest"
+)
+
+func TestConformance_SQL(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		db := pgtest.NewDB(t)
+		return repotest.Repos{
+			Repos: fixtures.Repos{
+				Teams: NewTeamRepository(db.SQL),
+				Users: NewUserRepository(db.SQL),
+				PRs:   NewPRRepository(db.SQL),
+			},
+			Stats: NewStatsRepository(db.SQL),
+		}
+	})
+}
+
+func TestConformance_Pgx(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		db := pgtest.NewDB(t)
+		return repotest.Repos{
+			Repos: fixtures.Repos{
+				Teams: NewPgxTeamRepository(db.Pool),
+				Users: NewPgxUserRepository(db.Pool),
+				PRs:   NewPgxPRRepository(db.Pool),
+			},
+			Stats: NewPgxStatsRepository(db.Pool),
+		}
+	})
+}
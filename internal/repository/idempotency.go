@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+const (
+	queryPurgeIdempotencyKeys = `DELETE FROM idempotency_keys WHERE expires_at <= NOW()`
+
+	queryReserveIdempotencyKey = `
+	INSERT INTO idempotency_keys (tenant_id, key, fingerprint, expires_at)
+	VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+	ON CONFLICT (tenant_id, key) DO NOTHING`
+
+	queryGetIdempotencyKey = `
+	SELECT fingerprint, status_code, content_type, body
+	FROM idempotency_keys
+	WHERE tenant_id = $1 AND key = $2`
+
+	queryCompleteIdempotencyKey = `
+	UPDATE idempotency_keys
+	SET status_code = $3, content_type = $4, body = $5
+	WHERE tenant_id = $1 AND key = $2`
+
+	queryReleaseIdempotencyKey = `DELETE FROM idempotency_keys WHERE tenant_id = $1 AND key = $2 AND status_code IS NULL`
+)
+
+var _ domain.IdempotencyStore = (*IdempotencyRepository)(nil)
+
+// reserveAttempts bounds the retries of Reserve when the key it found taken
+// is released before it could be read.
+const reserveAttempts = 3
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) domain.IdempotencyStore {
+	return &IdempotencyRepository{
+		db: db,
+	}
+}
+
+func (r *IdempotencyRepository) Reserve(
+	ctx context.Context,
+	key, fingerprint string,
+	ttl time.Duration,
+) (*domain.IdempotencyRecord, error) {
+	if _, err := r.db.ExecContext(ctx, queryPurgeIdempotencyKeys); err != nil {
+		return nil, fmt.Errorf("purge expired idempotency keys: %w", err)
+	}
+
+	tenant := domain.TenantFrom(ctx)
+	for range reserveAttempts {
+		res, err := r.db.ExecContext(ctx, queryReserveIdempotencyKey, tenant, key, fingerprint, ttl.Seconds())
+		if err != nil {
+			return nil, fmt.Errorf("reserve idempotency key: %w", err)
+		}
+		inserted, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("rows affected for reserve idempotency key: %w", err)
+		}
+		if inserted == 1 {
+			return nil, nil
+		}
+
+		var (
+			rec         domain.IdempotencyRecord
+			statusCode  sql.NullInt64
+			contentType string
+			body        []byte
+		)
+		err = r.db.QueryRowContext(ctx, queryGetIdempotencyKey, tenant, key).Scan(&rec.Fingerprint, &statusCode, &contentType, &body)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get idempotency key: %w", err)
+		}
+		if statusCode.Valid {
+			rec.Response = &domain.StoredResponse{
+				StatusCode:  int(statusCode.Int64),
+				ContentType: contentType,
+				Body:        body,
+			}
+		}
+		return &rec, nil
+	}
+	return nil, fmt.Errorf("reserve idempotency key: key %q keeps being released", key)
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, resp domain.StoredResponse) error {
+	if _, err := r.db.ExecContext(ctx, queryCompleteIdempotencyKey, domain.TenantFrom(ctx), key, resp.StatusCode, resp.ContentType, resp.Body); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	if _, err := r.db.ExecContext(ctx, queryReleaseIdempotencyKey, domain.TenantFrom(ctx), key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ domain.IdempotencyStore = (*PgxIdempotencyRepository)(nil)
+
+type PgxIdempotencyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxIdempotencyRepository(pool *pgxpool.Pool) domain.IdempotencyStore {
+	return &PgxIdempotencyRepository{
+		pool: pool,
+	}
+}
+
+func (r *PgxIdempotencyRepository) Reserve(
+	ctx context.Context,
+	key, fingerprint string,
+	ttl time.Duration,
+) (*domain.IdempotencyRecord, error) {
+	if _, err := r.pool.Exec(ctx, queryPurgeIdempotencyKeys); err != nil {
+		return nil, fmt.Errorf("purge expired idempotency keys: %w", err)
+	}
+
+	tenant := domain.TenantFrom(ctx)
+	for range reserveAttempts {
+		tag, err := r.pool.Exec(ctx, queryReserveIdempotencyKey, tenant, key, fingerprint, ttl.Seconds())
+		if err != nil {
+			return nil, fmt.Errorf("reserve idempotency key: %w", err)
+		}
+		if tag.RowsAffected() == 1 {
+			return nil, nil
+		}
+
+		var (
+			rec         domain.IdempotencyRecord
+			statusCode  sql.NullInt64
+			contentType string
+			body        []byte
+		)
+		err = r.pool.QueryRow(ctx, queryGetIdempotencyKey, tenant, key).Scan(&rec.Fingerprint, &statusCode, &contentType, &body)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get idempotency key: %w", err)
+		}
+		if statusCode.Valid {
+			rec.Response = &domain.StoredResponse{
+				StatusCode:  int(statusCode.Int64),
+				ContentType: contentType,
+				Body:        body,
+			}
+		}
+		return &rec, nil
+	}
+	return nil, fmt.Errorf("reserve idempotency key: key %q keeps being released", key)
+}
+
+func (r *PgxIdempotencyRepository) Complete(ctx context.Context, key string, resp domain.StoredResponse) error {
+	if _, err := r.pool.Exec(ctx, queryCompleteIdempotencyKey, domain.TenantFrom(ctx), key, resp.StatusCode, resp.ContentType, resp.Body); err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *PgxIdempotencyRepository) Release(ctx context.Context, key string) error {
+	if _, err := r.pool.Exec(ctx, queryReleaseIdempotencyKey, domain.TenantFrom(ctx), key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -6,8 +6,128 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// queryCreatePR inserts nothing if the id belongs to an archived pull
+	// request.
+	queryCreatePR = `
+	INSERT INTO pull_requests (tenant_id, pull_request_id, pull_request_name, author_id, status)
+	SELECT $1, $2, $3, $4, $5
+	WHERE NOT EXISTS (SELECT 1 FROM archived_pull_requests WHERE tenant_id = $1 AND pull_request_id = $2)
+	RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at`
+
+	queryGetPR = `
+	SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+	FROM pull_requests
+	WHERE tenant_id = $1 AND pull_request_id = $2`
+
+	queryUpdatePRStatus = `
+	UPDATE pull_requests
+	SET
+	    status = $3,
+	    merged_at = CASE WHEN $3 = 'MERGED' THEN NOW() ELSE merged_at END
+	WHERE tenant_id = $1 AND pull_request_id = $2`
+
+	// queryLockPR makes concurrent reviewer changes of one pull request wait
+	// for each other; otherwise each would keep the reviewers the other added.
+	queryLockPR = `
+	SELECT pull_request_id FROM pull_requests
+	WHERE tenant_id = $1 AND pull_request_id = $2
+	FOR UPDATE`
+
+	// Reviewers that stay on the pull request keep their original assigned_at.
+	queryUnassignReviewers = `
+	UPDATE reviewer_assignments SET unassigned_at = NOW()
+	WHERE tenant_id = $1 AND pull_request_id = $2 AND unassigned_at IS NULL AND NOT (reviewer_id = ANY($3))`
+
+	queryDeleteReviewers = `
+	DELETE FROM pull_request_reviewers
+	WHERE tenant_id = $1 AND pull_request_id = $2 AND NOT (reviewer_id = ANY($3))`
+
+	queryInsertReviewer = `
+	INSERT INTO pull_request_reviewers (tenant_id, pull_request_id, reviewer_id)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (tenant_id, pull_request_id, reviewer_id) DO NOTHING`
+
+	queryLogAssignment = `
+	INSERT INTO reviewer_assignments (tenant_id, pull_request_id, reviewer_id)
+	VALUES ($1, $2, $3)`
+
+	queryListPRsByReviewer = `
+	SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id,
+	pr.status, pr.created_at, pr.merged_at
+	FROM pull_requests AS pr
+	JOIN pull_request_reviewers AS r
+	ON r.tenant_id = pr.tenant_id AND r.pull_request_id = pr.pull_request_id
+	WHERE r.tenant_id = $1 AND r.reviewer_id = $2
+	ORDER BY pr.created_at, pr.pull_request_id`
+
+	queryListReviewers = `
+	SELECT reviewer_id
+	FROM pull_request_reviewers
+	WHERE tenant_id = $1 AND pull_request_id = $2
+	ORDER BY assigned_at, reviewer_id`
+
+	queryListOverdueReviews = `
+	SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id,
+	r.reviewer_id, COALESCE(u.team_name, ''), r.assigned_at
+	FROM pull_request_reviewers AS r
+	JOIN pull_requests AS pr ON pr.tenant_id = r.tenant_id AND pr.pull_request_id = r.pull_request_id
+	JOIN users AS u ON u.tenant_id = r.tenant_id AND u.id = r.reviewer_id
+	WHERE r.tenant_id = $1
+	AND pr.status = 'OPEN'
+	AND r.assigned_at <= $2
+	AND ($3 = '' OR u.team_name = $3)
+	ORDER BY r.assigned_at, pr.pull_request_id, r.reviewer_id`
+
+	// queryArchivePRs moves merged pull requests and their reviewers in one
+	// statement; the foreign keys are checked once it is done.
+	queryArchivePRs = `
+	WITH moved AS (
+	    DELETE FROM pull_requests
+	    WHERE tenant_id = $1 AND status = 'MERGED' AND merged_at < $2
+	    RETURNING tenant_id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+	), moved_reviewers AS (
+	    DELETE FROM pull_request_reviewers AS r
+	    USING moved AS m
+	    WHERE r.tenant_id = m.tenant_id AND r.pull_request_id = m.pull_request_id
+	    RETURNING r.tenant_id, r.pull_request_id, r.reviewer_id, r.assigned_at
+	), archived AS (
+	    INSERT INTO archived_pull_requests
+	        (tenant_id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at)
+	    SELECT tenant_id, pull_request_id, pull_request_name, author_id, status, created_at, merged_at FROM moved
+	    RETURNING pull_request_id
+	), archived_reviewers AS (
+	    INSERT INTO archived_pull_request_reviewers (tenant_id, pull_request_id, reviewer_id, assigned_at)
+	    SELECT tenant_id, pull_request_id, reviewer_id, assigned_at FROM moved_reviewers
+	)
+	SELECT COUNT(*) FROM archived`
+
+	queryGetArchivedPR = `
+	SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, archived_at
+	FROM archived_pull_requests
+	WHERE tenant_id = $1 AND pull_request_id = $2`
+
+	queryListArchivedReviewers = `
+	SELECT reviewer_id
+	FROM archived_pull_request_reviewers
+	WHERE tenant_id = $1 AND pull_request_id = $2
+	ORDER BY assigned_at, reviewer_id`
+
+	queryListArchivedPRsByReviewer = `
+	SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id,
+	pr.status, pr.created_at, pr.merged_at, pr.archived_at
+	FROM archived_pull_requests AS pr
+	JOIN archived_pull_request_reviewers AS r
+	ON r.tenant_id = pr.tenant_id AND r.pull_request_id = pr.pull_request_id
+	WHERE r.tenant_id = $1 AND r.reviewer_id = $2
+	ORDER BY pr.merged_at, pr.pull_request_id`
 )
 
 var _ domain.PRRepository = (*PRRepository)(nil)
@@ -23,24 +143,13 @@ func NewPRRepository(db *sql.DB) domain.PRRepository {
 }
 
 func (r *PRRepository) Create(ctx context.Context, req domain.PullRequest) (*domain.PullRequest, error) {
-	const query = `
-    INSERT INTO pull_requests (
-        pull_request_id,
-        pull_request_name,
-        author_id,
-        status
-    )
-    VALUES ($1, $2, $3, $4)
-    RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-    `
-
 	var (
 		pr       domain.PullRequest
 		mergedAt sql.NullTime
 	)
 
-	err := r.db.QueryRowContext(ctx, query,
-		req.ID, req.Name, req.AuthorID, req.Status,
+	err := r.db.QueryRowContext(ctx, queryCreatePR,
+		domain.TenantFrom(ctx), req.ID, req.Name, req.AuthorID, req.Status,
 	).Scan(
 		&pr.ID,
 		&pr.Name,
@@ -51,6 +160,9 @@ func (r *PRRepository) Create(ctx context.Context, req domain.PullRequest) (*dom
 	)
 
 	if err != nil {
+		if prExists(err) {
+			return nil, domain.NewError(domain.ErrorCodePRExists, "pull request already exists")
+		}
 		return nil, fmt.Errorf("create pull request: %w", err)
 	}
 
@@ -61,19 +173,23 @@ func (r *PRRepository) Create(ctx context.Context, req domain.PullRequest) (*dom
 	return &pr, nil
 }
 
-func (r *PRRepository) Get(ctx context.Context, id string) (*domain.PullRequest, error) {
-	const query = `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-		FROM pull_requests
-		WHERE pull_request_id = $1
-	`
+// prExists reports whether creating a pull request failed because its id
+// is taken by a live or an archived pull request.
+func prExists(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return true
+	}
+	return errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows)
+}
 
+func (r *PRRepository) Get(ctx context.Context, id string) (*domain.PullRequest, error) {
 	var (
 		pr       domain.PullRequest
 		mergedAt sql.NullTime
 	)
 
-	if err := r.db.QueryRowContext(ctx, query, id).
+	if err := r.db.QueryRowContext(ctx, queryGetPR, domain.TenantFrom(ctx), id).
 		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
@@ -89,15 +205,7 @@ func (r *PRRepository) Get(ctx context.Context, id string) (*domain.PullRequest,
 }
 
 func (r *PRRepository) Update(ctx context.Context, id string, status domain.PRStatus) error {
-	const query = `
-		UPDATE pull_requests
-		SET	
-		    status = $2,
-		    merged_at = CASE WHEN $2 = 'MERGED' THEN NOW() ELSE merged_at END
-		WHERE pull_request_id = $1
-	`
-
-	res, err := r.db.ExecContext(ctx, query, id, status)
+	res, err := r.db.ExecContext(ctx, queryUpdatePRStatus, domain.TenantFrom(ctx), id, status)
 	if err != nil {
 		return fmt.Errorf("update pull request: %w", err)
 	}
@@ -123,20 +231,44 @@ func (r *PRRepository) SetReviewers(ctx context.Context, id string, reviewers []
 		_ = tx.Rollback()
 	}()
 
-	const queryDelete = `DELETE FROM pull_request_reviewers WHERE pull_request_id = $1`
+	keep := reviewers
+	if keep == nil {
+		keep = []string{}
+	}
+	tenant := domain.TenantFrom(ctx)
 
-	if _, err := tx.ExecContext(ctx, queryDelete, id); err != nil {
-		return fmt.Errorf("delete reviewers: %w", err)
+	if err := tx.QueryRowContext(ctx, queryLockPR, tenant, id).Scan(new(string)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+		}
+		return fmt.Errorf("lock pull request: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryUnassignReviewers, tenant, id, keep); err != nil {
+		return fmt.Errorf("close reviewer assignments: %w", err)
 	}
 
-	const queryInsert = `
-	INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
-	VALUES ($1, $2)`
+	if _, err := tx.ExecContext(ctx, queryDeleteReviewers, tenant, id, keep); err != nil {
+		return fmt.Errorf("delete reviewers: %w", err)
+	}
 
 	for _, revID := range reviewers {
-		if _, err := tx.ExecContext(ctx, queryInsert, id, revID); err != nil {
+		res, err := tx.ExecContext(ctx, queryInsertReviewer, tenant, id, revID)
+		if err != nil {
+			return fmt.Errorf("insert reviewer: %w", err)
+		}
+
+		inserted, err := res.RowsAffected()
+		if err != nil {
 			return fmt.Errorf("insert reviewer: %w", err)
 		}
+		if inserted == 0 {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, queryLogAssignment, tenant, id, revID); err != nil {
+			return fmt.Errorf("log reviewer assignment: %w", err)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -147,16 +279,7 @@ func (r *PRRepository) SetReviewers(ctx context.Context, id string, reviewers []
 }
 
 func (r *PRRepository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
-	const query = `
-	SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, 
-	pr.status, pr.created_at, pr.merged_at
-	FROM pull_requests as pr
-	JOIN pull_request_reviewers as r 
-	ON r.pull_request_id = pr.pull_request_id
-	WHERE r.reviewer_id = $1
-`
-
-	rows, err := r.db.QueryContext(ctx, query, reviewerID)
+	rows, err := r.db.QueryContext(ctx, queryListPRsByReviewer, domain.TenantFrom(ctx), reviewerID)
 	if err != nil {
 		return nil, fmt.Errorf("select reviewers: %w", err)
 	}
@@ -189,13 +312,7 @@ func (r *PRRepository) ListByReviewer(ctx context.Context, reviewerID string) ([
 }
 
 func (r *PRRepository) ListReviewers(ctx context.Context, prID string) ([]string, error) {
-	const query = `
-		SELECT reviewer_id
-		FROM pull_request_reviewers
-		WHERE pull_request_id = $1
-	`
-
-	rows, err := r.db.QueryContext(ctx, query, prID)
+	rows, err := r.db.QueryContext(ctx, queryListReviewers, domain.TenantFrom(ctx), prID)
 	if err != nil {
 		return nil, fmt.Errorf("list reviewers: %w", err)
 	}
@@ -220,3 +337,111 @@ func (r *PRRepository) ListReviewers(ctx context.Context, prID string) ([]string
 
 	return reviewers, nil
 }
+
+func (r *PRRepository) ListOverdueReviews(ctx context.Context, assignedBefore time.Time, teamName string) ([]domain.ReviewAssignment, error) {
+	rows, err := r.db.QueryContext(ctx, queryListOverdueReviews, domain.TenantFrom(ctx), assignedBefore, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("list overdue reviews: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	var reviews []domain.ReviewAssignment
+	for rows.Next() {
+		var a domain.ReviewAssignment
+		if err := rows.Scan(&a.PullRequestID, &a.PullRequestName, &a.AuthorID,
+			&a.ReviewerID, &a.TeamName, &a.AssignedAt); err != nil {
+			return nil, fmt.Errorf("scan overdue review: %w", err)
+		}
+		reviews = append(reviews, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate overdue reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *PRRepository) Archive(ctx context.Context, mergedBefore time.Time) (int, error) {
+	var n int
+	if err := r.db.QueryRowContext(ctx, queryArchivePRs, domain.TenantFrom(ctx), mergedBefore).Scan(&n); err != nil {
+		return 0, fmt.Errorf("archive pull requests: %w", err)
+	}
+	return n, nil
+}
+
+func (r *PRRepository) GetArchived(ctx context.Context, id string) (*domain.PullRequest, error) {
+	tenant := domain.TenantFrom(ctx)
+
+	var (
+		pr       domain.PullRequest
+		mergedAt sql.NullTime
+	)
+	if err := r.db.QueryRowContext(ctx, queryGetArchivedPR, tenant, id).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, &pr.ArchivedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+		}
+		return nil, fmt.Errorf("get archived pull request: %w", err)
+	}
+	pr.MergedAt = mergedAt.Time
+
+	rows, err := r.db.QueryContext(ctx, queryListArchivedReviewers, tenant, id)
+	if err != nil {
+		return nil, fmt.Errorf("list archived reviewers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	for rows.Next() {
+		var reviewerID string
+		if err := rows.Scan(&reviewerID); err != nil {
+			return nil, fmt.Errorf("scan archived reviewer: %w", err)
+		}
+		pr.Reviewers = append(pr.Reviewers, reviewerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate archived reviewers: %w", err)
+	}
+
+	return &pr, nil
+}
+
+func (r *PRRepository) ListArchivedByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
+	rows, err := r.db.QueryContext(ctx, queryListArchivedPRsByReviewer, domain.TenantFrom(ctx), reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("list archived by reviewer: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	var prs []domain.PullRequest
+	for rows.Next() {
+		var (
+			pr       domain.PullRequest
+			mergedAt sql.NullTime
+		)
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, &pr.ArchivedAt); err != nil {
+			return nil, fmt.Errorf("scan archived pr by reviewer: %w", err)
+		}
+		pr.MergedAt = mergedAt.Time
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate archived by reviewer: %w", err)
+	}
+
+	return prs, nil
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// queryAddReviewers inserts the missing reviewers and logs an assignment for
+// each one actually added, so SetReviewers needs no per-reviewer round trip.
+const queryAddReviewers = `
+	WITH added AS (
+	    INSERT INTO pull_request_reviewers (tenant_id, pull_request_id, reviewer_id)
+	    SELECT $1, $2, reviewer_id
+	    FROM unnest($3::text[]) WITH ORDINALITY AS r(reviewer_id, position)
+	    ORDER BY position
+	    ON CONFLICT (tenant_id, pull_request_id, reviewer_id) DO NOTHING
+	    RETURNING tenant_id, pull_request_id, reviewer_id
+	)
+	INSERT INTO reviewer_assignments (tenant_id, pull_request_id, reviewer_id)
+	SELECT tenant_id, pull_request_id, reviewer_id FROM added`
+
+var _ domain.PRRepository = (*PgxPRRepository)(nil)
+
+type PgxPRRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxPRRepository(pool *pgxpool.Pool) domain.PRRepository {
+	return &PgxPRRepository{
+		pool: pool,
+	}
+}
+
+func (r *PgxPRRepository) Create(ctx context.Context, req domain.PullRequest) (*domain.PullRequest, error) {
+	var (
+		pr       domain.PullRequest
+		mergedAt sql.NullTime
+	)
+
+	err := r.pool.QueryRow(ctx, queryCreatePR,
+		domain.TenantFrom(ctx), req.ID, req.Name, req.AuthorID, req.Status,
+	).Scan(
+		&pr.ID,
+		&pr.Name,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.CreatedAt,
+		&mergedAt,
+	)
+
+	if err != nil {
+		if prExists(err) {
+			return nil, domain.NewError(domain.ErrorCodePRExists, "pull request already exists")
+		}
+		return nil, fmt.Errorf("create pull request: %w", err)
+	}
+
+	if mergedAt.Valid {
+		pr.MergedAt = mergedAt.Time
+	}
+
+	return &pr, nil
+}
+
+func (r *PgxPRRepository) Get(ctx context.Context, id string) (*domain.PullRequest, error) {
+	var (
+		pr       domain.PullRequest
+		mergedAt sql.NullTime
+	)
+
+	if err := r.pool.QueryRow(ctx, queryGetPR, domain.TenantFrom(ctx), id).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+		}
+		return nil, fmt.Errorf("get pull request: %w", err)
+	}
+
+	if mergedAt.Valid {
+		pr.MergedAt = mergedAt.Time
+	}
+
+	return &pr, nil
+}
+
+func (r *PgxPRRepository) Update(ctx context.Context, id string, status domain.PRStatus) error {
+	tag, err := r.pool.Exec(ctx, queryUpdatePRStatus, domain.TenantFrom(ctx), id, status)
+	if err != nil {
+		return fmt.Errorf("update pull request: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+	}
+
+	return nil
+}
+
+// SetReviewers sends all statements as one batch. Postgres runs a batch
+// without explicit transaction control in a single implicit transaction.
+func (r *PgxPRRepository) SetReviewers(ctx context.Context, id string, reviewers []string) error {
+	keep := reviewers
+	if keep == nil {
+		keep = []string{}
+	}
+
+	tenant := domain.TenantFrom(ctx)
+
+	b := &pgx.Batch{}
+	b.Queue(queryLockPR, tenant, id)
+	b.Queue(queryUnassignReviewers, tenant, id, keep)
+	b.Queue(queryDeleteReviewers, tenant, id, keep)
+	b.Queue(queryAddReviewers, tenant, id, keep)
+
+	br := r.pool.SendBatch(ctx, b)
+	if err := br.QueryRow().Scan(new(string)); err != nil {
+		_ = br.Close()
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+		}
+		return fmt.Errorf("lock pull request: %w", err)
+	}
+	for _, step := range []string{"close reviewer assignments", "delete reviewers", "add reviewers"} {
+		if _, err := br.Exec(); err != nil {
+			_ = br.Close()
+			return fmt.Errorf("%s: %w", step, err)
+		}
+	}
+
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("close set reviewers batch: %w", err)
+	}
+	return nil
+}
+
+func (r *PgxPRRepository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
+	rows, err := r.pool.Query(ctx, queryListPRsByReviewer, domain.TenantFrom(ctx), reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("select reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []domain.PullRequest
+	for rows.Next() {
+		var (
+			pr       domain.PullRequest
+			mergedAt sql.NullTime
+		)
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt); err != nil {
+			return nil, fmt.Errorf("scan pr by reviewer: %w", err)
+		}
+		if mergedAt.Valid {
+			pr.MergedAt = mergedAt.Time
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate by reviewer: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (r *PgxPRRepository) ListReviewers(ctx context.Context, prID string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, queryListReviewers, domain.TenantFrom(ctx), prID)
+	if err != nil {
+		return nil, fmt.Errorf("list reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var reviewers []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan reviewer: %w", err)
+		}
+		reviewers = append(reviewers, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewers: %w", err)
+	}
+
+	return reviewers, nil
+}
+
+func (r *PgxPRRepository) ListOverdueReviews(ctx context.Context, assignedBefore time.Time, teamName string) ([]domain.ReviewAssignment, error) {
+	rows, err := r.pool.Query(ctx, queryListOverdueReviews, domain.TenantFrom(ctx), assignedBefore, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("list overdue reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []domain.ReviewAssignment
+	for rows.Next() {
+		var a domain.ReviewAssignment
+		if err := rows.Scan(&a.PullRequestID, &a.PullRequestName, &a.AuthorID,
+			&a.ReviewerID, &a.TeamName, &a.AssignedAt); err != nil {
+			return nil, fmt.Errorf("scan overdue review: %w", err)
+		}
+		reviews = append(reviews, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate overdue reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func (r *PgxPRRepository) Archive(ctx context.Context, mergedBefore time.Time) (int, error) {
+	var n int
+	if err := r.pool.QueryRow(ctx, queryArchivePRs, domain.TenantFrom(ctx), mergedBefore).Scan(&n); err != nil {
+		return 0, fmt.Errorf("archive pull requests: %w", err)
+	}
+	return n, nil
+}
+
+func (r *PgxPRRepository) GetArchived(ctx context.Context, id string) (*domain.PullRequest, error) {
+	tenant := domain.TenantFrom(ctx)
+
+	var (
+		pr       domain.PullRequest
+		mergedAt sql.NullTime
+	)
+	if err := r.pool.QueryRow(ctx, queryGetArchivedPR, tenant, id).
+		Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, &pr.ArchivedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+		}
+		return nil, fmt.Errorf("get archived pull request: %w", err)
+	}
+	pr.MergedAt = mergedAt.Time
+
+	rows, err := r.pool.Query(ctx, queryListArchivedReviewers, tenant, id)
+	if err != nil {
+		return nil, fmt.Errorf("list archived reviewers: %w", err)
+	}
+	reviewers, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan archived reviewers: %w", err)
+	}
+	pr.Reviewers = reviewers
+
+	return &pr, nil
+}
+
+func (r *PgxPRRepository) ListArchivedByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
+	rows, err := r.pool.Query(ctx, queryListArchivedPRsByReviewer, domain.TenantFrom(ctx), reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("list archived by reviewer: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []domain.PullRequest
+	for rows.Next() {
+		var (
+			pr       domain.PullRequest
+			mergedAt sql.NullTime
+		)
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt, &pr.ArchivedAt); err != nil {
+			return nil, fmt.Errorf("scan archived pr by reviewer: %w", err)
+		}
+		pr.MergedAt = mergedAt.Time
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate archived by reviewer: %w", err)
+	}
+
+	return prs, nil
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/db/migrations"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/pgtest"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// openSchemaDB returns a fresh schema with the migrations applied twice, as
+// they must be safe to run on every start.
+func openSchemaDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := pgtest.NewDB(t)
+	if err := migrations.CreateTables(db.SQL); err != nil {
+		t.Fatalf("migrate again: %v", err)
+	}
+	return db.SQL
+}
+
+func TestSchema_QueryPlansUseIndexes(t *testing.T) {
+	db := openSchemaDB(t)
+	ctx := context.Background()
+	const tenant = domain.DefaultTenant
+
+	cases := []struct {
+		name  string
+		query string
+		args  []any
+		index string
+	}{
+		{"list prs by reviewer", queryListPRsByReviewer, []any{tenant, "u1"}, "pull_request_reviewers_reviewer_id_idx"},
+		{"list review candidates", queryListReviewCandidates, []any{tenant, "backend", "u1"}, "users_team_name_is_active_idx"},
+		{"list team members", queryListTeamMembers, []any{tenant, "backend"}, "users_team_name_is_active_idx"},
+		{"unassign reviewers", queryUnassignReviewers, []any{tenant, "pr1", []string{"u2"}}, "reviewer_assignments_open_idx"},
+		{"log activity", queryLogActivity, []any{tenant, "u1", true}, "user_activity_events_user_id_idx"},
+		{"archive merged prs", queryArchivePRs, []any{tenant, time.Now()}, "pull_requests_merged_at_idx"},
+		{"list archived prs by reviewer", queryListArchivedPRsByReviewer, []any{tenant, "u1"}, "archived_pull_request_reviewers_reviewer_id_idx"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				t.Fatalf("begin: %v", err)
+			}
+			defer func() { _ = tx.Rollback() }()
+
+			// The tables are empty, so without this the planner rightly
+			// prefers sequential scans.
+			if _, err := tx.ExecContext(ctx, "SET LOCAL enable_seqscan = off"); err != nil {
+				t.Fatalf("disable seqscan: %v", err)
+			}
+
+			rows, err := tx.QueryContext(ctx, "EXPLAIN "+tc.query, tc.args...)
+			if err != nil {
+				t.Fatalf("explain: %v", err)
+			}
+			var plan strings.Builder
+			for rows.Next() {
+				var line string
+				if err := rows.Scan(&line); err != nil {
+					t.Fatalf("scan plan: %v", err)
+				}
+				plan.WriteString(line + "\n")
+			}
+			if err := rows.Err(); err != nil {
+				t.Fatalf("read plan: %v", err)
+			}
+			_ = rows.Close()
+
+			if !strings.Contains(plan.String(), tc.index) {
+				t.Errorf("plan does not use %s:\n%s", tc.index, plan.String())
+			}
+		})
+	}
+}
+
+func TestSchema_Constraints(t *testing.T) {
+	db := openSchemaDB(t)
+	ctx := context.Background()
+
+	exec := func(query string, args ...any) error {
+		_, err := db.ExecContext(ctx, query, args...)
+		return err
+	}
+	mustExec := func(query string, args ...any) {
+		t.Helper()
+		if err := exec(query, args...); err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+	}
+	wantCode := func(err error, code string) {
+		t.Helper()
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) || pgErr.Code != code {
+			t.Fatalf("got error %v, want SQLSTATE %s", err, code)
+		}
+	}
+
+	const tenant = domain.DefaultTenant
+	mustExec(queryEnsureTenant, "payments")
+	mustExec(queryCreateTeam, tenant, "backend")
+	mustExec(queryUpsertUser, tenant, "u1", "Alice", "backend", true)
+	mustExec(queryUpsertUser, tenant, "u2", "Bob", "backend", true)
+	mustExec(queryUpsertUser, tenant, "u3", "Carol", "", true)
+
+	t.Run("user team must exist", func(t *testing.T) {
+		wantCode(exec(queryUpsertUser, tenant, "u4", "Dave", "missing", true), "23503")
+	})
+
+	t.Run("tenant must exist", func(t *testing.T) {
+		wantCode(exec(queryCreateTeam, "missing", "backend"), "23503")
+	})
+
+	t.Run("ids are unique per tenant", func(t *testing.T) {
+		mustExec(queryCreateTeam, "payments", "backend")
+		mustExec(queryUpsertUser, "payments", "u1", "Eve", "backend", true)
+
+		var name string
+		err := db.QueryRowContext(ctx, `SELECT name FROM users WHERE tenant_id = $1 AND id = 'u1'`, tenant).Scan(&name)
+		if err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		if name != "Alice" {
+			t.Fatalf("name = %q, want Alice", name)
+		}
+	})
+
+	t.Run("references stay within the tenant", func(t *testing.T) {
+		// u2 exists only in the default tenant.
+		wantCode(exec(queryCreatePR, "payments", "pr9", "cross", "u2", "OPEN"), "23503")
+	})
+
+	t.Run("user without team stores null", func(t *testing.T) {
+		var teamName sql.NullString
+		if err := db.QueryRowContext(ctx, `SELECT team_name FROM users WHERE id = 'u3'`).Scan(&teamName); err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		if teamName.Valid {
+			t.Fatalf("team_name = %q, want NULL", teamName.String)
+		}
+	})
+
+	t.Run("status is checked", func(t *testing.T) {
+		wantCode(exec(queryCreatePR, tenant, "pr0", "bad", "u1", "WIP"), "23514")
+	})
+
+	t.Run("reviewers are deleted with the pull request", func(t *testing.T) {
+		mustExec(queryCreatePR, tenant, "pr1", "feature", "u1", "OPEN")
+		mustExec(queryInsertReviewer, tenant, "pr1", "u2")
+		mustExec(`DELETE FROM reviewer_assignments WHERE pull_request_id = 'pr1'`)
+		mustExec(`DELETE FROM pull_requests WHERE pull_request_id = 'pr1'`)
+
+		var n int
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pull_request_reviewers`).Scan(&n); err != nil {
+			t.Fatalf("count: %v", err)
+		}
+		if n != 0 {
+			t.Fatalf("reviewers left: %d", n)
+		}
+	})
+
+	t.Run("archival moves reviewers and keeps the id taken", func(t *testing.T) {
+		mustExec(queryCreatePR, tenant, "pr2", "feature", "u1", "OPEN")
+		mustExec(queryInsertReviewer, tenant, "pr2", "u2")
+		mustExec(queryUpdatePRStatus, tenant, "pr2", "MERGED")
+
+		var n int
+		if err := db.QueryRowContext(ctx, queryArchivePRs, tenant, time.Now().Add(time.Minute)).Scan(&n); err != nil {
+			t.Fatalf("archive: %v", err)
+		}
+		if n != 1 {
+			t.Fatalf("archived %d pull requests, want 1", n)
+		}
+
+		var reviewer string
+		if err := db.QueryRowContext(ctx, queryListArchivedReviewers, tenant, "pr2").Scan(&reviewer); err != nil {
+			t.Fatalf("archived reviewer: %v", err)
+		}
+		if reviewer != "u2" {
+			t.Fatalf("archived reviewer = %q, want u2", reviewer)
+		}
+
+		err := db.QueryRowContext(ctx, queryCreatePR, tenant, "pr2", "again", "u1", "OPEN").Scan(new(string), new(string),
+			new(string), new(string), new(time.Time), new(sql.NullTime))
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("create with an archived id: got %v, want no rows", err)
+		}
+	})
+
+	t.Run("timestamps carry a time zone", func(t *testing.T) {
+		var n int
+		err := db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM information_schema.columns
+			WHERE table_schema = current_schema() AND data_type = 'timestamp without time zone'`).Scan(&n)
+		if err != nil {
+			t.Fatalf("count: %v", err)
+		}
+		if n != 0 {
+			t.Fatalf("%d columns still use timestamp without time zone", n)
+		}
+	})
+}
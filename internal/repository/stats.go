@@ -3,11 +3,74 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/ChernykhITMO/Avito/internal/domain"
 )
 
+const (
+	queryPRStats = `
+	SELECT
+	    COUNT(*) AS total,
+	    COUNT(*) FILTER (WHERE status='DRAFT') AS draft,
+	    COUNT(*) FILTER (WHERE status='OPEN') AS open,
+	    COUNT(*) FILTER (WHERE status='MERGED') AS merged,
+	    COUNT(*) FILTER (WHERE status='CLOSED') AS closed
+	FROM all_pull_requests
+	WHERE tenant_id = $1`
+
+	queryAssignmentsStats = `
+	SELECT reviewer_id, COUNT(*)
+	FROM (
+	    SELECT reviewer_id FROM pull_request_reviewers WHERE tenant_id = $1
+	    UNION ALL
+	    SELECT reviewer_id FROM archived_pull_request_reviewers WHERE tenant_id = $1
+	) AS r
+	GROUP BY reviewer_id
+	ORDER BY reviewer_id`
+
+	queryListPRTimelines = `
+	SELECT pr.pull_request_id, COALESCE(u.team_name, ''), pr.created_at, pr.merged_at
+	FROM all_pull_requests AS pr
+	JOIN users AS u ON u.tenant_id = pr.tenant_id AND u.id = pr.author_id
+	WHERE pr.tenant_id = $1
+	AND ($2 = '' OR u.team_name = $2)
+	AND (
+	    (($3::timestamptz IS NULL OR pr.created_at >= $3) AND ($4::timestamptz IS NULL OR pr.created_at < $4))
+	    OR (pr.merged_at IS NOT NULL
+	        AND ($3::timestamptz IS NULL OR pr.merged_at >= $3) AND ($4::timestamptz IS NULL OR pr.merged_at < $4))
+	)
+	ORDER BY pr.created_at, pr.pull_request_id`
+
+	queryListAssignments = `
+	SELECT a.pull_request_id, a.reviewer_id, COALESCE(u.team_name, ''), a.assigned_at, a.unassigned_at
+	FROM reviewer_assignments AS a
+	JOIN users AS u ON u.tenant_id = a.tenant_id AND u.id = a.reviewer_id
+	WHERE a.tenant_id = $1
+	AND ($2 = '' OR u.team_name = $2)
+	AND ($3::timestamptz IS NULL OR a.assigned_at >= $3)
+	AND ($4::timestamptz IS NULL OR a.assigned_at < $4)
+	ORDER BY a.assigned_at, a.id`
+
+	queryListOpenLoad = `
+	SELECT r.reviewer_id, COALESCE(u.team_name, ''), COUNT(*)
+	FROM pull_request_reviewers AS r
+	JOIN pull_requests AS pr ON pr.tenant_id = r.tenant_id AND pr.pull_request_id = r.pull_request_id
+	JOIN users AS u ON u.tenant_id = r.tenant_id AND u.id = r.reviewer_id
+	WHERE r.tenant_id = $1 AND pr.status = 'OPEN' AND ($2 = '' OR u.team_name = $2)
+	GROUP BY r.reviewer_id, u.team_name
+	ORDER BY r.reviewer_id`
+
+	queryListActivityEvents = `
+	SELECT e.user_id, COALESCE(u.team_name, ''), e.is_active, e.changed_at
+	FROM user_activity_events AS e
+	JOIN users AS u ON u.tenant_id = e.tenant_id AND u.id = e.user_id
+	WHERE e.tenant_id = $1 AND ($2 = '' OR u.team_name = $2) AND e.changed_at < $3
+	ORDER BY e.user_id, e.changed_at, e.id`
+)
+
 var _ domain.StatsRepository = (*StatsRepository)(nil)
 
 type StatsRepository struct {
@@ -22,27 +85,13 @@ func NewStatsRepository(db *sql.DB) domain.StatsRepository {
 func (r *StatsRepository) GetPRStats(ctx context.Context) (domain.PRStats, error) {
 	var s domain.PRStats
 
-	query := `
-        SELECT 
-            COUNT(*) AS total,
-            COUNT(*) FILTER (WHERE status='OPEN') AS open,
-            COUNT(*) FILTER (WHERE status='MERGED') AS merged
-        FROM pull_requests;
-    `
-
-	row := r.db.QueryRowContext(ctx, query)
-	err := row.Scan(&s.Total, &s.Open, &s.Merged)
+	row := r.db.QueryRowContext(ctx, queryPRStats, domain.TenantFrom(ctx))
+	err := row.Scan(&s.Total, &s.Draft, &s.Open, &s.Merged, &s.Closed)
 	return s, err
 }
 
 func (r *StatsRepository) GetAssignmentsStats(ctx context.Context) ([]domain.UserAssignmentStat, error) {
-	query := `
-        SELECT reviewer_id, COUNT(*)
-        FROM pull_request_reviewers
-        GROUP BY reviewer_id;
-    `
-
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, queryAssignmentsStats, domain.TenantFrom(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -61,5 +110,146 @@ func (r *StatsRepository) GetAssignmentsStats(ctx context.Context) ([]domain.Use
 		}
 		stats = append(stats, s)
 	}
-	return stats, nil
+	return stats, rows.Err()
+}
+
+func (r *StatsRepository) ListPRTimelines(ctx context.Context, f domain.StatsFilter) ([]domain.PRTimeline, error) {
+	rows, err := r.db.QueryContext(ctx, queryListPRTimelines, domain.TenantFrom(ctx), f.TeamName, nullTime(f.From), nullTime(f.To))
+	if err != nil {
+		return nil, fmt.Errorf("list pr timelines: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	var timelines []domain.PRTimeline
+	for rows.Next() {
+		var (
+			t        domain.PRTimeline
+			mergedAt sql.NullTime
+		)
+		if err := rows.Scan(&t.ID, &t.TeamName, &t.CreatedAt, &mergedAt); err != nil {
+			return nil, fmt.Errorf("scan pr timeline: %w", err)
+		}
+		if mergedAt.Valid {
+			t.MergedAt = mergedAt.Time
+		}
+		timelines = append(timelines, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pr timelines: %w", err)
+	}
+
+	return timelines, nil
+}
+
+func (r *StatsRepository) ListAssignments(ctx context.Context, f domain.StatsFilter) ([]domain.AssignmentRecord, error) {
+	var records []domain.AssignmentRecord
+	err := r.EachAssignment(ctx, f, func(a domain.AssignmentRecord) error {
+		records = append(records, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *StatsRepository) EachAssignment(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	rows, err := r.db.QueryContext(ctx, queryListAssignments, domain.TenantFrom(ctx), f.TeamName, nullTime(f.From), nullTime(f.To))
+	if err != nil {
+		return fmt.Errorf("list assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			a            domain.AssignmentRecord
+			unassignedAt sql.NullTime
+		)
+		if err := rows.Scan(&a.PullRequestID, &a.ReviewerID, &a.TeamName, &a.AssignedAt, &unassignedAt); err != nil {
+			return fmt.Errorf("scan assignment: %w", err)
+		}
+		if unassignedAt.Valid {
+			a.UnassignedAt = unassignedAt.Time
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate assignments: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatsRepository) ListOpenLoad(ctx context.Context, teamName string) ([]domain.ReviewerLoad, error) {
+	rows, err := r.db.QueryContext(ctx, queryListOpenLoad, domain.TenantFrom(ctx), teamName)
+	if err != nil {
+		return nil, fmt.Errorf("list open load: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	var loads []domain.ReviewerLoad
+	for rows.Next() {
+		var l domain.ReviewerLoad
+		if err := rows.Scan(&l.UserID, &l.TeamName, &l.Open); err != nil {
+			return nil, fmt.Errorf("scan open load: %w", err)
+		}
+		loads = append(loads, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate open load: %w", err)
+	}
+
+	return loads, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func (r *StatsRepository) ListActivityEvents(ctx context.Context, teamName string, before time.Time) ([]domain.ActivityEvent, error) {
+	rows, err := r.db.QueryContext(ctx, queryListActivityEvents, domain.TenantFrom(ctx), teamName, before)
+	if err != nil {
+		return nil, fmt.Errorf("list activity events: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	var events []domain.ActivityEvent
+	for rows.Next() {
+		var e domain.ActivityEvent
+		if err := rows.Scan(&e.UserID, &e.TeamName, &e.IsActive, &e.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan activity event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate activity events: %w", err)
+	}
+
+	return events, nil
 }
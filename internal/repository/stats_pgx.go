@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ domain.StatsRepository = (*PgxStatsRepository)(nil)
+
+type PgxStatsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxStatsRepository(pool *pgxpool.Pool) domain.StatsRepository {
+	return &PgxStatsRepository{
+		pool: pool,
+	}
+}
+
+func (r *PgxStatsRepository) GetPRStats(ctx context.Context) (domain.PRStats, error) {
+	var s domain.PRStats
+
+	err := r.pool.QueryRow(ctx, queryPRStats, domain.TenantFrom(ctx)).Scan(&s.Total, &s.Draft, &s.Open, &s.Merged, &s.Closed)
+	return s, err
+}
+
+func (r *PgxStatsRepository) GetAssignmentsStats(ctx context.Context) ([]domain.UserAssignmentStat, error) {
+	rows, err := r.pool.Query(ctx, queryAssignmentsStats, domain.TenantFrom(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []domain.UserAssignmentStat
+	for rows.Next() {
+		var s domain.UserAssignmentStat
+		if err := rows.Scan(&s.UserID, &s.Count); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+func (r *PgxStatsRepository) ListPRTimelines(ctx context.Context, f domain.StatsFilter) ([]domain.PRTimeline, error) {
+	rows, err := r.pool.Query(ctx, queryListPRTimelines, domain.TenantFrom(ctx), f.TeamName, nullTime(f.From), nullTime(f.To))
+	if err != nil {
+		return nil, fmt.Errorf("list pr timelines: %w", err)
+	}
+	defer rows.Close()
+
+	var timelines []domain.PRTimeline
+	for rows.Next() {
+		var (
+			t        domain.PRTimeline
+			mergedAt sql.NullTime
+		)
+		if err := rows.Scan(&t.ID, &t.TeamName, &t.CreatedAt, &mergedAt); err != nil {
+			return nil, fmt.Errorf("scan pr timeline: %w", err)
+		}
+		if mergedAt.Valid {
+			t.MergedAt = mergedAt.Time
+		}
+		timelines = append(timelines, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pr timelines: %w", err)
+	}
+
+	return timelines, nil
+}
+
+func (r *PgxStatsRepository) ListAssignments(ctx context.Context, f domain.StatsFilter) ([]domain.AssignmentRecord, error) {
+	var records []domain.AssignmentRecord
+	err := r.EachAssignment(ctx, f, func(a domain.AssignmentRecord) error {
+		records = append(records, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *PgxStatsRepository) EachAssignment(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	rows, err := r.pool.Query(ctx, queryListAssignments, domain.TenantFrom(ctx), f.TeamName, nullTime(f.From), nullTime(f.To))
+	if err != nil {
+		return fmt.Errorf("list assignments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			a            domain.AssignmentRecord
+			unassignedAt sql.NullTime
+		)
+		if err := rows.Scan(&a.PullRequestID, &a.ReviewerID, &a.TeamName, &a.AssignedAt, &unassignedAt); err != nil {
+			return fmt.Errorf("scan assignment: %w", err)
+		}
+		if unassignedAt.Valid {
+			a.UnassignedAt = unassignedAt.Time
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate assignments: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PgxStatsRepository) ListOpenLoad(ctx context.Context, teamName string) ([]domain.ReviewerLoad, error) {
+	rows, err := r.pool.Query(ctx, queryListOpenLoad, domain.TenantFrom(ctx), teamName)
+	if err != nil {
+		return nil, fmt.Errorf("list open load: %w", err)
+	}
+	defer rows.Close()
+
+	var loads []domain.ReviewerLoad
+	for rows.Next() {
+		var l domain.ReviewerLoad
+		if err := rows.Scan(&l.UserID, &l.TeamName, &l.Open); err != nil {
+			return nil, fmt.Errorf("scan open load: %w", err)
+		}
+		loads = append(loads, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate open load: %w", err)
+	}
+
+	return loads, nil
+}
+
+func (r *PgxStatsRepository) ListActivityEvents(ctx context.Context, teamName string, before time.Time) ([]domain.ActivityEvent, error) {
+	rows, err := r.pool.Query(ctx, queryListActivityEvents, domain.TenantFrom(ctx), teamName, before)
+	if err != nil {
+		return nil, fmt.Errorf("list activity events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []domain.ActivityEvent
+	for rows.Next() {
+		var e domain.ActivityEvent
+		if err := rows.Scan(&e.UserID, &e.TeamName, &e.IsActive, &e.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan activity event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate activity events: %w", err)
+	}
+
+	return events, nil
+}
@@ -6,13 +6,47 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 
 	"github.com/ChernykhITMO/Avito/internal/domain"
-	"github.com/jackc/pgx/v5/pgconn"
 )
 
 const pgUniqueViolation = "23505"
 
+const (
+	// queryCreateTeam brings a deleted team back and changes nothing if the
+	// team exists.
+	queryCreateTeam = `
+	INSERT INTO teams(tenant_id, name) VALUES ($1, $2)
+	ON CONFLICT (tenant_id, name) DO UPDATE SET deleted_at = NULL
+	WHERE teams.deleted_at IS NOT NULL`
+	queryGetTeam         = `SELECT name FROM teams WHERE tenant_id = $1 AND name = $2 AND deleted_at IS NULL`
+	queryListTeamMembers = `
+	SELECT id, name, is_active FROM users
+	WHERE tenant_id = $1 AND team_name = $2 AND deleted_at IS NULL
+	ORDER BY id`
+
+	queryListTeams = `
+	SELECT t.name, u.id, u.name, u.is_active
+	FROM teams AS t
+	LEFT JOIN users AS u ON u.tenant_id = t.tenant_id AND u.team_name = t.name AND u.deleted_at IS NULL
+	WHERE t.tenant_id = $1 AND t.deleted_at IS NULL
+	ORDER BY t.name, u.id`
+
+	queryEnsureTeam = `
+	INSERT INTO teams(tenant_id, name) VALUES ($1, $2)
+	ON CONFLICT (tenant_id, name) DO UPDATE SET deleted_at = NULL`
+
+	queryDeleteTeam = `
+	UPDATE teams SET deleted_at = NOW()
+	WHERE tenant_id = $1 AND name = $2 AND deleted_at IS NULL`
+	queryDeleteTeamMembers = `
+	UPDATE users SET is_active = false, deleted_at = NOW()
+	WHERE tenant_id = $1 AND team_name = $2 AND deleted_at IS NULL
+	RETURNING id, name, is_active, deleted_at`
+)
+
 var _ domain.TeamRepository = (*TeamRepository)(nil)
 
 type TeamRepository struct {
@@ -26,35 +60,33 @@ func NewTeamRepository(db *sql.DB) domain.TeamRepository {
 }
 
 func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
-	const query = `INSERT INTO teams(name) VALUES ($1)`
-
-	_, err := r.db.ExecContext(ctx, query, team.Name)
+	res, err := r.db.ExecContext(ctx, queryCreateTeam, domain.TenantFrom(ctx), team.Name)
+	if err != nil {
+		return fmt.Errorf("create team: %w", err)
+	}
 
+	created, err := res.RowsAffected()
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
-			return domain.NewError(domain.ErrorCodeTeamExists, "team already exists")
-		}
 		return fmt.Errorf("create team: %w", err)
 	}
+	if created == 0 {
+		return domain.NewError(domain.ErrorCodeTeamExists, "team already exists")
+	}
 	return nil
 }
 
 func (r *TeamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
-	const (
-		queryTeam    = `SELECT name FROM teams WHERE name = $1`
-		queryMembers = `SELECT id, name, is_active FROM users WHERE team_name = $1`
-	)
+	tenant := domain.TenantFrom(ctx)
 
 	var team domain.Team
-	if err := r.db.QueryRowContext(ctx, queryTeam, name).Scan(&team.Name); err != nil {
+	if err := r.db.QueryRowContext(ctx, queryGetTeam, tenant, name).Scan(&team.Name); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
 		}
 		return nil, fmt.Errorf("get team: %w", err)
 	}
 
-	rows, err := r.db.QueryContext(ctx, queryMembers, team.Name)
+	rows, err := r.db.QueryContext(ctx, queryListTeamMembers, tenant, team.Name)
 	if err != nil {
 		return nil, fmt.Errorf("get team members: %w", err)
 	}
@@ -82,3 +114,151 @@ func (r *TeamRepository) GetByName(ctx context.Context, name string) (*domain.Te
 	team.Members = members
 	return &team, nil
 }
+
+func (r *TeamRepository) List(ctx context.Context) ([]domain.Team, error) {
+	rows, err := r.db.QueryContext(ctx, queryListTeams, domain.TenantFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	var teams []domain.Team
+	for rows.Next() {
+		var (
+			teamName string
+			id, name sql.NullString
+			isActive sql.NullBool
+		)
+		if err := rows.Scan(&teamName, &id, &name, &isActive); err != nil {
+			return nil, fmt.Errorf("scan team: %w", err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].Name != teamName {
+			teams = append(teams, domain.Team{Name: teamName})
+		}
+		if !id.Valid {
+			continue
+		}
+
+		team := &teams[len(teams)-1]
+		team.Members = append(team.Members, domain.User{
+			ID:       id.String,
+			Name:     name.String,
+			TeamName: teamName,
+			IsActive: isActive.Bool,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) ApplyDiff(ctx context.Context, diff domain.OrgDiff) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx for apply diff: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+	for _, name := range diff.CreatedTeams {
+		if _, err := tx.ExecContext(ctx, queryEnsureTeam, tenant, name); err != nil {
+			return fmt.Errorf("create team %s: %w", name, err)
+		}
+	}
+
+	for _, u := range diff.Upserts() {
+		if _, err := tx.ExecContext(ctx, queryUpsertUser, tenant, u.ID, u.Name, u.TeamName, u.IsActive); err != nil {
+			return fmt.Errorf("save user %s: %w", u.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, queryLogActivity, tenant, u.ID, u.IsActive); err != nil {
+			return fmt.Errorf("log activity of user %s: %w", u.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx for apply diff: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, name string) (*domain.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx for delete team: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	res, err := tx.ExecContext(ctx, queryDeleteTeam, tenant, name)
+	if err != nil {
+		return nil, fmt.Errorf("delete team: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("delete team: %w", err)
+	}
+	if deleted == 0 {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+	}
+
+	team := domain.Team{Name: name}
+	err = func() error {
+		rows, err := tx.QueryContext(ctx, queryDeleteTeamMembers, tenant, name)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := rows.Close(); err != nil {
+				log.Printf("rows.Close error: %v", err)
+			}
+		}()
+
+		for rows.Next() {
+			user := domain.User{TeamName: name}
+			if err := rows.Scan(&user.ID, &user.Name, &user.IsActive, &user.DeletedAt); err != nil {
+				return err
+			}
+			team.Members = append(team.Members, user)
+		}
+		return rows.Err()
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("delete team members: %w", err)
+	}
+	sortMembers(team.Members)
+
+	for _, u := range team.Members {
+		if _, err := tx.ExecContext(ctx, queryLogActivity, tenant, u.ID, false); err != nil {
+			return nil, fmt.Errorf("log activity of user %s: %w", u.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx for delete team: %w", err)
+	}
+
+	return &team, nil
+}
+
+// sortMembers orders members by id, as team reads return them.
+func sortMembers(members []domain.User) {
+	slices.SortFunc(members, func(a, b domain.User) int { return strings.Compare(a.ID, b.ID) })
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ domain.TeamRepository = (*PgxTeamRepository)(nil)
+
+type PgxTeamRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxTeamRepository(pool *pgxpool.Pool) domain.TeamRepository {
+	return &PgxTeamRepository{
+		pool: pool,
+	}
+}
+
+func (r *PgxTeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	tag, err := r.pool.Exec(ctx, queryCreateTeam, domain.TenantFrom(ctx), team.Name)
+	if err != nil {
+		return fmt.Errorf("create team: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewError(domain.ErrorCodeTeamExists, "team already exists")
+	}
+	return nil
+}
+
+// GetByName reads the team and its members in one round trip.
+func (r *PgxTeamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	tenant := domain.TenantFrom(ctx)
+
+	var team domain.Team
+
+	b := &pgx.Batch{}
+	b.Queue(queryGetTeam, tenant, name).QueryRow(func(row pgx.Row) error {
+		return row.Scan(&team.Name)
+	})
+	b.Queue(queryListTeamMembers, tenant, name).Query(func(rows pgx.Rows) error {
+		for rows.Next() {
+			user := domain.User{TeamName: name}
+			if err := rows.Scan(&user.ID, &user.Name, &user.IsActive); err != nil {
+				return fmt.Errorf("scan team member: %w", err)
+			}
+			team.Members = append(team.Members, user)
+		}
+		return nil
+	})
+
+	if err := r.pool.SendBatch(ctx, b).Close(); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+		}
+		return nil, fmt.Errorf("get team: %w", err)
+	}
+
+	return &team, nil
+}
+
+func (r *PgxTeamRepository) List(ctx context.Context) ([]domain.Team, error) {
+	rows, err := r.pool.Query(ctx, queryListTeams, domain.TenantFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []domain.Team
+	for rows.Next() {
+		var (
+			teamName string
+			id, name sql.NullString
+			isActive sql.NullBool
+		)
+		if err := rows.Scan(&teamName, &id, &name, &isActive); err != nil {
+			return nil, fmt.Errorf("scan team: %w", err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].Name != teamName {
+			teams = append(teams, domain.Team{Name: teamName})
+		}
+		if !id.Valid {
+			continue
+		}
+
+		team := &teams[len(teams)-1]
+		team.Members = append(team.Members, domain.User{
+			ID:       id.String,
+			Name:     name.String,
+			TeamName: teamName,
+			IsActive: isActive.Bool,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate teams: %w", err)
+	}
+
+	return teams, nil
+}
+
+func (r *PgxTeamRepository) ApplyDiff(ctx context.Context, diff domain.OrgDiff) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx for apply diff: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	b := &pgx.Batch{}
+	for _, name := range diff.CreatedTeams {
+		b.Queue(queryEnsureTeam, tenant, name)
+	}
+	if err := tx.SendBatch(ctx, b).Close(); err != nil {
+		return fmt.Errorf("create teams: %w", err)
+	}
+
+	if err := saveUsersBatch(ctx, tx, diff.Upserts()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx for apply diff: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PgxTeamRepository) Delete(ctx context.Context, name string) (*domain.Team, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx for delete team: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	tag, err := tx.Exec(ctx, queryDeleteTeam, tenant, name)
+	if err != nil {
+		return nil, fmt.Errorf("delete team: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+	}
+
+	rows, err := tx.Query(ctx, queryDeleteTeamMembers, tenant, name)
+	if err != nil {
+		return nil, fmt.Errorf("delete team members: %w", err)
+	}
+	members, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.User, error) {
+		user := domain.User{TeamName: name}
+		err := row.Scan(&user.ID, &user.Name, &user.IsActive, &user.DeletedAt)
+		return user, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete team members: %w", err)
+	}
+	sortMembers(members)
+
+	b := &pgx.Batch{}
+	for _, u := range members {
+		b.Queue(queryLogActivity, tenant, u.ID, false)
+	}
+	if err := tx.SendBatch(ctx, b).Close(); err != nil {
+		return nil, fmt.Errorf("log activity of team members: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx for delete team: %w", err)
+	}
+
+	return &domain.Team{Name: name, Members: members}, nil
+}
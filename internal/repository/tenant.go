@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+const (
+	queryEnsureTenant = `INSERT INTO tenants (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`
+	queryListTenants  = `SELECT id FROM tenants ORDER BY id`
+)
+
+var _ domain.TenantRepository = (*TenantRepository)(nil)
+
+type TenantRepository struct {
+	db *sql.DB
+}
+
+func NewTenantRepository(db *sql.DB) domain.TenantRepository {
+	return &TenantRepository{
+		db: db,
+	}
+}
+
+func (r *TenantRepository) Ensure(ctx context.Context, tenants []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx for ensure tenants: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	for _, id := range tenants {
+		if _, err := tx.ExecContext(ctx, queryEnsureTenant, id); err != nil {
+			return fmt.Errorf("ensure tenant %s: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx for ensure tenants: %w", err)
+	}
+	return nil
+}
+
+func (r *TenantRepository) List(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, queryListTenants)
+	if err != nil {
+		return nil, fmt.Errorf("list tenants: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("rows.Close error: %v", err)
+		}
+	}()
+
+	var tenants []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan tenant: %w", err)
+		}
+		tenants = append(tenants, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tenants: %w", err)
+	}
+
+	return tenants, nil
+}
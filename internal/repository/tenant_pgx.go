@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ domain.TenantRepository = (*PgxTenantRepository)(nil)
+
+type PgxTenantRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxTenantRepository(pool *pgxpool.Pool) domain.TenantRepository {
+	return &PgxTenantRepository{
+		pool: pool,
+	}
+}
+
+// Ensure registers all tenants in one batch, which Postgres runs as a
+// single implicit transaction.
+func (r *PgxTenantRepository) Ensure(ctx context.Context, tenants []string) error {
+	b := &pgx.Batch{}
+	for _, id := range tenants {
+		b.Queue(queryEnsureTenant, id)
+	}
+	if err := r.pool.SendBatch(ctx, b).Close(); err != nil {
+		return fmt.Errorf("ensure tenants: %w", err)
+	}
+	return nil
+}
+
+func (r *PgxTenantRepository) List(ctx context.Context) ([]string, error) {
+	rows, err := r.pool.Query(ctx, queryListTenants)
+	if err != nil {
+		return nil, fmt.Errorf("list tenants: %w", err)
+	}
+
+	tenants, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scan tenants: %w", err)
+	}
+	return tenants, nil
+}
@@ -12,6 +12,55 @@ import (
 
 var _ domain.UserRepository = (*UserRepository)(nil)
 
+// queryLogActivity records an activity change unless it matches the latest
+// recorded state of the user.
+const queryLogActivity = `
+	INSERT INTO user_activity_events (tenant_id, user_id, is_active)
+	SELECT $1, $2, $3
+	WHERE COALESCE((
+	    SELECT is_active FROM user_activity_events
+	    WHERE tenant_id = $1 AND user_id = $2
+	    ORDER BY changed_at DESC, id DESC
+	    LIMIT 1
+	) <> $3, true)`
+
+const (
+	queryUpsertUser = `
+	INSERT INTO users (tenant_id, id, name, team_name, is_active)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+	ON CONFLICT (tenant_id, id) DO UPDATE
+	SET
+	    name = EXCLUDED.name,
+	    team_name = EXCLUDED.team_name,
+	    is_active = EXCLUDED.is_active,
+	    deleted_at = NULL`
+
+	queryGetUser = `
+	SELECT id, name, COALESCE(team_name, ''), is_active
+	FROM users WHERE tenant_id = $1 AND id = $2 AND deleted_at IS NULL`
+
+	querySetUserActive = `
+	UPDATE users SET is_active = $3
+	WHERE tenant_id = $1 AND id = $2 AND deleted_at IS NULL
+	RETURNING id, name, COALESCE(team_name, ''), is_active`
+
+	queryListReviewCandidates = `
+	SELECT id, name, team_name, is_active
+	FROM users
+	WHERE tenant_id = $1 AND team_name = $2 AND is_active = true AND id <> $3 AND deleted_at IS NULL
+	ORDER BY id`
+
+	queryDeleteUser = `
+	UPDATE users SET is_active = false, deleted_at = NOW()
+	WHERE tenant_id = $1 AND id = $2 AND deleted_at IS NULL
+	RETURNING id, name, COALESCE(team_name, ''), is_active, deleted_at`
+
+	queryPurgeUser = `
+	UPDATE users SET name = $3, is_active = false, deleted_at = COALESCE(deleted_at, NOW())
+	WHERE tenant_id = $1 AND id = $2
+	RETURNING id, name, COALESCE(team_name, ''), is_active, deleted_at`
+)
+
 type UserRepository struct {
 	db *sql.DB
 }
@@ -23,16 +72,6 @@ func NewUserRepository(db *sql.DB) domain.UserRepository {
 }
 
 func (r *UserRepository) SaveAll(ctx context.Context, users []domain.User) error {
-	const query = `
-	INSERT INTO users (id, name, team_name, is_active) 
-	VALUES ($1, $2, $3, $4)
-	ON CONFLICT (id) DO UPDATE
-	SET 
-	    name = EXCLUDED.name, 
-		team_name = EXCLUDED.team_name, 
-		is_active = EXCLUDED.is_active
-	`
-
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin tx for save users: %w", err)
@@ -43,7 +82,7 @@ func (r *UserRepository) SaveAll(ctx context.Context, users []domain.User) error
 		}
 	}()
 
-	stmt, err := tx.PrepareContext(ctx, query)
+	stmt, err := tx.PrepareContext(ctx, queryUpsertUser)
 	if err != nil {
 		return fmt.Errorf("prepare save user stmt: %w", err)
 	}
@@ -53,10 +92,14 @@ func (r *UserRepository) SaveAll(ctx context.Context, users []domain.User) error
 		}
 	}()
 
+	tenant := domain.TenantFrom(ctx)
 	for _, u := range users {
-		if _, err := stmt.ExecContext(ctx, u.ID, u.Name, u.TeamName, u.IsActive); err != nil {
+		if _, err := stmt.ExecContext(ctx, tenant, u.ID, u.Name, u.TeamName, u.IsActive); err != nil {
 			return fmt.Errorf("save user %s: %w", u.ID, err)
 		}
+		if _, err := tx.ExecContext(ctx, queryLogActivity, tenant, u.ID, u.IsActive); err != nil {
+			return fmt.Errorf("log activity of user %s: %w", u.ID, err)
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -67,13 +110,9 @@ func (r *UserRepository) SaveAll(ctx context.Context, users []domain.User) error
 }
 
 func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
-	const query = `
-	SELECT id, name, team_name, is_active 
-	FROM users WHERE id = $1`
-
 	var user domain.User
 
-	if err := r.db.QueryRowContext(ctx, query, id).Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+	if err := r.db.QueryRowContext(ctx, queryGetUser, domain.TenantFrom(ctx), id).Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
 		}
@@ -83,28 +122,39 @@ func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*domain.Us
 }
 
 func (r *UserRepository) SetIsActive(ctx context.Context, id string, active bool) (*domain.User, error) {
-	const query = `
-	UPDATE users SET is_active = $2 
-	WHERE id = $1 RETURNING id, name, team_name, is_active`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx for set user active: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
 
 	var user domain.User
-	if err := r.db.QueryRowContext(ctx, query, id, active).
+	if err := tx.QueryRowContext(ctx, querySetUserActive, tenant, id, active).
 		Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
 		}
 		return nil, fmt.Errorf("set user active: %w", err)
 	}
+
+	if _, err := tx.ExecContext(ctx, queryLogActivity, tenant, id, active); err != nil {
+		return nil, fmt.Errorf("log user activity: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx for set user active: %w", err)
+	}
 	return &user, nil
 }
 
 func (r *UserRepository) ListReviewCandidates(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
-	const query = `
-	SELECT id, name, team_name, is_active 
-	FROM users 
-	WHERE team_name = $1 AND is_active = true AND id <> $2`
-
-	rows, err := r.db.QueryContext(ctx, query, teamName, excludeUserID)
+	rows, err := r.db.QueryContext(ctx, queryListReviewCandidates, domain.TenantFrom(ctx), teamName, excludeUserID)
 	if err != nil {
 		return nil, fmt.Errorf("list review candidates: %w", err)
 	}
@@ -129,3 +179,52 @@ func (r *UserRepository) ListReviewCandidates(ctx context.Context, teamName, exc
 
 	return users, nil
 }
+
+func (r *UserRepository) Delete(ctx context.Context, id string) (*domain.User, error) {
+	user, err := r.deactivate(ctx, queryDeleteUser, id)
+	if err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+	return user, nil
+}
+
+func (r *UserRepository) Purge(ctx context.Context, id string) (*domain.User, error) {
+	user, err := r.deactivate(ctx, queryPurgeUser, id, domain.PurgedUserName)
+	if err != nil {
+		return nil, fmt.Errorf("purge user: %w", err)
+	}
+	return user, nil
+}
+
+// deactivate runs a query that deletes the user and records it as inactive.
+func (r *UserRepository) deactivate(ctx context.Context, query, id string, args ...any) (*domain.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	var user domain.User
+	if err := tx.QueryRowContext(ctx, query, append([]any{tenant, id}, args...)...).
+		Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive, &user.DeletedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, queryLogActivity, tenant, id, false); err != nil {
+		return nil, fmt.Errorf("log user activity: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return &user, nil
+}
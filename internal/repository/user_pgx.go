@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ domain.UserRepository = (*PgxUserRepository)(nil)
+
+type PgxUserRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgxUserRepository(pool *pgxpool.Pool) domain.UserRepository {
+	return &PgxUserRepository{
+		pool: pool,
+	}
+}
+
+func (r *PgxUserRepository) SaveAll(ctx context.Context, users []domain.User) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx for save users: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	if err := saveUsersBatch(ctx, tx, users); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx for save users: %w", err)
+	}
+
+	return nil
+}
+
+// saveUsersBatch upserts users and logs their activity in one round trip.
+func saveUsersBatch(ctx context.Context, tx pgx.Tx, users []domain.User) error {
+	tenant := domain.TenantFrom(ctx)
+
+	b := &pgx.Batch{}
+	for _, u := range users {
+		b.Queue(queryUpsertUser, tenant, u.ID, u.Name, u.TeamName, u.IsActive)
+		b.Queue(queryLogActivity, tenant, u.ID, u.IsActive)
+	}
+
+	br := tx.SendBatch(ctx, b)
+	for _, u := range users {
+		if _, err := br.Exec(); err != nil {
+			_ = br.Close()
+			return fmt.Errorf("save user %s: %w", u.ID, err)
+		}
+		if _, err := br.Exec(); err != nil {
+			_ = br.Close()
+			return fmt.Errorf("log activity of user %s: %w", u.ID, err)
+		}
+	}
+
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("close save users batch: %w", err)
+	}
+	return nil
+}
+
+func (r *PgxUserRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	var user domain.User
+
+	if err := r.pool.QueryRow(ctx, queryGetUser, domain.TenantFrom(ctx), id).Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *PgxUserRepository) SetIsActive(ctx context.Context, id string, active bool) (*domain.User, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx for set user active: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	var user domain.User
+	if err := tx.QueryRow(ctx, querySetUserActive, tenant, id, active).
+		Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return nil, fmt.Errorf("set user active: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, queryLogActivity, tenant, id, active); err != nil {
+		return nil, fmt.Errorf("log user activity: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx for set user active: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *PgxUserRepository) ListReviewCandidates(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
+	rows, err := r.pool.Query(ctx, queryListReviewCandidates, domain.TenantFrom(ctx), teamName, excludeUserID)
+	if err != nil {
+		return nil, fmt.Errorf("list review candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive); err != nil {
+			return nil, fmt.Errorf("scan review candidate: %w", err)
+		}
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review candidates: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *PgxUserRepository) Delete(ctx context.Context, id string) (*domain.User, error) {
+	user, err := r.deactivate(ctx, queryDeleteUser, id)
+	if err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+	return user, nil
+}
+
+func (r *PgxUserRepository) Purge(ctx context.Context, id string) (*domain.User, error) {
+	user, err := r.deactivate(ctx, queryPurgeUser, id, domain.PurgedUserName)
+	if err != nil {
+		return nil, fmt.Errorf("purge user: %w", err)
+	}
+	return user, nil
+}
+
+// deactivate runs a query that deletes the user and records it as inactive.
+func (r *PgxUserRepository) deactivate(ctx context.Context, query, id string, args ...any) (*domain.User, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			log.Printf("tx.Rollback error: %v", err)
+		}
+	}()
+
+	tenant := domain.TenantFrom(ctx)
+
+	var user domain.User
+	if err := tx.QueryRow(ctx, query, append([]any{tenant, id}, args...)...).
+		Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive, &user.DeletedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, queryLogActivity, tenant, id, false); err != nil {
+		return nil, fmt.Errorf("log user activity: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return &user, nil
+}
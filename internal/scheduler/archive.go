@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type ArchiveConfig struct {
+	// After is how long a merged pull request stays live.
+	After    time.Duration
+	Interval time.Duration
+}
+
+// PRArchiver moves merged pull requests past the retention period to the
+// archive.
+type PRArchiver struct {
+	cfg     ArchiveConfig
+	tenants domain.TenantRepository
+	prs     service.PullRequestService
+	clock   service.Clock
+}
+
+// NewPRArchiver archives the pull requests of every tenant in tenants, or of
+// the default tenant only if tenants is nil.
+func NewPRArchiver(
+	cfg ArchiveConfig,
+	tenants domain.TenantRepository,
+	prs service.PullRequestService,
+	clock service.Clock,
+) *PRArchiver {
+	return &PRArchiver{
+		cfg:     cfg,
+		tenants: tenants,
+		prs:     prs,
+		clock:   clock,
+	}
+}
+
+// Run archives every cfg.Interval until ctx is cancelled.
+func (a *PRArchiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.RunOnce(ctx); err != nil {
+				log.Printf("pull request archival: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce archives the pull requests merged more than cfg.After ago and
+// returns how many were moved. A failing tenant does not stop the others.
+func (a *PRArchiver) RunOnce(ctx context.Context) (int, error) {
+	tenants := []string{domain.DefaultTenant}
+	if a.tenants != nil {
+		var err error
+		if tenants, err = a.tenants.List(ctx); err != nil {
+			return 0, fmt.Errorf("pull request archival: %w", err)
+		}
+	}
+
+	mergedBefore := a.clock.Now().Add(-a.cfg.After)
+
+	total := 0
+	var errs []error
+	for _, tenant := range tenants {
+		n, err := a.prs.ArchiveMerged(domain.WithTenant(ctx, tenant), mergedBefore)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+			continue
+		}
+		if n > 0 {
+			log.Printf("pull request archival: tenant=%s archived=%d merged before %s",
+				tenant, n, mergedBefore.Format(time.RFC3339))
+		}
+		total += n
+	}
+	return total, errors.Join(errs...)
+}
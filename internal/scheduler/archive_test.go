@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type archiveServiceStub struct {
+	service.PullRequestService
+	failTenant      string
+	gotTenants      []string
+	gotMergedBefore time.Time
+}
+
+func (s *archiveServiceStub) ArchiveMerged(ctx context.Context, mergedBefore time.Time) (int, error) {
+	tenant := domain.TenantFrom(ctx)
+	if tenant == s.failTenant {
+		return 0, errors.New("db fail")
+	}
+	s.gotTenants = append(s.gotTenants, tenant)
+	s.gotMergedBefore = mergedBefore
+	return 2, nil
+}
+
+func TestPRArchiver_ArchivesEveryTenant(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	prs := &archiveServiceStub{failTenant: "globex"}
+	tenants := &tenantRepoStub{tenants: []string{"default", "acme", "globex"}}
+
+	a := NewPRArchiver(ArchiveConfig{After: 30 * 24 * time.Hour}, tenants, prs, clock)
+
+	n, err := a.RunOnce(context.Background())
+	if err == nil {
+		t.Fatalf("expected the failure of globex to be reported")
+	}
+	if n != 4 {
+		t.Fatalf("archived = %d, want 4", n)
+	}
+	if want := []string{"default", "acme"}; !slices.Equal(prs.gotTenants, want) {
+		t.Fatalf("tenants = %v, want %v", prs.gotTenants, want)
+	}
+	if want := time.Date(2025, 9, 24, 12, 0, 0, 0, time.UTC); !prs.gotMergedBefore.Equal(want) {
+		t.Fatalf("merged before = %s, want %s", prs.gotMergedBefore, want)
+	}
+}
+
+func TestPRArchiver_DefaultTenantOnly(t *testing.T) {
+	prs := &archiveServiceStub{}
+	a := NewPRArchiver(ArchiveConfig{After: time.Hour}, nil, prs, &fakeClock{})
+
+	if _, err := a.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs.gotTenants) != 1 || prs.gotTenants[0] != domain.DefaultTenant {
+		t.Fatalf("tenants = %v, want only the default", prs.gotTenants)
+	}
+}
@@ -0,0 +1,228 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+// DirectorySource provides the desired org chart. Version changes whenever
+// the content does, so unchanged sources are not re-applied.
+type DirectorySource interface {
+	Load(ctx context.Context) (snapshot domain.OrgSnapshot, version string, err error)
+}
+
+// Reassignment is an open review moved off a deactivated user. ReplacedBy
+// is empty and Reason set when no replacement could be assigned.
+type Reassignment struct {
+	PullRequestID string
+	ReviewerID    string
+	ReplacedBy    string
+	Reason        string
+}
+
+// SyncReport describes one sync run. Skipped runs found the source
+// unchanged since the last successful sync.
+type SyncReport struct {
+	Version      string
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	Skipped      bool
+	Diff         domain.OrgDiff
+	Reassigned   []Reassignment
+	Unreassigned []Reassignment
+}
+
+type SyncReporter interface {
+	Report(ctx context.Context, r SyncReport) error
+}
+
+// LogSyncReporter writes sync reports to the standard logger.
+type LogSyncReporter struct{}
+
+func (LogSyncReporter) Report(_ context.Context, r SyncReport) error {
+	if r.Skipped {
+		return nil
+	}
+
+	log.Printf("org sync %.12s: teams created=%d users created=%d updated=%d moved=%d deactivated=%d reviews reassigned=%d unreassigned=%d in %s",
+		r.Version, len(r.Diff.CreatedTeams), len(r.Diff.CreatedUsers), len(r.Diff.UpdatedUsers),
+		len(r.Diff.MovedUsers), len(r.Diff.DeactivatedUsers), len(r.Reassigned), len(r.Unreassigned),
+		r.FinishedAt.Sub(r.StartedAt))
+	for _, m := range r.Diff.MovedUsers {
+		log.Printf("org sync: user %s moved %s -> %s", m.User.ID, m.FromTeam, m.User.TeamName)
+	}
+	for _, u := range r.Diff.DeactivatedUsers {
+		log.Printf("org sync: user %s (%s) deactivated", u.ID, u.TeamName)
+	}
+	for _, a := range r.Unreassigned {
+		log.Printf("org sync: pr=%s reviewer %s kept: %s", a.PullRequestID, a.ReviewerID, a.Reason)
+	}
+	return nil
+}
+
+type OrgSyncConfig struct {
+	Interval time.Duration
+}
+
+type OrgSync struct {
+	cfg      OrgSyncConfig
+	source   DirectorySource
+	teams    service.TeamService
+	users    service.UserService
+	prs      service.PullRequestService
+	reporter SyncReporter
+	clock    service.Clock
+
+	lastVersion string
+	// pending holds users made inactive by an import whose reviews are not
+	// reassigned yet. They are retried until that succeeds, as a repeated
+	// import no longer reports them.
+	pending []string
+}
+
+func NewOrgSync(
+	cfg OrgSyncConfig,
+	source DirectorySource,
+	teams service.TeamService,
+	users service.UserService,
+	prs service.PullRequestService,
+	reporter SyncReporter,
+	clock service.Clock,
+) *OrgSync {
+	return &OrgSync{
+		cfg:      cfg,
+		source:   source,
+		teams:    teams,
+		users:    users,
+		prs:      prs,
+		reporter: reporter,
+		clock:    clock,
+	}
+}
+
+// Run syncs immediately and then every cfg.Interval until ctx is cancelled.
+func (s *OrgSync) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunOnce(ctx); err != nil {
+			log.Printf("org sync: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce applies the source if it changed since the last successful sync
+// and reassigns open reviews of users that ended up inactive, including
+// those left over from a failed run.
+func (s *OrgSync) RunOnce(ctx context.Context) (SyncReport, error) {
+	report := SyncReport{StartedAt: s.clock.Now()}
+
+	snapshot, version, err := s.source.Load(ctx)
+	if err != nil {
+		return report, fmt.Errorf("org sync: %w", err)
+	}
+	report.Version = version
+
+	if version == s.lastVersion {
+		report.Skipped = true
+		report.FinishedAt = s.clock.Now()
+		return report, s.report(ctx, report)
+	}
+	// An empty chart is almost always a broken export; applying it would
+	// deactivate everyone.
+	if len(snapshot.Teams) == 0 {
+		return report, fmt.Errorf("org sync: source has no teams, refusing to apply")
+	}
+
+	diff, err := s.teams.ImportTeams(ctx, snapshot, false)
+	if err != nil {
+		return report, fmt.Errorf("org sync: %w", err)
+	}
+	report.Diff = diff
+
+	for _, u := range diff.UpdatedUsers {
+		if u.IsActive {
+			s.pending = slices.DeleteFunc(s.pending, func(id string) bool { return id == u.ID })
+		}
+	}
+	for _, u := range deactivated(diff) {
+		if !slices.Contains(s.pending, u.ID) {
+			s.pending = append(s.pending, u.ID)
+		}
+	}
+
+	for len(s.pending) > 0 {
+		if err := s.reassignReviews(ctx, s.pending[0], &report); err != nil {
+			return report, fmt.Errorf("org sync: %w", err)
+		}
+		s.pending = s.pending[1:]
+	}
+
+	s.lastVersion = version
+	report.FinishedAt = s.clock.Now()
+	return report, s.report(ctx, report)
+}
+
+func (s *OrgSync) report(ctx context.Context, r SyncReport) error {
+	if err := s.reporter.Report(ctx, r); err != nil {
+		return fmt.Errorf("org sync: report: %w", err)
+	}
+	return nil
+}
+
+// deactivated returns users that are inactive after the import: those
+// missing from the source and those the source marks inactive.
+func deactivated(diff domain.OrgDiff) []domain.User {
+	users := append([]domain.User{}, diff.DeactivatedUsers...)
+	for _, u := range diff.UpdatedUsers {
+		if !u.IsActive {
+			users = append(users, u)
+		}
+	}
+	return users
+}
+
+func (s *OrgSync) reassignReviews(ctx context.Context, userID string, report *SyncReport) error {
+	prs, err := s.users.GetUserReviewPRs(ctx, userID, false)
+	if err != nil {
+		return fmt.Errorf("list reviews of %s: %w", userID, err)
+	}
+
+	for _, pr := range prs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+
+		entry := Reassignment{PullRequestID: pr.ID, ReviewerID: userID}
+
+		_, replacedBy, err := s.prs.ReassignReviewer(ctx, pr.ID, userID)
+		if err != nil {
+			var derr *domain.Error
+			if !errors.As(err, &derr) {
+				return fmt.Errorf("reassign %s on %s: %w", userID, pr.ID, err)
+			}
+			entry.Reason = derr.Message
+			report.Unreassigned = append(report.Unreassigned, entry)
+			continue
+		}
+
+		entry.ReplacedBy = replacedBy
+		report.Reassigned = append(report.Reassigned, entry)
+	}
+
+	return nil
+}
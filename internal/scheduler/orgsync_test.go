@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+)
+
+type sourceStub struct {
+	snapshot domain.OrgSnapshot
+	version  string
+}
+
+func (s *sourceStub) Load(ctx context.Context) (domain.OrgSnapshot, string, error) {
+	return s.snapshot, s.version, nil
+}
+
+type teamServiceStub struct {
+	service.TeamService
+	diff    domain.OrgDiff
+	imports int
+}
+
+func (s *teamServiceStub) ImportTeams(ctx context.Context, snapshot domain.OrgSnapshot, dryRun bool) (domain.OrgDiff, error) {
+	s.imports++
+	return s.diff, nil
+}
+
+type userServiceStub struct {
+	service.UserService
+	reviews map[string][]domain.PullRequest
+}
+
+func (s *userServiceStub) GetUserReviewPRs(ctx context.Context, userID string, includeArchived bool) ([]domain.PullRequest, error) {
+	return s.reviews[userID], nil
+}
+
+type syncReporterStub struct {
+	got []SyncReport
+}
+
+func (r *syncReporterStub) Report(ctx context.Context, report SyncReport) error {
+	r.got = append(r.got, report)
+	return nil
+}
+
+func TestOrgSync_ReassignsReviewsOfDeactivatedUsers(t *testing.T) {
+	source := &sourceStub{
+		snapshot: domain.OrgSnapshot{Teams: []domain.Team{{Name: "backend"}}},
+		version:  "v1",
+	}
+	teams := &teamServiceStub{diff: domain.OrgDiff{
+		DeactivatedUsers: []domain.User{{ID: "u2", TeamName: "backend"}},
+		UpdatedUsers: []domain.User{
+			{ID: "u3", Name: "Carol", TeamName: "backend", IsActive: false},
+			{ID: "u4", Name: "Dave (renamed)", TeamName: "backend", IsActive: true},
+		},
+	}}
+	users := &userServiceStub{reviews: map[string][]domain.PullRequest{
+		"u2": {{ID: "pr1", Status: domain.PRStatusOpen}, {ID: "pr2", Status: domain.PRStatusMerged}},
+		"u3": {{ID: "pr3", Status: domain.PRStatusOpen}},
+		"u4": {{ID: "pr4", Status: domain.PRStatusOpen}},
+	}}
+	prs := &prServiceStub{}
+	reporter := &syncReporterStub{}
+
+	sync := NewOrgSync(OrgSyncConfig{Interval: time.Minute}, source, teams, users, prs, reporter, &fakeClock{})
+
+	report, err := sync.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"pr1/u2", "pr3/u3"}
+	if len(prs.reassigned) != len(want) || prs.reassigned[0] != want[0] || prs.reassigned[1] != want[1] {
+		t.Fatalf("expected reassignments %v, got %v", want, prs.reassigned)
+	}
+	if len(report.Reassigned) != 2 || report.Reassigned[0].ReplacedBy != "u9" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if len(reporter.got) != 1 {
+		t.Fatalf("expected one report, got %d", len(reporter.got))
+	}
+
+	// The source did not change: the second run must not import again.
+	report, err = sync.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Skipped || teams.imports != 1 {
+		t.Fatalf("expected skipped run without import, got skipped=%v imports=%d", report.Skipped, teams.imports)
+	}
+}
+
+func TestOrgSync_NoCandidateIsReported(t *testing.T) {
+	source := &sourceStub{snapshot: domain.OrgSnapshot{Teams: []domain.Team{{Name: "backend"}}}, version: "v1"}
+	teams := &teamServiceStub{diff: domain.OrgDiff{DeactivatedUsers: []domain.User{{ID: "u2"}}}}
+	users := &userServiceStub{reviews: map[string][]domain.PullRequest{"u2": {{ID: "pr1", Status: domain.PRStatusOpen}}}}
+	prs := &prServiceStub{reassignErr: domain.NewError(domain.ErrorCodeNoCandidate, "no active replacement candidate in team")}
+
+	sync := NewOrgSync(OrgSyncConfig{}, source, teams, users, prs, &syncReporterStub{}, &fakeClock{})
+
+	report, err := sync.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Unreassigned) != 1 || report.Unreassigned[0].Reason == "" {
+		t.Fatalf("expected unreassigned review with reason, got %+v", report.Unreassigned)
+	}
+}
+
+func TestOrgSync_RefusesEmptySource(t *testing.T) {
+	teams := &teamServiceStub{}
+	sync := NewOrgSync(OrgSyncConfig{}, &sourceStub{version: "v1"}, teams, &userServiceStub{}, &prServiceStub{}, &syncReporterStub{}, &fakeClock{})
+
+	if _, err := sync.RunOnce(context.Background()); err == nil {
+		t.Fatal("expected error for empty source")
+	}
+	if teams.imports != 0 {
+		t.Fatal("empty source must not be imported")
+	}
+}
+
+func TestOrgSync_RetriesFailedReassignment(t *testing.T) {
+	ctx := context.Background()
+	store := fakes.New()
+	members := []domain.User{
+		{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+		{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		{ID: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+	}
+	if err := store.Teams().Create(ctx, &domain.Team{Name: "backend"}); err != nil {
+		t.Fatalf("seed team: %v", err)
+	}
+	if err := store.Users().SaveAll(ctx, members); err != nil {
+		t.Fatalf("seed users: %v", err)
+	}
+	if _, err := store.PRs().Create(ctx, domain.PullRequest{ID: "pr1", Name: "Add search", AuthorID: "u1", Status: domain.PRStatusOpen}); err != nil {
+		t.Fatalf("seed pr: %v", err)
+	}
+	if err := store.PRs().SetReviewers(ctx, "pr1", []string{"u2"}); err != nil {
+		t.Fatalf("seed reviewers: %v", err)
+	}
+
+	// Bob left the company.
+	source := &sourceStub{
+		snapshot: domain.OrgSnapshot{Teams: []domain.Team{{Name: "backend", Members: []domain.User{members[0], members[2]}}}},
+		version:  "v1",
+	}
+	sync := NewOrgSync(OrgSyncConfig{}, source,
+		service.NewTeamService(store.Teams(), store.Users()),
+		service.NewUserService(store.Users(), store.PRs()),
+		service.NewPullRequestService(store.PRs(), store.Users(), store.Teams(), service.SystemRand()),
+		&syncReporterStub{}, &fakeClock{})
+
+	errDB := errors.New("connection reset")
+	store.Fail("PRs.SetReviewers", errDB)
+	if _, err := sync.RunOnce(ctx); !errors.Is(err, errDB) {
+		t.Fatalf("expected %v, got %v", errDB, err)
+	}
+	store.Fail("PRs.SetReviewers", nil)
+
+	report, err := sync.RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Diff.Empty() {
+		t.Fatalf("expected the import to be applied already, got %+v", report.Diff)
+	}
+	if len(report.Reassigned) != 1 || report.Reassigned[0] != (Reassignment{PullRequestID: "pr1", ReviewerID: "u2", ReplacedBy: "u3"}) {
+		t.Fatalf("unexpected reassignments: %+v", report.Reassigned)
+	}
+	reviewers, err := store.PRs().ListReviewers(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("list reviewers: %v", err)
+	}
+	if !slices.Equal(reviewers, []string{"u3"}) {
+		t.Fatalf("expected reviewers [u3], got %v", reviewers)
+	}
+
+	// Nothing is left to retry once the source is applied.
+	if report, err = sync.RunOnce(ctx); err != nil || !report.Skipped {
+		t.Fatalf("expected skipped run, got %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,188 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+// Action is what the scheduler does with a review that is past the SLA.
+type Action string
+
+const (
+	ActionReassign Action = "reassign"
+	ActionEscalate Action = "escalate"
+)
+
+type Config struct {
+	SLA      time.Duration
+	Interval time.Duration
+	Action   Action
+}
+
+// Escalation is emitted for an overdue review that the scheduler could not
+// or was not allowed to resolve by reassignment.
+type Escalation struct {
+	Tenant  string
+	Review  domain.ReviewAssignment
+	Overdue time.Duration
+	Reason  string
+}
+
+type Escalator interface {
+	Escalate(ctx context.Context, e Escalation) error
+}
+
+// LogEscalator writes escalations to the standard logger.
+type LogEscalator struct{}
+
+func (LogEscalator) Escalate(_ context.Context, e Escalation) error {
+	log.Printf("review escalation: tenant=%s pr=%s reviewer=%s team=%s overdue=%s reason=%s",
+		e.Tenant, e.Review.PullRequestID, e.Review.ReviewerID, e.Review.TeamName, e.Overdue, e.Reason)
+	return nil
+}
+
+type SLAScheduler struct {
+	cfg       Config
+	tenants   domain.TenantRepository
+	reviews   service.ReviewService
+	prs       service.PullRequestService
+	escalator Escalator
+	clock     service.Clock
+
+	// escalated holds the overdue reviews already escalated, so that each
+	// assignment is escalated once rather than on every check.
+	escalated map[escalationKey]struct{}
+}
+
+type escalationKey struct {
+	tenant     string
+	prID       string
+	reviewerID string
+	assignedAt time.Time
+}
+
+// NewSLAScheduler checks the reviews of every tenant in tenants, or of the
+// default tenant only if tenants is nil.
+func NewSLAScheduler(
+	cfg Config,
+	tenants domain.TenantRepository,
+	reviews service.ReviewService,
+	prs service.PullRequestService,
+	escalator Escalator,
+	clock service.Clock,
+) *SLAScheduler {
+	return &SLAScheduler{
+		cfg:       cfg,
+		tenants:   tenants,
+		reviews:   reviews,
+		prs:       prs,
+		escalator: escalator,
+		clock:     clock,
+		escalated: make(map[escalationKey]struct{}),
+	}
+}
+
+// Run checks overdue reviews every cfg.Interval until ctx is cancelled.
+func (s *SLAScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RunOnce(ctx); err != nil {
+				log.Printf("review sla check: %v", err)
+			}
+		}
+	}
+}
+
+// RunOnce handles every review that has been waiting longer than the SLA.
+// A failing tenant does not stop the check of the others.
+func (s *SLAScheduler) RunOnce(ctx context.Context) error {
+	tenants := []string{domain.DefaultTenant}
+	if s.tenants != nil {
+		var err error
+		if tenants, err = s.tenants.List(ctx); err != nil {
+			return fmt.Errorf("review sla check: %w", err)
+		}
+	}
+
+	var errs []error
+	for _, tenant := range tenants {
+		if err := s.runTenant(domain.WithTenant(ctx, tenant), tenant); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *SLAScheduler) runTenant(ctx context.Context, tenant string) error {
+	overdue, err := s.reviews.ListOverdue(ctx, s.cfg.SLA, "")
+	if err != nil {
+		return fmt.Errorf("review sla check: %w", err)
+	}
+
+	now := s.clock.Now()
+	overdueKeys := make(map[escalationKey]struct{}, len(overdue))
+	for _, review := range overdue {
+		reason := "review SLA exceeded"
+		key := escalationKey{
+			tenant:     tenant,
+			prID:       review.PullRequestID,
+			reviewerID: review.ReviewerID,
+			assignedAt: review.AssignedAt.UTC(),
+		}
+		overdueKeys[key] = struct{}{}
+
+		if s.cfg.Action == ActionReassign {
+			_, replacedBy, err := s.prs.ReassignReviewer(ctx, review.PullRequestID, review.ReviewerID)
+			if err == nil {
+				log.Printf("review sla: pr=%s reviewer %s replaced by %s",
+					review.PullRequestID, review.ReviewerID, replacedBy)
+				continue
+			}
+
+			var derr *domain.Error
+			if !errors.As(err, &derr) {
+				return fmt.Errorf("review sla check: %w", err)
+			}
+			// The pull request was merged, closed or reassigned since it was listed.
+			if derr.Code != domain.ErrorCodeNoCandidate {
+				continue
+			}
+			reason = "no replacement reviewer available"
+		}
+
+		if _, ok := s.escalated[key]; ok {
+			continue
+		}
+		escalation := Escalation{
+			Tenant:  tenant,
+			Review:  review,
+			Overdue: now.Sub(review.AssignedAt),
+			Reason:  reason,
+		}
+		if err := s.escalator.Escalate(ctx, escalation); err != nil {
+			return fmt.Errorf("review sla check: escalate: %w", err)
+		}
+		s.escalated[key] = struct{}{}
+	}
+
+	// Reviews that are no longer overdue will not be listed again.
+	for key := range s.escalated {
+		if _, ok := overdueKeys[key]; !ok && key.tenant == tenant {
+			delete(s.escalated, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/service"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type reviewServiceStub struct {
+	reviews      []domain.ReviewAssignment
+	gotOlderThan time.Duration
+	gotTenants   []string
+}
+
+func (s *reviewServiceStub) ListOverdue(ctx context.Context, olderThan time.Duration, teamName string) ([]domain.ReviewAssignment, error) {
+	s.gotOlderThan = olderThan
+	s.gotTenants = append(s.gotTenants, domain.TenantFrom(ctx))
+	return s.reviews, nil
+}
+
+type tenantRepoStub struct {
+	domain.TenantRepository
+	tenants []string
+}
+
+func (r *tenantRepoStub) List(ctx context.Context) ([]string, error) {
+	return r.tenants, nil
+}
+
+type prServiceStub struct {
+	service.PullRequestService
+	reassignErr error
+	reassigned  []string
+}
+
+func (s *prServiceStub) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+	if s.reassignErr != nil {
+		return nil, "", s.reassignErr
+	}
+	s.reassigned = append(s.reassigned, prID+"/"+oldReviewerID)
+	return &domain.PullRequest{ID: prID}, "u9", nil
+}
+
+type escalatorStub struct {
+	got []Escalation
+}
+
+func (e *escalatorStub) Escalate(ctx context.Context, esc Escalation) error {
+	e.got = append(e.got, esc)
+	return nil
+}
+
+func overdueReview(assignedAt time.Time) domain.ReviewAssignment {
+	return domain.ReviewAssignment{
+		PullRequestID: "pr1",
+		ReviewerID:    "u2",
+		TeamName:      "backend",
+		AssignedAt:    assignedAt,
+	}
+}
+
+func TestSLAScheduler_Reassign(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	reviews := &reviewServiceStub{reviews: []domain.ReviewAssignment{overdueReview(clock.now.Add(-72 * time.Hour))}}
+	prs := &prServiceStub{}
+	esc := &escalatorStub{}
+
+	s := NewSLAScheduler(Config{SLA: 48 * time.Hour, Action: ActionReassign}, nil, reviews, prs, esc, clock)
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if reviews.gotOlderThan != 48*time.Hour {
+		t.Fatalf("expected SLA 48h passed to service, got %s", reviews.gotOlderThan)
+	}
+	if len(prs.reassigned) != 1 || prs.reassigned[0] != "pr1/u2" {
+		t.Fatalf("expected pr1/u2 reassigned, got %v", prs.reassigned)
+	}
+	if len(esc.got) != 0 {
+		t.Fatalf("expected no escalations, got %v", esc.got)
+	}
+}
+
+func TestSLAScheduler_Reassign_NoCandidateEscalates(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	reviews := &reviewServiceStub{reviews: []domain.ReviewAssignment{overdueReview(clock.now.Add(-50 * time.Hour))}}
+	prs := &prServiceStub{reassignErr: domain.NewError(domain.ErrorCodeNoCandidate, "no candidates")}
+	esc := &escalatorStub{}
+
+	s := NewSLAScheduler(Config{SLA: 48 * time.Hour, Action: ActionReassign}, nil, reviews, prs, esc, clock)
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if len(esc.got) != 1 {
+		t.Fatalf("expected 1 escalation, got %d", len(esc.got))
+	}
+	if esc.got[0].Overdue != 50*time.Hour {
+		t.Fatalf("expected overdue 50h, got %s", esc.got[0].Overdue)
+	}
+}
+
+func TestSLAScheduler_Reassign_StalePRSkipped(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	reviews := &reviewServiceStub{reviews: []domain.ReviewAssignment{overdueReview(clock.now.Add(-50 * time.Hour))}}
+	prs := &prServiceStub{reassignErr: domain.NewError(domain.ErrorCodePRMerged, "merged")}
+	esc := &escalatorStub{}
+
+	s := NewSLAScheduler(Config{SLA: 48 * time.Hour, Action: ActionReassign}, nil, reviews, prs, esc, clock)
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if len(esc.got) != 0 {
+		t.Fatalf("expected no escalations, got %v", esc.got)
+	}
+}
+
+func TestSLAScheduler_Escalate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	reviews := &reviewServiceStub{reviews: []domain.ReviewAssignment{overdueReview(clock.now.Add(-49 * time.Hour))}}
+	prs := &prServiceStub{}
+	esc := &escalatorStub{}
+
+	s := NewSLAScheduler(Config{SLA: 48 * time.Hour, Action: ActionEscalate}, nil, reviews, prs, esc, clock)
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if len(prs.reassigned) != 0 {
+		t.Fatalf("escalate mode must not reassign, got %v", prs.reassigned)
+	}
+	if len(esc.got) != 1 || esc.got[0].Review.PullRequestID != "pr1" {
+		t.Fatalf("expected escalation for pr1, got %v", esc.got)
+	}
+}
+
+func TestSLAScheduler_EscalatesOnce(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	assignedAt := clock.now.Add(-50 * time.Hour)
+	reviews := &reviewServiceStub{reviews: []domain.ReviewAssignment{overdueReview(assignedAt)}}
+	prs := &prServiceStub{reassignErr: domain.NewError(domain.ErrorCodeNoCandidate, "no candidates")}
+	esc := &escalatorStub{}
+
+	s := NewSLAScheduler(Config{SLA: 48 * time.Hour, Action: ActionReassign}, nil, reviews, prs, esc, clock)
+	for range 2 {
+		if err := s.RunOnce(context.Background()); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		clock.now = clock.now.Add(time.Hour)
+	}
+	if len(esc.got) != 1 {
+		t.Fatalf("expected one escalation, got %v", esc.got)
+	}
+
+	// The reviewer was assigned again: the new assignment is escalated too.
+	reviews.reviews = []domain.ReviewAssignment{overdueReview(assignedAt.Add(time.Minute))}
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(esc.got) != 2 || len(s.escalated) != 1 {
+		t.Fatalf("expected a second escalation and one tracked review, got %v and %v", esc.got, s.escalated)
+	}
+}
+
+func TestSLAScheduler_ChecksEveryTenant(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)}
+	reviews := &reviewServiceStub{reviews: []domain.ReviewAssignment{overdueReview(clock.now.Add(-49 * time.Hour))}}
+	tenants := &tenantRepoStub{tenants: []string{"default", "payments"}}
+	esc := &escalatorStub{}
+
+	s := NewSLAScheduler(Config{SLA: 48 * time.Hour, Action: ActionEscalate}, tenants, reviews, &prServiceStub{}, esc, clock)
+	if err := s.RunOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if !slices.Equal(reviews.gotTenants, tenants.tenants) {
+		t.Fatalf("checked tenants %v, want %v", reviews.gotTenants, tenants.tenants)
+	}
+	if len(esc.got) != 2 || esc.got[0].Tenant != "default" || esc.got[1].Tenant != "payments" {
+		t.Fatalf("expected one escalation per tenant, got %v", esc.got)
+	}
+}
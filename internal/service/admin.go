@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+type AdminService interface {
+	// Snapshot returns a versioned copy of the whole store.
+	Snapshot(ctx context.Context) (*domain.Archive, error)
+	// Restore validates the archive and loads it into an empty store.
+	Restore(ctx context.Context, archive *domain.Archive) error
+	// CacheStats reports the repository caches; disabled when there are none.
+	CacheStats() domain.CacheStats
+}
+
+// CacheStatsSource reports hit rates of the repository caches.
+type CacheStatsSource interface {
+	Stats() domain.CacheStats
+}
+
+var _ AdminService = (*adminService)(nil)
+
+type adminService struct {
+	repo   domain.ArchiveRepository
+	clock  Clock
+	caches CacheStatsSource
+}
+
+// NewAdminService builds the admin service; caches is nil when the
+// repositories are not cached.
+func NewAdminService(repo domain.ArchiveRepository, clock Clock, caches CacheStatsSource) AdminService {
+	return &adminService{
+		repo:   repo,
+		clock:  clock,
+		caches: caches,
+	}
+}
+
+func (s *adminService) Snapshot(ctx context.Context) (*domain.Archive, error) {
+	archive, err := s.repo.Dump(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	archive.Version = domain.ArchiveVersion
+	archive.CreatedAt = s.clock.Now().UTC()
+
+	return archive, nil
+}
+
+func (s *adminService) Restore(ctx context.Context, archive *domain.Archive) error {
+	if archive == nil {
+		return domain.NewError(domain.ErrorCodeBadRequest, "empty archive")
+	}
+	if err := archive.Validate(); err != nil {
+		return domain.NewError(domain.ErrorCodeBadRequest, err.Error())
+	}
+
+	if err := s.repo.Restore(ctx, archive); err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+
+	return nil
+}
+
+func (s *adminService) CacheStats() domain.CacheStats {
+	if s.caches == nil {
+		return domain.CacheStats{}
+	}
+	return s.caches.Stats()
+}
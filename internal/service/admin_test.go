@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+type archiveRepoMock struct {
+	dump     *domain.Archive
+	restored *domain.Archive
+}
+
+func (m *archiveRepoMock) Dump(ctx context.Context) (*domain.Archive, error) {
+	return m.dump, nil
+}
+
+func (m *archiveRepoMock) Restore(ctx context.Context, archive *domain.Archive) error {
+	m.restored = archive
+	return nil
+}
+
+func validArchive() *domain.Archive {
+	at := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	return &domain.Archive{
+		Version: domain.ArchiveVersion,
+		Teams:   []string{"backend"},
+		Users: []domain.User{
+			{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+		},
+		PullRequests: []domain.PullRequest{
+			{ID: "pr1", Name: "Add search", AuthorID: "u1", Status: domain.PRStatusOpen, Reviewers: []string{"u2"}, CreatedAt: at},
+		},
+		Assignments:    []domain.AssignmentRecord{{PullRequestID: "pr1", ReviewerID: "u2", AssignedAt: at}},
+		ActivityEvents: []domain.ActivityEvent{{UserID: "u1", IsActive: true, ChangedAt: at}},
+	}
+}
+
+func TestAdminService_Snapshot(t *testing.T) {
+	now := time.Date(2025, 10, 24, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	repo := &archiveRepoMock{dump: &domain.Archive{Teams: []string{"backend"}}}
+
+	archive, err := NewAdminService(repo, fixedClock{now: now}, nil).Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if archive.Version != domain.ArchiveVersion || !archive.CreatedAt.Equal(now) || archive.CreatedAt.Location() != time.UTC {
+		t.Fatalf("unexpected archive header: version=%d created_at=%s", archive.Version, archive.CreatedAt)
+	}
+}
+
+func TestAdminService_Restore_IntegrityChecks(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(a *domain.Archive)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(a *domain.Archive) {}},
+		{name: "version", mutate: func(a *domain.Archive) { a.Version = 2 }, wantErr: "unsupported archive version"},
+		{name: "user team", mutate: func(a *domain.Archive) { a.Users[1].TeamName = "ghost" }, wantErr: `unknown team "ghost"`},
+		{name: "duplicate user", mutate: func(a *domain.Archive) { a.Users[1].ID = "u1" }, wantErr: `duplicate user "u1"`},
+		{name: "author", mutate: func(a *domain.Archive) { a.PullRequests[0].AuthorID = "u9" }, wantErr: `unknown author "u9"`},
+		{name: "reviewer", mutate: func(a *domain.Archive) { a.PullRequests[0].Reviewers = []string{"u9"} }, wantErr: `unknown reviewer "u9"`},
+		{name: "status", mutate: func(a *domain.Archive) { a.PullRequests[0].Status = "LOST" }, wantErr: "unknown status"},
+		{name: "assignment pr", mutate: func(a *domain.Archive) { a.Assignments[0].PullRequestID = "pr9" }, wantErr: `unknown pull request "pr9"`},
+		{name: "event user", mutate: func(a *domain.Archive) { a.ActivityEvents[0].UserID = "u9" }, wantErr: `unknown user "u9"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := validArchive()
+			tt.mutate(archive)
+
+			repo := &archiveRepoMock{}
+			err := NewAdminService(repo, SystemClock(), nil).Restore(context.Background(), archive)
+
+			if tt.wantErr == "" {
+				if err != nil || repo.restored != archive {
+					t.Fatalf("expected archive to be restored, got err %v", err)
+				}
+				return
+			}
+			requireCode(t, err, domain.ErrorCodeBadRequest)
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if repo.restored != nil {
+				t.Fatal("invalid archive must not reach the repository")
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package service
+
+import "time"
+
+// Clock abstracts the current time so that time-based logic can be tested.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock returns a Clock backed by time.Now.
+func SystemClock() Clock {
+	return systemClock{}
+}
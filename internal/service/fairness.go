@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// A member whose share of assignments differs from their share of active time
+// by more than these factors is flagged as an outlier.
+const (
+	fairnessOverRatio  = 1.5
+	fairnessUnderRatio = 0.5
+)
+
+// GetFairness reports, per team, how assignments in the filter's window were
+// distributed relative to each member's active time. A user is treated as not
+// being a member before their first recorded activity event.
+func (s *statsService) GetFairness(ctx context.Context, f domain.StatsFilter) ([]domain.TeamFairness, error) {
+	f.Granularity = domain.GranularityDay
+	f, err := s.normalizeFilter(f)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.ListActivityEvents(ctx, f.TeamName, f.To)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.ListAssignments(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	type member struct {
+		events      []domain.ActivityEvent
+		assignments int
+	}
+	teams := make(map[string]map[string]*member)
+
+	get := func(team, userID string) *member {
+		if teams[team] == nil {
+			teams[team] = make(map[string]*member)
+		}
+		m, ok := teams[team][userID]
+		if !ok {
+			m = &member{}
+			teams[team][userID] = m
+		}
+		return m
+	}
+
+	for _, e := range events {
+		m := get(e.TeamName, e.UserID)
+		m.events = append(m.events, e)
+	}
+	for _, a := range history {
+		get(a.TeamName, a.ReviewerID).assignments++
+	}
+
+	names := make([]string, 0, len(teams))
+	for name := range teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reports := make([]domain.TeamFairness, 0, len(names))
+	for _, name := range names {
+		report := domain.TeamFairness{
+			TeamName: name,
+			From:     f.From,
+			To:       f.To,
+		}
+
+		var totalActive time.Duration
+		active := make(map[string]time.Duration, len(teams[name]))
+		for userID, m := range teams[name] {
+			d := activeDuration(m.events, f.From, f.To)
+			active[userID] = d
+			totalActive += d
+			report.TotalAssignments += m.assignments
+		}
+
+		var rates []float64
+		for userID, m := range teams[name] {
+			mf := domain.MemberFairness{
+				UserID:      userID,
+				Assignments: m.assignments,
+				ActiveDays:  active[userID].Hours() / 24,
+			}
+			if report.TotalAssignments > 0 {
+				mf.AssignmentShare = float64(m.assignments) / float64(report.TotalAssignments)
+			}
+			if totalActive > 0 {
+				mf.ActiveShare = float64(active[userID]) / float64(totalActive)
+			}
+
+			if mf.ActiveDays > 0 {
+				rates = append(rates, float64(m.assignments)/mf.ActiveDays)
+			}
+
+			if mf.ActiveShare > 0 && report.TotalAssignments > 0 {
+				ratio := mf.AssignmentShare / mf.ActiveShare
+				mf.Ratio = &ratio
+
+				switch {
+				case ratio > fairnessOverRatio:
+					mf.Outlier = "over"
+				case ratio < fairnessUnderRatio:
+					mf.Outlier = "under"
+				}
+			}
+
+			report.Members = append(report.Members, mf)
+		}
+
+		sort.Slice(report.Members, func(i, j int) bool {
+			return report.Members[i].UserID < report.Members[j].UserID
+		})
+
+		report.Gini = gini(rates)
+		report.MaxMinRatio = maxMinRatio(rates)
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// activeDuration returns how long the user was active within [from, to).
+// events must be ordered by time.
+func activeDuration(events []domain.ActivityEvent, from, to time.Time) time.Duration {
+	var (
+		total  time.Duration
+		active bool
+		cursor = from
+	)
+
+	for _, e := range events {
+		if e.ChangedAt.After(from) {
+			if active {
+				total += e.ChangedAt.Sub(cursor)
+			}
+			cursor = e.ChangedAt
+		}
+		active = e.IsActive
+	}
+
+	if active && cursor.Before(to) {
+		total += to.Sub(cursor)
+	}
+
+	return total
+}
+
+// gini is the Gini coefficient of xs: 0 for a perfectly even distribution,
+// approaching 1 when everything goes to a single member.
+func gini(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	if len(xs) == 0 || sum == 0 {
+		return 0
+	}
+
+	var diff float64
+	for _, a := range xs {
+		for _, b := range xs {
+			diff += math.Abs(a - b)
+		}
+	}
+
+	return diff / (2 * float64(len(xs)) * sum)
+}
+
+func maxMinRatio(xs []float64) *float64 {
+	if len(xs) == 0 {
+		return nil
+	}
+
+	lo, hi := xs[0], xs[0]
+	for _, x := range xs[1:] {
+		lo = math.Min(lo, x)
+		hi = math.Max(hi, x)
+	}
+	if lo == 0 {
+		return nil
+	}
+
+	ratio := hi / lo
+	return &ratio
+}
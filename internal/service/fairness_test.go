@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+func TestStatsService_GetFairness(t *testing.T) {
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+	before := from.AddDate(0, 0, -1)
+
+	repo := &statsRepoMock{
+		events: []domain.ActivityEvent{
+			{UserID: "u1", TeamName: "backend", IsActive: true, ChangedAt: before},
+			{UserID: "u2", TeamName: "backend", IsActive: true, ChangedAt: before},
+			// u3 was active only for the second half of the window.
+			{UserID: "u3", TeamName: "backend", IsActive: false, ChangedAt: before},
+			{UserID: "u3", TeamName: "backend", IsActive: true, ChangedAt: from.AddDate(0, 0, 5)},
+		},
+		history: []domain.AssignmentRecord{
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(time.Hour)},
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(2 * time.Hour)},
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(3 * time.Hour)},
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(4 * time.Hour)},
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(5 * time.Hour)},
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(6 * time.Hour)},
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(7 * time.Hour)},
+			{ReviewerID: "u1", TeamName: "backend", AssignedAt: from.Add(8 * time.Hour)},
+			{ReviewerID: "u2", TeamName: "backend", AssignedAt: from.Add(9 * time.Hour)},
+			{ReviewerID: "u3", TeamName: "backend", AssignedAt: from.AddDate(0, 0, 6)},
+		},
+	}
+
+	svc := NewStatsService(repo, fixedClock{now: to})
+
+	got, err := svc.GetFairness(context.Background(), domain.StatsFilter{From: from, To: to})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 team, got %d", len(got))
+	}
+
+	team := got[0]
+	if team.TeamName != "backend" || team.TotalAssignments != 10 {
+		t.Fatalf("wrong team report: %+v", team)
+	}
+	if len(team.Members) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(team.Members))
+	}
+
+	u1, u2, u3 := team.Members[0], team.Members[1], team.Members[2]
+	if u1.ActiveDays != 10 || u3.ActiveDays != 5 {
+		t.Fatalf("wrong active days: u1=%v u3=%v", u1.ActiveDays, u3.ActiveDays)
+	}
+	if u1.Outlier != "over" {
+		t.Fatalf("u1 should be over-assigned, got %q (ratio %v)", u1.Outlier, *u1.Ratio)
+	}
+	if u2.Outlier != "under" {
+		t.Fatalf("u2 should be under-assigned, got %q (ratio %v)", u2.Outlier, *u2.Ratio)
+	}
+	if u3.Outlier != "" {
+		t.Fatalf("u3 should not be flagged, got %q (ratio %v)", u3.Outlier, *u3.Ratio)
+	}
+
+	// rates per active day are 0.8, 0.1 and 0.2
+	if team.MaxMinRatio == nil || math.Abs(*team.MaxMinRatio-8) > 1e-9 {
+		t.Fatalf("wrong max/min ratio: %v", team.MaxMinRatio)
+	}
+	if math.Abs(team.Gini-gini([]float64{0.8, 0.1, 0.2})) > 1e-9 || team.Gini <= 0 {
+		t.Fatalf("wrong gini: %v", team.Gini)
+	}
+}
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []float64
+		want float64
+	}{
+		{"empty", nil, 0},
+		{"all zero", []float64{0, 0}, 0},
+		{"even", []float64{2, 2, 2, 2}, 0},
+		{"single owner", []float64{0, 0, 0, 4}, 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gini(tt.xs); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestActiveDuration(t *testing.T) {
+	from := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+	at := func(h int) time.Time { return from.Add(time.Duration(h) * time.Hour) }
+
+	tests := []struct {
+		name   string
+		events []domain.ActivityEvent
+		want   time.Duration
+	}{
+		{"no history", nil, 0},
+		{"active before window", []domain.ActivityEvent{{IsActive: true, ChangedAt: at(-5)}}, 10 * time.Hour},
+		{"joined mid window", []domain.ActivityEvent{{IsActive: true, ChangedAt: at(4)}}, 6 * time.Hour},
+		{"paused", []domain.ActivityEvent{
+			{IsActive: true, ChangedAt: at(-1)},
+			{IsActive: false, ChangedAt: at(2)},
+			{IsActive: true, ChangedAt: at(7)},
+		}, 5 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := activeDuration(tt.events, from, to); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
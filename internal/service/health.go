@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// readinessPingTimeout bounds the database check of a readiness probe.
+const readinessPingTimeout = 2 * time.Second
+
+// Pinger checks that a dependency is reachable; *sql.DB satisfies it.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Readiness is the outcome of a readiness probe.
+type Readiness struct {
+	// Database is the error of the database ping, nil when it succeeded.
+	Database error
+	Migrated bool
+	Draining bool
+}
+
+// Ready reports whether the instance should receive traffic.
+func (r Readiness) Ready() bool {
+	return r.Database == nil && r.Migrated && !r.Draining
+}
+
+type HealthService interface {
+	// Readiness checks the database and reports the lifecycle state.
+	Readiness(ctx context.Context) Readiness
+	// MarkMigrated records that the schema migrations have been applied.
+	MarkMigrated()
+	// Drain makes the instance report not ready ahead of shutdown, so
+	// load balancers stop routing to it.
+	Drain()
+}
+
+var _ HealthService = (*healthService)(nil)
+
+type healthService struct {
+	db       Pinger
+	migrated atomic.Bool
+	draining atomic.Bool
+}
+
+func NewHealthService(db Pinger) HealthService {
+	return &healthService{
+		db: db,
+	}
+}
+
+func (s *healthService) Readiness(ctx context.Context) Readiness {
+	ctx, cancel := context.WithTimeout(ctx, readinessPingTimeout)
+	defer cancel()
+
+	var dbErr error
+	if err := s.db.PingContext(ctx); err != nil {
+		dbErr = fmt.Errorf("ping database: %w", err)
+	}
+
+	return Readiness{
+		Database: dbErr,
+		Migrated: s.migrated.Load(),
+		Draining: s.draining.Load(),
+	}
+}
+
+func (s *healthService) MarkMigrated() {
+	s.migrated.Store(true)
+}
+
+func (s *healthService) Drain() {
+	s.draining.Store(true)
+}
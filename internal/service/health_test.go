@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type pingerStub struct {
+	err error
+}
+
+func (p pingerStub) PingContext(context.Context) error {
+	return p.err
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	down := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		pingErr   error
+		migrated  bool
+		drain     bool
+		wantReady bool
+	}{
+		{name: "ready", migrated: true, wantReady: true},
+		{name: "database down", pingErr: down, migrated: true},
+		{name: "migrations pending"},
+		{name: "draining", migrated: true, drain: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewHealthService(pingerStub{err: tt.pingErr})
+			if tt.migrated {
+				s.MarkMigrated()
+			}
+			if tt.drain {
+				s.Drain()
+			}
+
+			got := s.Readiness(context.Background())
+
+			if got.Ready() != tt.wantReady {
+				t.Fatalf("Ready() = %v, want %v (%+v)", got.Ready(), tt.wantReady, got)
+			}
+			if !errors.Is(got.Database, tt.pingErr) {
+				t.Fatalf("Database = %v, want %v", got.Database, tt.pingErr)
+			}
+		})
+	}
+}
@@ -2,15 +2,28 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/ChernykhITMO/Avito/internal/domain"
 )
 
 type PullRequestService interface {
 	Create(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error)
+	CreateDraft(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error)
+	MarkReady(ctx context.Context, id string) (*domain.PullRequest, error)
 	Merge(ctx context.Context, id string) (*domain.PullRequest, error)
+	Close(ctx context.Context, id string) (*domain.PullRequest, error)
+	Reopen(ctx context.Context, id string) (*domain.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error)
+	// Get looks in the archive as well if includeArchived is set and the
+	// pull request is not live.
+	Get(ctx context.Context, id string, includeArchived bool) (*domain.PullRequest, error)
+	// ArchiveMerged moves pull requests merged before mergedBefore to the
+	// archive and returns how many were moved.
+	ArchiveMerged(ctx context.Context, mergedBefore time.Time) (int, error)
 }
 
 var _ PullRequestService = (*pullRequestService)(nil)
@@ -19,19 +32,27 @@ type pullRequestService struct {
 	prs   domain.PRRepository
 	users domain.UserRepository
 	teams domain.TeamRepository
+	rnd   Rand
 }
 
-func NewPullRequestService(prs domain.PRRepository, users domain.UserRepository, teams domain.TeamRepository) PullRequestService {
+func NewPullRequestService(prs domain.PRRepository, users domain.UserRepository, teams domain.TeamRepository, rnd Rand) PullRequestService {
 	return &pullRequestService{
 		prs:   prs,
 		users: users,
 		teams: teams,
+		rnd:   rnd,
 	}
 }
 
 func (s *pullRequestService) Create(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
-	const maxReviewers = 2
+	return s.create(ctx, id, name, authorID, domain.PRStatusOpen)
+}
+
+func (s *pullRequestService) CreateDraft(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
+	return s.create(ctx, id, name, authorID, domain.PRStatusDraft)
+}
 
+func (s *pullRequestService) create(ctx context.Context, id, name, authorID string, status domain.PRStatus) (*domain.PullRequest, error) {
 	if id == "" {
 		return nil, fmt.Errorf("create pull request: empty id")
 	}
@@ -51,30 +72,19 @@ func (s *pullRequestService) Create(ctx context.Context, id, name, authorID stri
 		return nil, fmt.Errorf("create pull request: get team: %w", err)
 	}
 
-	candidates, err := s.users.ListReviewCandidates(ctx, author.TeamName, author.ID)
-	if err != nil {
-		return nil, fmt.Errorf("create pull request: list review candidates: %w", err)
-	}
-
-	if len(candidates) == 0 {
-		return nil, domain.NewError(domain.ErrorCodeNoCandidate, "no review candidates found")
-	}
-
-	maxCandidates := maxReviewers
-	if len(candidates) < maxCandidates {
-		maxCandidates = len(candidates)
-	}
-
-	reviewerIDs := make([]string, 0, maxCandidates)
-	for i := 0; i < maxCandidates; i++ {
-		reviewerIDs = append(reviewerIDs, candidates[i].ID)
+	var reviewerIDs []string
+	if status == domain.PRStatusOpen {
+		reviewerIDs, err = s.pickReviewers(ctx, author)
+		if err != nil {
+			return nil, fmt.Errorf("create pull request: %w", err)
+		}
 	}
 
 	pr := domain.PullRequest{
 		ID:       id,
 		Name:     name,
 		AuthorID: authorID,
-		Status:   domain.PRStatusOpen,
+		Status:   status,
 	}
 
 	created, err := s.prs.Create(ctx, pr)
@@ -82,14 +92,45 @@ func (s *pullRequestService) Create(ctx context.Context, id, name, authorID stri
 		return nil, fmt.Errorf("create pull request: %w", err)
 	}
 
-	if err := s.prs.SetReviewers(ctx, created.ID, reviewerIDs); err != nil {
-		return nil, fmt.Errorf("create pull request: set reviewers: %w", err)
+	if len(reviewerIDs) > 0 {
+		if err := s.prs.SetReviewers(ctx, created.ID, reviewerIDs); err != nil {
+			return nil, fmt.Errorf("create pull request: set reviewers: %w", err)
+		}
 	}
 	created.Reviewers = reviewerIDs
 
 	return created, nil
 }
 
+// pickReviewers selects up to two random active members of the author's
+// team. The ids are sorted, the order in which reviewers assigned together
+// are listed.
+func (s *pullRequestService) pickReviewers(ctx context.Context, author *domain.User) ([]string, error) {
+	const maxReviewers = 2
+
+	candidates, err := s.users.ListReviewCandidates(ctx, author.TeamName, author.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list review candidates: %w", err)
+	}
+
+	if len(candidates) == 0 {
+		return nil, domain.NewError(domain.ErrorCodeNoCandidate, "no review candidates found")
+	}
+
+	// A partial Fisher-Yates shuffle: each step moves a random remaining
+	// candidate to position i.
+	n := min(maxReviewers, len(candidates))
+	reviewerIDs := make([]string, 0, n)
+	for i := range n {
+		j := i + s.rnd.IntN(len(candidates)-i)
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+		reviewerIDs = append(reviewerIDs, candidates[i].ID)
+	}
+	slices.Sort(reviewerIDs)
+
+	return reviewerIDs, nil
+}
+
 func (s *pullRequestService) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
 	if id == "" {
 		return nil, fmt.Errorf("merge pull request: empty id")
@@ -101,14 +142,19 @@ func (s *pullRequestService) Merge(ctx context.Context, id string) (*domain.Pull
 	}
 
 	if pr.Status == domain.PRStatusMerged {
-		return pr, nil
+		return s.load(ctx, id)
+	}
+
+	status, err := nextStatus(pr.Status, prActionMerge)
+	if err != nil {
+		return nil, fmt.Errorf("merge pull request: %w", err)
 	}
 
-	if err := s.prs.Update(ctx, id, domain.PRStatusMerged); err != nil {
+	if err := s.prs.Update(ctx, id, status); err != nil {
 		return nil, fmt.Errorf("merge pull request: %w", err)
 	}
 
-	mergedPR, err := s.prs.Get(ctx, id)
+	mergedPR, err := s.load(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("merge pull request: %w", err)
 	}
@@ -116,6 +162,145 @@ func (s *pullRequestService) Merge(ctx context.Context, id string) (*domain.Pull
 	return mergedPR, nil
 }
 
+// MarkReady moves a draft to OPEN and assigns its reviewers.
+func (s *pullRequestService) MarkReady(ctx context.Context, id string) (*domain.PullRequest, error) {
+	if id == "" {
+		return nil, fmt.Errorf("mark pull request ready: empty id")
+	}
+
+	pr, err := s.changeStatus(ctx, id, prActionReady)
+	if err != nil {
+		return nil, fmt.Errorf("mark pull request ready: %w", err)
+	}
+
+	return pr, nil
+}
+
+// Close abandons a pull request without merging it. Reviewers stay assigned
+// but can no longer be changed.
+func (s *pullRequestService) Close(ctx context.Context, id string) (*domain.PullRequest, error) {
+	if id == "" {
+		return nil, fmt.Errorf("close pull request: empty id")
+	}
+
+	pr, err := s.changeStatus(ctx, id, prActionClose)
+	if err != nil {
+		return nil, fmt.Errorf("close pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+// Reopen moves a closed pull request back to OPEN keeping its frozen reviewers.
+// A pull request closed while still a draft gets reviewers assigned instead.
+func (s *pullRequestService) Reopen(ctx context.Context, id string) (*domain.PullRequest, error) {
+	if id == "" {
+		return nil, fmt.Errorf("reopen pull request: empty id")
+	}
+
+	pr, err := s.changeStatus(ctx, id, prActionReopen)
+	if err != nil {
+		return nil, fmt.Errorf("reopen pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+// changeStatus applies action to the pull request and makes sure that a
+// pull request entering OPEN has reviewers.
+func (s *pullRequestService) changeStatus(ctx context.Context, id string, action prAction) (*domain.PullRequest, error) {
+	pr, err := s.prs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := nextStatus(pr.Status, action)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == domain.PRStatusOpen {
+		if err := s.ensureReviewers(ctx, pr); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.prs.Update(ctx, id, status); err != nil {
+		return nil, err
+	}
+
+	return s.load(ctx, id)
+}
+
+func (s *pullRequestService) ensureReviewers(ctx context.Context, pr *domain.PullRequest) error {
+	reviewers, err := s.prs.ListReviewers(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("list reviewers: %w", err)
+	}
+
+	if len(reviewers) > 0 {
+		return nil
+	}
+
+	author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	reviewers, err = s.pickReviewers(ctx, author)
+	if err != nil {
+		return err
+	}
+
+	if err := s.prs.SetReviewers(ctx, pr.ID, reviewers); err != nil {
+		return fmt.Errorf("set reviewers: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the pull request together with its assigned reviewers.
+func (s *pullRequestService) Get(ctx context.Context, id string, includeArchived bool) (*domain.PullRequest, error) {
+	if id == "" {
+		return nil, fmt.Errorf("get pull request: empty id")
+	}
+
+	pr, err := s.load(ctx, id)
+	var derr *domain.Error
+	if includeArchived && errors.As(err, &derr) && derr.Code == domain.ErrorCodeNotFound {
+		pr, err = s.prs.GetArchived(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+func (s *pullRequestService) ArchiveMerged(ctx context.Context, mergedBefore time.Time) (int, error) {
+	n, err := s.prs.Archive(ctx, mergedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("archive merged pull requests: %w", err)
+	}
+	return n, nil
+}
+
+// load returns the pull request together with its assigned reviewers.
+func (s *pullRequestService) load(ctx context.Context, id string) (*domain.PullRequest, error) {
+	pr, err := s.prs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := s.prs.ListReviewers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list reviewers: %w", err)
+	}
+	pr.Reviewers = reviewers
+
+	return pr, nil
+}
+
 func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
 	if prID == "" {
 		return nil, "", fmt.Errorf("reassign reviewer: empty pr id")
@@ -132,6 +317,9 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 	if pr.Status == domain.PRStatusMerged {
 		return nil, "", domain.NewError(domain.ErrorCodePRMerged, "pull request already merged")
 	}
+	if pr.Status != domain.PRStatusOpen {
+		return nil, "", domain.NewError(domain.ErrorCodePRNotOpen, "pull request is not open")
+	}
 
 	reviewers, err := s.prs.ListReviewers(ctx, prID)
 	if err != nil {
@@ -162,25 +350,14 @@ func (s *pullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		return nil, "", fmt.Errorf("reassign reviewer: list review candidates: %w", err)
 	}
 
-	var newReviewerID string
-
-candidateLoop:
-	for _, c := range candidates {
-		if c.ID == oldReviewerID {
-			continue
-		}
-		for _, existing := range reviewers {
-			if existing == c.ID {
-				continue candidateLoop
-			}
-		}
-		newReviewerID = c.ID
-		break
-	}
-
-	if newReviewerID == "" {
+	// The old reviewer is among the current ones, so this skips it too.
+	eligible := slices.DeleteFunc(candidates, func(c domain.User) bool {
+		return slices.Contains(reviewers, c.ID)
+	})
+	if len(eligible) == 0 {
 		return nil, "", domain.NewError(domain.ErrorCodeNoCandidate, "no replacement reviewer found")
 	}
+	newReviewerID := eligible[s.rnd.IntN(len(eligible))].ID
 
 	newReviewers := make([]string, len(reviewers))
 	copy(newReviewers, reviewers)
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+type prAction string
+
+const (
+	prActionReady  prAction = "ready"
+	prActionMerge  prAction = "merge"
+	prActionClose  prAction = "close"
+	prActionReopen prAction = "reopen"
+)
+
+// prTransitions is the pull request state machine: for every status it lists
+// the allowed actions and the status each of them leads to. MERGED is terminal.
+var prTransitions = map[domain.PRStatus]map[prAction]domain.PRStatus{
+	domain.PRStatusDraft: {
+		prActionReady: domain.PRStatusOpen,
+		prActionClose: domain.PRStatusClosed,
+	},
+	domain.PRStatusOpen: {
+		prActionMerge: domain.PRStatusMerged,
+		prActionClose: domain.PRStatusClosed,
+	},
+	domain.PRStatusClosed: {
+		prActionReopen: domain.PRStatusOpen,
+	},
+	domain.PRStatusMerged: {},
+}
+
+func nextStatus(from domain.PRStatus, action prAction) (domain.PRStatus, error) {
+	to, ok := prTransitions[from][action]
+	if !ok {
+		return "", domain.NewError(
+			domain.ErrorCodeInvalidTransition,
+			fmt.Sprintf("cannot %s pull request in status %s", action, from),
+		)
+	}
+	return to, nil
+}
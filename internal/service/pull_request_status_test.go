@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+func TestNextStatus(t *testing.T) {
+	tests := []struct {
+		from    domain.PRStatus
+		action  prAction
+		want    domain.PRStatus
+		allowed bool
+	}{
+		{domain.PRStatusDraft, prActionReady, domain.PRStatusOpen, true},
+		{domain.PRStatusDraft, prActionMerge, "", false},
+		{domain.PRStatusDraft, prActionClose, domain.PRStatusClosed, true},
+		{domain.PRStatusDraft, prActionReopen, "", false},
+
+		{domain.PRStatusOpen, prActionReady, "", false},
+		{domain.PRStatusOpen, prActionMerge, domain.PRStatusMerged, true},
+		{domain.PRStatusOpen, prActionClose, domain.PRStatusClosed, true},
+		{domain.PRStatusOpen, prActionReopen, "", false},
+
+		{domain.PRStatusClosed, prActionReady, "", false},
+		{domain.PRStatusClosed, prActionMerge, "", false},
+		{domain.PRStatusClosed, prActionClose, "", false},
+		{domain.PRStatusClosed, prActionReopen, domain.PRStatusOpen, true},
+
+		{domain.PRStatusMerged, prActionReady, "", false},
+		{domain.PRStatusMerged, prActionMerge, "", false},
+		{domain.PRStatusMerged, prActionClose, "", false},
+		{domain.PRStatusMerged, prActionReopen, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"/"+string(tt.action), func(t *testing.T) {
+			got, err := nextStatus(tt.from, tt.action)
+			if !tt.allowed {
+				var derr *domain.Error
+				if !errors.As(err, &derr) || derr.Code != domain.ErrorCodeInvalidTransition {
+					t.Fatalf("expected INVALID_TRANSITION, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPRTransitions_CoverAllStatuses(t *testing.T) {
+	statuses := []domain.PRStatus{
+		domain.PRStatusDraft,
+		domain.PRStatusOpen,
+		domain.PRStatusMerged,
+		domain.PRStatusClosed,
+	}
+
+	for _, s := range statuses {
+		if _, ok := prTransitions[s]; !ok {
+			t.Fatalf("status %s is missing from the state machine", s)
+		}
+	}
+}
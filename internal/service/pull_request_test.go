@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+)
+
+var (
+	// errDB stands for any repository failure injected with Store.Fail.
+	errDB = errors.New("connection reset")
+	// errBadInput matches the validation errors of the services, which
+	// are plain errors rather than domain ones.
+	errBadInput = errors.New("bad input")
+)
+
+var testEpoch = time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+// scriptedRand returns its numbers in order and zeros once they run out.
+type scriptedRand struct {
+	mu   sync.Mutex
+	next []int
+}
+
+func newScriptedRand(next ...int) *scriptedRand {
+	return &scriptedRand{next: next}
+}
+
+func (r *scriptedRand) IntN(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.next) == 0 {
+		return 0
+	}
+	v := r.next[0]
+	r.next = r.next[1:]
+	if v < 0 || v >= n {
+		panic(fmt.Sprintf("scriptedRand: %d is out of [0, %d)", v, n))
+	}
+	return v
+}
+
+// newTestStore returns a store with the teams backend (u1-u5), pair (u6,
+// u7) and solo (u8), and u0 who belongs to no team.
+func newTestStore(t *testing.T) *fakes.Store {
+	t.Helper()
+
+	store := fakes.New()
+	store.SetNow(testEpoch)
+
+	repos := fixturesFor(store)
+	repos.Team("backend").Members("u1", "u2", "u3", "u4", "u5").Create(t)
+	repos.Team("pair").Members("u6", "u7").Create(t)
+	repos.Team("solo").Members("u8").Create(t)
+	if err := store.Users().SaveAll(context.Background(), []domain.User{fixtures.User("u0")}); err != nil {
+		t.Fatalf("seed u0: %v", err)
+	}
+
+	return store
+}
+
+// fixturesFor arranges state in store. Pull requests are written past the
+// services and their state machine.
+func fixturesFor(store *fakes.Store) fixtures.Repos {
+	return fixtures.Repos{Teams: store.Teams(), Users: store.Users(), PRs: store.PRs()}
+}
+
+func requireCode(t *testing.T, err error, code domain.Code) {
+	t.Helper()
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != code {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+}
+
+// requireErr checks err against the expectation of a test case: a domain
+// error code, errBadInput, an injected error or, if both are empty, none.
+func requireErr(t *testing.T, err error, code domain.Code, want error) {
+	t.Helper()
+
+	var derr *domain.Error
+	switch {
+	case code != "":
+		requireCode(t, err, code)
+	case want == errBadInput:
+		if err == nil || errors.As(err, &derr) {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+	case want != nil:
+		if !errors.Is(err, want) {
+			t.Fatalf("expected %v, got %v", want, err)
+		}
+	case err != nil:
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// requireStored checks the status and reviewers the store holds for id.
+func requireStored(t *testing.T, store *fakes.Store, id string, status domain.PRStatus, reviewers []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	pr, err := store.PRs().Get(ctx, id)
+	if err != nil {
+		t.Fatalf("get %s: %v", id, err)
+	}
+	got, err := store.PRs().ListReviewers(ctx, id)
+	if err != nil {
+		t.Fatalf("list reviewers of %s: %v", id, err)
+	}
+	if pr.Status != status || !slices.Equal(got, reviewers) {
+		t.Fatalf("stored %s: status %s, reviewers %v; want %s, %v", id, pr.Status, got, status, reviewers)
+	}
+}
+
+func newPRService(store *fakes.Store, rnd Rand) PullRequestService {
+	return NewPullRequestService(store.PRs(), store.Users(), store.Teams(), rnd)
+}
+
+func TestPullRequestService_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		draft     bool
+		id        string
+		prName    string
+		authorID  string
+		rand      []int
+		fail      string
+		code      domain.Code
+		err       error
+		reviewers []string
+	}{
+		{name: "empty id", id: "", prName: "Feature", authorID: "u1", err: errBadInput},
+		{name: "empty name", id: "pr1", prName: "", authorID: "u1", err: errBadInput},
+		{name: "empty author", id: "pr1", prName: "Feature", authorID: "", err: errBadInput},
+		{name: "unknown author", id: "pr1", prName: "Feature", authorID: "nobody", code: domain.ErrorCodeNotFound},
+		{name: "author without team", id: "pr1", prName: "Feature", authorID: "u0", code: domain.ErrorCodeNotFound},
+		{name: "no candidates", id: "pr1", prName: "Feature", authorID: "u8", code: domain.ErrorCodeNoCandidate},
+		{name: "existing id", id: "pr0", prName: "Feature", authorID: "u1", code: domain.ErrorCodePRExists},
+		{name: "get author fails", id: "pr1", prName: "Feature", authorID: "u1", fail: "Users.GetUserByID", err: errDB},
+		{name: "get team fails", id: "pr1", prName: "Feature", authorID: "u1", fail: "Teams.GetByName", err: errDB},
+		{name: "list candidates fails", id: "pr1", prName: "Feature", authorID: "u1", fail: "Users.ListReviewCandidates", err: errDB},
+		{name: "create fails", id: "pr1", prName: "Feature", authorID: "u1", fail: "PRs.Create", err: errDB},
+		{name: "set reviewers fails", id: "pr1", prName: "Feature", authorID: "u1", fail: "PRs.SetReviewers", err: errDB},
+		{name: "single candidate", id: "pr1", prName: "Feature", authorID: "u6", reviewers: []string{"u7"}},
+		{name: "first two candidates", id: "pr1", prName: "Feature", authorID: "u1", rand: []int{0, 0}, reviewers: []string{"u2", "u3"}},
+		// u5 and then u4 are drawn; the reviewers are sorted.
+		{name: "random candidates", id: "pr1", prName: "Feature", authorID: "u1", rand: []int{3, 1}, reviewers: []string{"u4", "u5"}},
+		{name: "draft", draft: true, id: "pr1", prName: "Draft", authorID: "u1"},
+		{name: "draft without candidates", draft: true, id: "pr1", prName: "Draft", authorID: "u8"},
+		{name: "draft with existing id", draft: true, id: "pr0", prName: "Draft", authorID: "u1", code: domain.ErrorCodePRExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			fixturesFor(store).PR("pr0", "u1").Reviewers("u2").Create(t)
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+			svc := newPRService(store, newScriptedRand(tt.rand...))
+
+			create, status := svc.Create, domain.PRStatusOpen
+			if tt.draft {
+				create, status = svc.CreateDraft, domain.PRStatusDraft
+			}
+
+			pr, err := create(context.Background(), tt.id, tt.prName, tt.authorID)
+			requireErr(t, err, tt.code, tt.err)
+			if tt.err == errBadInput && store.Calls("Users.GetUserByID") != 0 {
+				t.Fatal("invalid input reached the repositories")
+			}
+			if err != nil {
+				return
+			}
+
+			if pr.ID != tt.id || pr.Name != tt.prName || pr.AuthorID != tt.authorID || pr.Status != status {
+				t.Fatalf("unexpected pull request: %+v", pr)
+			}
+			if !slices.Equal(pr.Reviewers, tt.reviewers) {
+				t.Fatalf("reviewers %v, want %v", pr.Reviewers, tt.reviewers)
+			}
+			requireStored(t, store, tt.id, status, tt.reviewers)
+		})
+	}
+}
+
+func TestPullRequestService_Merge(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		fail       string
+		code       domain.Code
+		err        error
+		wantUpdate bool
+	}{
+		{name: "empty id", id: "", err: errBadInput},
+		{name: "unknown", id: "missing", code: domain.ErrorCodeNotFound},
+		{name: "open", id: "open", wantUpdate: true},
+		{name: "already merged", id: "merged"},
+		{name: "draft", id: "draft", code: domain.ErrorCodeInvalidTransition},
+		{name: "closed", id: "closed", code: domain.ErrorCodeInvalidTransition},
+		{name: "get fails", id: "open", fail: "PRs.Get", err: errDB},
+		{name: "update fails", id: "open", fail: "PRs.Update", err: errDB, wantUpdate: true},
+		{name: "list reviewers fails", id: "open", fail: "PRs.ListReviewers", err: errDB, wantUpdate: true},
+		{name: "list reviewers of merged fails", id: "merged", fail: "PRs.ListReviewers", err: errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			fixturesFor(store).PR("open", "u1").Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("merged", "u1").Status(domain.PRStatusMerged).Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("draft", "u1").Status(domain.PRStatusDraft).Create(t)
+			fixturesFor(store).PR("closed", "u1").Status(domain.PRStatusClosed).Reviewers("u2", "u3").Create(t)
+			updates := store.Calls("PRs.Update")
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+			svc := newPRService(store, newScriptedRand())
+
+			pr, err := svc.Merge(context.Background(), tt.id)
+			requireErr(t, err, tt.code, tt.err)
+			if updated := store.Calls("PRs.Update") > updates; updated != tt.wantUpdate {
+				t.Fatalf("updated = %v, want %v", updated, tt.wantUpdate)
+			}
+			if err != nil {
+				return
+			}
+
+			if pr.Status != domain.PRStatusMerged || pr.MergedAt.IsZero() || !slices.Equal(pr.Reviewers, []string{"u2", "u3"}) {
+				t.Fatalf("expected merged PR with its reviewers, got %+v", pr)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_ChangeStatus(t *testing.T) {
+	type op func(PullRequestService, context.Context, string) (*domain.PullRequest, error)
+	var (
+		markReady op = PullRequestService.MarkReady
+		closePR   op = PullRequestService.Close
+		reopen    op = PullRequestService.Reopen
+	)
+
+	tests := []struct {
+		name string
+		op   op
+		id   string
+		rand []int
+		fail string
+		code domain.Code
+		err  error
+		// status and reviewers are the stored state after the call.
+		status    domain.PRStatus
+		reviewers []string
+	}{
+		{name: "ready: empty id", op: markReady, id: "", err: errBadInput},
+		{name: "ready: unknown", op: markReady, id: "missing", code: domain.ErrorCodeNotFound},
+		{name: "ready: draft", op: markReady, id: "draft", rand: []int{3, 1}, status: domain.PRStatusOpen, reviewers: []string{"u4", "u5"}},
+		{name: "ready: open", op: markReady, id: "open", code: domain.ErrorCodeInvalidTransition, status: domain.PRStatusOpen, reviewers: []string{"u2", "u3"}},
+		{name: "ready: merged", op: markReady, id: "merged", code: domain.ErrorCodeInvalidTransition, status: domain.PRStatusMerged, reviewers: []string{"u2", "u3"}},
+		{name: "ready: no candidates", op: markReady, id: "solo-draft", code: domain.ErrorCodeNoCandidate, status: domain.PRStatusDraft},
+		{name: "ready: author deleted", op: markReady, id: "orphan-draft", code: domain.ErrorCodeNotFound, status: domain.PRStatusDraft},
+		{name: "ready: get fails", op: markReady, id: "draft", fail: "PRs.Get", err: errDB},
+		{name: "ready: list reviewers fails", op: markReady, id: "draft", fail: "PRs.ListReviewers", err: errDB, status: domain.PRStatusDraft},
+		{name: "ready: get author fails", op: markReady, id: "draft", fail: "Users.GetUserByID", err: errDB, status: domain.PRStatusDraft},
+		{name: "ready: set reviewers fails", op: markReady, id: "draft", fail: "PRs.SetReviewers", err: errDB, status: domain.PRStatusDraft},
+		{name: "ready: update fails", op: markReady, id: "draft", fail: "PRs.Update", err: errDB, status: domain.PRStatusDraft, reviewers: []string{"u2", "u3"}},
+
+		{name: "close: empty id", op: closePR, id: "", err: errBadInput},
+		{name: "close: unknown", op: closePR, id: "missing", code: domain.ErrorCodeNotFound},
+		{name: "close: open keeps reviewers", op: closePR, id: "open", status: domain.PRStatusClosed, reviewers: []string{"u2", "u3"}},
+		{name: "close: draft", op: closePR, id: "draft", status: domain.PRStatusClosed},
+		{name: "close: closed", op: closePR, id: "closed", code: domain.ErrorCodeInvalidTransition, status: domain.PRStatusClosed, reviewers: []string{"u2", "u3"}},
+		{name: "close: merged", op: closePR, id: "merged", code: domain.ErrorCodeInvalidTransition, status: domain.PRStatusMerged, reviewers: []string{"u2", "u3"}},
+		{name: "close: update fails", op: closePR, id: "open", fail: "PRs.Update", err: errDB, status: domain.PRStatusOpen, reviewers: []string{"u2", "u3"}},
+
+		{name: "reopen: empty id", op: reopen, id: "", err: errBadInput},
+		{name: "reopen: unknown", op: reopen, id: "missing", code: domain.ErrorCodeNotFound},
+		{name: "reopen: keeps reviewers", op: reopen, id: "closed", rand: []int{3, 1}, status: domain.PRStatusOpen, reviewers: []string{"u2", "u3"}},
+		{name: "reopen: closed draft gets reviewers", op: reopen, id: "closed-draft", rand: []int{3, 1}, status: domain.PRStatusOpen, reviewers: []string{"u4", "u5"}},
+		{name: "reopen: open", op: reopen, id: "open", code: domain.ErrorCodeInvalidTransition, status: domain.PRStatusOpen, reviewers: []string{"u2", "u3"}},
+		{name: "reopen: merged", op: reopen, id: "merged", code: domain.ErrorCodeInvalidTransition, status: domain.PRStatusMerged, reviewers: []string{"u2", "u3"}},
+		{name: "reopen: update fails", op: reopen, id: "closed", fail: "PRs.Update", err: errDB, status: domain.PRStatusClosed, reviewers: []string{"u2", "u3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			fixturesFor(store).PR("draft", "u1").Status(domain.PRStatusDraft).Create(t)
+			fixturesFor(store).PR("open", "u1").Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("merged", "u1").Status(domain.PRStatusMerged).Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("closed", "u1").Status(domain.PRStatusClosed).Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("closed-draft", "u1").Status(domain.PRStatusClosed).Create(t)
+			fixturesFor(store).PR("solo-draft", "u8").Status(domain.PRStatusDraft).Create(t)
+			fixturesFor(store).PR("orphan-draft", "u7").Status(domain.PRStatusDraft).Create(t)
+			if _, err := store.Users().Delete(context.Background(), "u7"); err != nil {
+				t.Fatalf("delete u7: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+			svc := newPRService(store, newScriptedRand(tt.rand...))
+
+			pr, err := tt.op(svc, context.Background(), tt.id)
+			requireErr(t, err, tt.code, tt.err)
+
+			store.Fail(tt.fail, nil)
+			if tt.status != "" {
+				requireStored(t, store, tt.id, tt.status, tt.reviewers)
+			}
+			if err != nil {
+				return
+			}
+			if pr.Status != tt.status || !slices.Equal(pr.Reviewers, tt.reviewers) {
+				t.Fatalf("returned status %s, reviewers %v; want %s, %v", pr.Status, pr.Reviewers, tt.status, tt.reviewers)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_ReassignReviewer(t *testing.T) {
+	tests := []struct {
+		name       string
+		prID       string
+		oldID      string
+		inactive   []string
+		rand       []int
+		fail       string
+		code       domain.Code
+		err        error
+		newID      string
+		reviewers  []string
+		wantStored []string
+	}{
+		{name: "empty pr id", prID: "", oldID: "u2", err: errBadInput},
+		{name: "empty reviewer id", prID: "open", oldID: "", err: errBadInput},
+		{name: "unknown", prID: "missing", oldID: "u2", code: domain.ErrorCodeNotFound},
+		{name: "merged", prID: "merged", oldID: "u2", code: domain.ErrorCodePRMerged},
+		{name: "draft", prID: "draft", oldID: "u2", code: domain.ErrorCodePRNotOpen},
+		{name: "closed", prID: "closed", oldID: "u2", code: domain.ErrorCodePRNotOpen},
+		{name: "not assigned", prID: "open", oldID: "u4", code: domain.ErrorCodeNotAssigned},
+		{name: "author as reviewer", prID: "open", oldID: "u1", code: domain.ErrorCodeNotAssigned},
+		{name: "no candidates", prID: "open", oldID: "u2", inactive: []string{"u4", "u5"}, code: domain.ErrorCodeNoCandidate},
+		{name: "author deleted", prID: "orphan", oldID: "u6", code: domain.ErrorCodeNotFound},
+		{name: "first candidate", prID: "open", oldID: "u2", newID: "u4", reviewers: []string{"u4", "u3"}, wantStored: []string{"u3", "u4"}},
+		{name: "random candidate", prID: "open", oldID: "u3", rand: []int{1}, newID: "u5", reviewers: []string{"u2", "u5"}, wantStored: []string{"u2", "u5"}},
+		{name: "inactive reviewer replaced", prID: "open", oldID: "u2", inactive: []string{"u2", "u4"}, newID: "u5", reviewers: []string{"u5", "u3"}, wantStored: []string{"u3", "u5"}},
+		{name: "get fails", prID: "open", oldID: "u2", fail: "PRs.Get", err: errDB},
+		{name: "list reviewers fails", prID: "open", oldID: "u2", fail: "PRs.ListReviewers", err: errDB},
+		{name: "get author fails", prID: "open", oldID: "u2", fail: "Users.GetUserByID", err: errDB},
+		{name: "list candidates fails", prID: "open", oldID: "u2", fail: "Users.ListReviewCandidates", err: errDB},
+		{name: "set reviewers fails", prID: "open", oldID: "u2", fail: "PRs.SetReviewers", err: errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			fixturesFor(store).PR("open", "u1").Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("merged", "u1").Status(domain.PRStatusMerged).Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("draft", "u1").Status(domain.PRStatusDraft).Create(t)
+			fixturesFor(store).PR("closed", "u1").Status(domain.PRStatusClosed).Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("orphan", "u7").Reviewers("u6").Create(t)
+			if _, err := store.Users().Delete(ctx, "u7"); err != nil {
+				t.Fatalf("delete u7: %v", err)
+			}
+			for _, id := range tt.inactive {
+				if _, err := store.Users().SetIsActive(ctx, id, false); err != nil {
+					t.Fatalf("deactivate %s: %v", id, err)
+				}
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+			svc := newPRService(store, newScriptedRand(tt.rand...))
+
+			pr, newID, err := svc.ReassignReviewer(ctx, tt.prID, tt.oldID)
+			requireErr(t, err, tt.code, tt.err)
+
+			store.Fail(tt.fail, nil)
+			if err != nil {
+				if tt.prID == "open" {
+					requireStored(t, store, "open", domain.PRStatusOpen, []string{"u2", "u3"})
+				}
+				return
+			}
+
+			if newID != tt.newID || !slices.Equal(pr.Reviewers, tt.reviewers) {
+				t.Fatalf("got %s and reviewers %v, want %s and %v", newID, pr.Reviewers, tt.newID, tt.reviewers)
+			}
+			requireStored(t, store, tt.prID, domain.PRStatusOpen, tt.wantStored)
+		})
+	}
+}
+
+func TestPullRequestService_Get(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		includeArchived bool
+		fail            string
+		code            domain.Code
+		err             error
+		wantArchived    bool
+		wantArchiveRead bool
+	}{
+		{name: "empty id", id: "", err: errBadInput},
+		{name: "live", id: "live"},
+		{name: "live including archived", id: "live", includeArchived: true},
+		{name: "unknown", id: "missing", code: domain.ErrorCodeNotFound},
+		{name: "unknown including archived", id: "missing", includeArchived: true, code: domain.ErrorCodeNotFound, wantArchiveRead: true},
+		{name: "archived", id: "old", code: domain.ErrorCodeNotFound},
+		{name: "archived including archived", id: "old", includeArchived: true, wantArchived: true, wantArchiveRead: true},
+		{name: "get fails", id: "live", includeArchived: true, fail: "PRs.Get", err: errDB},
+		{name: "list reviewers fails", id: "live", fail: "PRs.ListReviewers", err: errDB},
+		{name: "get archived fails", id: "old", includeArchived: true, fail: "PRs.GetArchived", err: errDB, wantArchiveRead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			fixturesFor(store).PR("old", "u1").Status(domain.PRStatusMerged).Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("live", "u1").Reviewers("u2", "u3").Create(t)
+			if _, err := store.PRs().Archive(context.Background(), testEpoch.Add(time.Hour)); err != nil {
+				t.Fatalf("archive: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+			svc := newPRService(store, newScriptedRand())
+
+			pr, err := svc.Get(context.Background(), tt.id, tt.includeArchived)
+			requireErr(t, err, tt.code, tt.err)
+			if read := store.Calls("PRs.GetArchived") > 0; read != tt.wantArchiveRead {
+				t.Fatalf("archive read = %v, want %v", read, tt.wantArchiveRead)
+			}
+			if err != nil {
+				return
+			}
+
+			if pr.ID != tt.id || pr.ArchivedAt.IsZero() == tt.wantArchived || !slices.Equal(pr.Reviewers, []string{"u2", "u3"}) {
+				t.Fatalf("unexpected pull request: %+v", pr)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_ArchiveMerged(t *testing.T) {
+	cutoff := testEpoch.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		fail string
+		err  error
+		want int
+		live []string
+	}{
+		{name: "archives merged before cutoff", want: 1, live: []string{"recent", "open"}},
+		{name: "archive fails", fail: "PRs.Archive", err: errDB, live: []string{"old", "recent", "open"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			fixturesFor(store).PR("old", "u1").Status(domain.PRStatusMerged).Reviewers("u2").Create(t)
+			store.SetNow(cutoff)
+			fixturesFor(store).PR("recent", "u1").Status(domain.PRStatusMerged).Reviewers("u2").Create(t)
+			fixturesFor(store).PR("open", "u1").Reviewers("u2").Create(t)
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+			svc := newPRService(store, newScriptedRand())
+
+			n, err := svc.ArchiveMerged(ctx, cutoff)
+			requireErr(t, err, "", tt.err)
+			if n != tt.want {
+				t.Fatalf("archived %d pull requests, want %d", n, tt.want)
+			}
+
+			for _, id := range []string{"old", "recent", "open"} {
+				_, err := store.PRs().Get(ctx, id)
+				if live := err == nil; live != slices.Contains(tt.live, id) {
+					t.Fatalf("%s: live = %v, want %v", id, live, !live)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package service
+
+import "math/rand/v2"
+
+// Rand abstracts the random choice of reviewers so that it can be made
+// deterministic in tests. Implementations must be safe for concurrent use.
+type Rand interface {
+	// IntN returns a number in [0, n).
+	IntN(n int) int
+}
+
+type systemRand struct{}
+
+func (systemRand) IntN(n int) int {
+	return rand.IntN(n)
+}
+
+// SystemRand returns a Rand backed by the global math/rand/v2 source.
+func SystemRand() Rand {
+	return systemRand{}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+type ReviewService interface {
+	ListOverdue(ctx context.Context, olderThan time.Duration, teamName string) ([]domain.ReviewAssignment, error)
+}
+
+var _ ReviewService = (*reviewService)(nil)
+
+type reviewService struct {
+	prs        domain.PRRepository
+	clock      Clock
+	defaultSLA time.Duration
+}
+
+// NewReviewService creates a ReviewService. defaultSLA is used when the caller
+// does not specify how old an assignment has to be to count as overdue.
+func NewReviewService(prs domain.PRRepository, clock Clock, defaultSLA time.Duration) ReviewService {
+	return &reviewService{
+		prs:        prs,
+		clock:      clock,
+		defaultSLA: defaultSLA,
+	}
+}
+
+func (s *reviewService) ListOverdue(ctx context.Context, olderThan time.Duration, teamName string) ([]domain.ReviewAssignment, error) {
+	if olderThan < 0 {
+		return nil, fmt.Errorf("list overdue reviews: negative duration")
+	}
+	if olderThan == 0 {
+		olderThan = s.defaultSLA
+	}
+
+	cutoff := s.clock.Now().UTC().Add(-olderThan)
+
+	reviews, err := s.prs.ListOverdueReviews(ctx, cutoff, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("list overdue reviews: %w", err)
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+type overdueRepoStub struct {
+	domain.PRRepository
+	gotBefore time.Time
+	gotTeam   string
+}
+
+func (m *overdueRepoStub) ListOverdueReviews(ctx context.Context, assignedBefore time.Time, teamName string) ([]domain.ReviewAssignment, error) {
+	m.gotBefore = assignedBefore
+	m.gotTeam = teamName
+	return []domain.ReviewAssignment{{PullRequestID: "pr1", ReviewerID: "u2"}}, nil
+}
+
+func TestReviewService_ListOverdue_Cutoff(t *testing.T) {
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	repo := &overdueRepoStub{}
+	svc := NewReviewService(repo, fixedClock{now: now}, 48*time.Hour)
+
+	got, err := svc.ListOverdue(context.Background(), 24*time.Hour, "backend")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 review, got %d", len(got))
+	}
+	if want := now.Add(-24 * time.Hour); !repo.gotBefore.Equal(want) {
+		t.Fatalf("expected cutoff %s, got %s", want, repo.gotBefore)
+	}
+	if repo.gotTeam != "backend" {
+		t.Fatalf("expected team backend, got %q", repo.gotTeam)
+	}
+}
+
+func TestReviewService_ListOverdue_DefaultSLA(t *testing.T) {
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	repo := &overdueRepoStub{}
+	svc := NewReviewService(repo, fixedClock{now: now}, 48*time.Hour)
+
+	if _, err := svc.ListOverdue(context.Background(), 0, ""); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if want := now.Add(-48 * time.Hour); !repo.gotBefore.Equal(want) {
+		t.Fatalf("expected cutoff %s, got %s", want, repo.gotBefore)
+	}
+}
+
+func TestReviewService_ListOverdue_Negative(t *testing.T) {
+	svc := NewReviewService(&overdueRepoStub{}, fixedClock{now: time.Now()}, time.Hour)
+
+	if _, err := svc.ListOverdue(context.Background(), -time.Hour, ""); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -2,24 +2,44 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/ChernykhITMO/Avito/internal/domain"
 )
 
+const (
+	defaultStatsRange = 30 * 24 * time.Hour
+	maxStatsPeriods   = 366
+)
+
 type StatsService interface {
-	GetStats(ctx context.Context) (domain.StatsResponse, error)
+	GetStats(ctx context.Context, filter domain.StatsFilter) (domain.StatsResponse, error)
+	ExportAssignments(ctx context.Context, filter domain.StatsFilter, fn func(domain.AssignmentRecord) error) error
+	GetFairness(ctx context.Context, filter domain.StatsFilter) ([]domain.TeamFairness, error)
 }
 type statsService struct {
-	repo domain.StatsRepository
+	repo  domain.StatsRepository
+	clock Clock
 }
 
-func NewStatsService(repo domain.StatsRepository) StatsService {
+func NewStatsService(repo domain.StatsRepository, clock Clock) StatsService {
 	return &statsService{
-		repo: repo,
+		repo:  repo,
+		clock: clock,
 	}
 }
 
-func (s *statsService) GetStats(ctx context.Context) (domain.StatsResponse, error) {
+// GetStats returns all-time totals together with time series for the
+// filter's range. Missing range bounds default to the last 30 days and
+// missing granularity to one day.
+func (s *statsService) GetStats(ctx context.Context, f domain.StatsFilter) (domain.StatsResponse, error) {
+	f, err := s.normalizeFilter(f)
+	if err != nil {
+		return domain.StatsResponse{}, err
+	}
+
 	pr, err := s.repo.GetPRStats(ctx)
 	if err != nil {
 		return domain.StatsResponse{}, err
@@ -30,8 +50,238 @@ func (s *statsService) GetStats(ctx context.Context) (domain.StatsResponse, erro
 		return domain.StatsResponse{}, err
 	}
 
-	return domain.StatsResponse{
+	timelines, err := s.repo.ListPRTimelines(ctx, f)
+	if err != nil {
+		return domain.StatsResponse{}, err
+	}
+
+	history, err := s.repo.ListAssignments(ctx, f)
+	if err != nil {
+		return domain.StatsResponse{}, err
+	}
+
+	loads, err := s.repo.ListOpenLoad(ctx, f.TeamName)
+	if err != nil {
+		return domain.StatsResponse{}, err
+	}
+
+	periods := periodStarts(f.From, f.To, f.Granularity)
+
+	resp := domain.StatsResponse{
 		PRStats:            pr,
 		AssignmentsPerUser: assignments,
-	}, nil
+		From:               f.From,
+		To:                 f.To,
+		Granularity:        f.Granularity,
+		Global:             buildTeamStats("", f, periods, timelines, history, loads),
+	}
+
+	for _, team := range teamNames(timelines, history, loads) {
+		resp.Teams = append(resp.Teams, buildTeamStats(team, f, periods,
+			filterByTeam(timelines, team, func(t domain.PRTimeline) string { return t.TeamName }),
+			filterByTeam(history, team, func(a domain.AssignmentRecord) string { return a.TeamName }),
+			filterByTeam(loads, team, func(l domain.ReviewerLoad) string { return l.TeamName }),
+		))
+	}
+
+	return resp, nil
+}
+
+// ExportAssignments streams the reviewer assignment history. Unlike GetStats
+// it does not apply a default range: zero bounds export everything.
+func (s *statsService) ExportAssignments(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return domain.NewError(domain.ErrorCodeBadRequest, "from must be before to")
+	}
+
+	return s.repo.EachAssignment(ctx, f, fn)
+}
+
+func (s *statsService) normalizeFilter(f domain.StatsFilter) (domain.StatsFilter, error) {
+	if f.Granularity == "" {
+		f.Granularity = domain.GranularityDay
+	}
+	if f.Granularity != domain.GranularityDay && f.Granularity != domain.GranularityWeek {
+		return f, domain.NewError(domain.ErrorCodeBadRequest, fmt.Sprintf("unknown granularity %q", f.Granularity))
+	}
+
+	if f.To.IsZero() {
+		f.To = s.clock.Now()
+	}
+	if f.From.IsZero() {
+		f.From = f.To.Add(-defaultStatsRange)
+	}
+	f.From, f.To = f.From.UTC(), f.To.UTC()
+
+	if !f.From.Before(f.To) {
+		return f, domain.NewError(domain.ErrorCodeBadRequest, "from must be before to")
+	}
+	if len(periodStarts(f.From, f.To, f.Granularity)) > maxStatsPeriods {
+		return f, domain.NewError(domain.ErrorCodeBadRequest, fmt.Sprintf("range exceeds %d periods", maxStatsPeriods))
+	}
+
+	return f, nil
+}
+
+func buildTeamStats(
+	team string,
+	f domain.StatsFilter,
+	periods []time.Time,
+	timelines []domain.PRTimeline,
+	history []domain.AssignmentRecord,
+	loads []domain.ReviewerLoad,
+) domain.TeamStats {
+	stats := domain.TeamStats{
+		TeamName:               team,
+		PRsPerPeriod:           make([]domain.PeriodPRStats, len(periods)),
+		AssignmentsPerPeriod:   []domain.PeriodAssignmentStat{},
+		ReassignmentsPerPeriod: make([]domain.PeriodReassignmentStat, len(periods)),
+		OpenLoad:               make([]domain.OpenLoadStat, 0, len(loads)),
+	}
+
+	index := make(map[time.Time]int, len(periods))
+	for i, p := range periods {
+		index[p] = i
+		stats.PRsPerPeriod[i].PeriodStart = p
+		stats.ReassignmentsPerPeriod[i].PeriodStart = p
+	}
+
+	var mergeTimes []time.Duration
+	for _, t := range timelines {
+		if inRange(t.CreatedAt, f) {
+			stats.PRsPerPeriod[index[periodStart(t.CreatedAt, f.Granularity)]].Opened++
+		}
+		if !t.MergedAt.IsZero() && inRange(t.MergedAt, f) {
+			stats.PRsPerPeriod[index[periodStart(t.MergedAt, f.Granularity)]].Merged++
+			mergeTimes = append(mergeTimes, t.MergedAt.Sub(t.CreatedAt))
+		}
+	}
+	stats.MedianTimeToMergeSeconds = medianSeconds(mergeTimes)
+
+	type periodUser struct {
+		period time.Time
+		userID string
+	}
+	perUser := make(map[periodUser]int)
+
+	for _, a := range history {
+		if !inRange(a.AssignedAt, f) {
+			continue
+		}
+		p := periodStart(a.AssignedAt, f.Granularity)
+		perUser[periodUser{period: p, userID: a.ReviewerID}]++
+
+		r := &stats.ReassignmentsPerPeriod[index[p]]
+		r.Assignments++
+		if !a.UnassignedAt.IsZero() {
+			r.Reassignments++
+		}
+	}
+
+	for key, count := range perUser {
+		stats.AssignmentsPerPeriod = append(stats.AssignmentsPerPeriod, domain.PeriodAssignmentStat{
+			PeriodStart: key.period,
+			UserID:      key.userID,
+			Count:       count,
+		})
+	}
+	sort.Slice(stats.AssignmentsPerPeriod, func(i, j int) bool {
+		a, b := stats.AssignmentsPerPeriod[i], stats.AssignmentsPerPeriod[j]
+		if !a.PeriodStart.Equal(b.PeriodStart) {
+			return a.PeriodStart.Before(b.PeriodStart)
+		}
+		return a.UserID < b.UserID
+	})
+
+	for i := range stats.ReassignmentsPerPeriod {
+		r := &stats.ReassignmentsPerPeriod[i]
+		if r.Assignments > 0 {
+			r.Rate = float64(r.Reassignments) / float64(r.Assignments)
+		}
+	}
+
+	for _, l := range loads {
+		stats.OpenLoad = append(stats.OpenLoad, domain.OpenLoadStat{UserID: l.UserID, Open: l.Open})
+	}
+
+	return stats
+}
+
+// periodStart truncates t to the beginning of its UTC day or ISO week (Monday).
+func periodStart(t time.Time, g domain.Granularity) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	if g == domain.GranularityWeek {
+		sinceMonday := (int(day.Weekday()) + 6) % 7
+		day = day.AddDate(0, 0, -sinceMonday)
+	}
+
+	return day
+}
+
+func periodStarts(from, to time.Time, g domain.Granularity) []time.Time {
+	step := 1
+	if g == domain.GranularityWeek {
+		step = 7
+	}
+
+	var periods []time.Time
+	for p := periodStart(from, g); p.Before(to); p = p.AddDate(0, 0, step) {
+		periods = append(periods, p)
+		if len(periods) > maxStatsPeriods {
+			break
+		}
+	}
+	return periods
+}
+
+func inRange(t time.Time, f domain.StatsFilter) bool {
+	return !t.Before(f.From) && t.Before(f.To)
+}
+
+func medianSeconds(durations []time.Duration) *float64 {
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	median := sorted[mid].Seconds()
+	if len(sorted)%2 == 0 {
+		median = (sorted[mid-1].Seconds() + sorted[mid].Seconds()) / 2
+	}
+	return &median
+}
+
+func teamNames(timelines []domain.PRTimeline, history []domain.AssignmentRecord, loads []domain.ReviewerLoad) []string {
+	seen := make(map[string]struct{})
+	for _, t := range timelines {
+		seen[t.TeamName] = struct{}{}
+	}
+	for _, a := range history {
+		seen[a.TeamName] = struct{}{}
+	}
+	for _, l := range loads {
+		seen[l.TeamName] = struct{}{}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func filterByTeam[T any](items []T, team string, teamOf func(T) string) []T {
+	var res []T
+	for _, item := range items {
+		if teamOf(item) == team {
+			res = append(res, item)
+		}
+	}
+	return res
 }
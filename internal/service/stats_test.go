@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ChernykhITMO/Avito/internal/domain"
 )
@@ -10,6 +11,11 @@ import (
 type statsRepoMock struct {
 	prStatsFn func(ctx context.Context) (domain.PRStats, error)
 	assignFn  func(ctx context.Context) ([]domain.UserAssignmentStat, error)
+
+	timelines []domain.PRTimeline
+	history   []domain.AssignmentRecord
+	loads     []domain.ReviewerLoad
+	events    []domain.ActivityEvent
 }
 
 func (m *statsRepoMock) GetPRStats(ctx context.Context) (domain.PRStats, error) {
@@ -20,6 +26,31 @@ func (m *statsRepoMock) GetAssignmentsStats(ctx context.Context) ([]domain.UserA
 	return m.assignFn(ctx)
 }
 
+func (m *statsRepoMock) ListPRTimelines(ctx context.Context, f domain.StatsFilter) ([]domain.PRTimeline, error) {
+	return m.timelines, nil
+}
+
+func (m *statsRepoMock) ListAssignments(ctx context.Context, f domain.StatsFilter) ([]domain.AssignmentRecord, error) {
+	return m.history, nil
+}
+
+func (m *statsRepoMock) EachAssignment(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	for _, a := range m.history {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *statsRepoMock) ListActivityEvents(ctx context.Context, teamName string, before time.Time) ([]domain.ActivityEvent, error) {
+	return m.events, nil
+}
+
+func (m *statsRepoMock) ListOpenLoad(ctx context.Context, teamName string) ([]domain.ReviewerLoad, error) {
+	return m.loads, nil
+}
+
 func TestStatsService_GetStats_OK(t *testing.T) {
 	repo := &statsRepoMock{
 		prStatsFn: func(ctx context.Context) (domain.PRStats, error) {
@@ -32,9 +63,9 @@ func TestStatsService_GetStats_OK(t *testing.T) {
 		},
 	}
 
-	svc := NewStatsService(repo)
+	svc := NewStatsService(repo, fixedClock{now: time.Now()})
 
-	got, err := svc.GetStats(context.Background())
+	got, err := svc.GetStats(context.Background(), domain.StatsFilter{})
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -45,3 +76,125 @@ func TestStatsService_GetStats_OK(t *testing.T) {
 		t.Fatalf("wrong assignments: %+v", got.AssignmentsPerUser)
 	}
 }
+
+func TestStatsService_GetStats_TimeSeries(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2025, 10, d, h, 0, 0, 0, time.UTC) }
+
+	repo := &statsRepoMock{
+		prStatsFn: func(ctx context.Context) (domain.PRStats, error) { return domain.PRStats{}, nil },
+		assignFn:  func(ctx context.Context) ([]domain.UserAssignmentStat, error) { return nil, nil },
+		timelines: []domain.PRTimeline{
+			{ID: "pr1", TeamName: "backend", CreatedAt: day(20, 10), MergedAt: day(21, 10)},
+			{ID: "pr2", TeamName: "backend", CreatedAt: day(20, 12), MergedAt: day(20, 14)},
+			{ID: "pr3", TeamName: "payments", CreatedAt: day(21, 9)},
+		},
+		history: []domain.AssignmentRecord{
+			{PullRequestID: "pr1", ReviewerID: "u2", TeamName: "backend", AssignedAt: day(20, 10), UnassignedAt: day(20, 11)},
+			{PullRequestID: "pr1", ReviewerID: "u3", TeamName: "backend", AssignedAt: day(20, 11)},
+			{PullRequestID: "pr3", ReviewerID: "u7", TeamName: "payments", AssignedAt: day(21, 9)},
+		},
+		loads: []domain.ReviewerLoad{{UserID: "u7", TeamName: "payments", Open: 1}},
+	}
+
+	svc := NewStatsService(repo, fixedClock{now: day(25, 0)})
+
+	got, err := svc.GetStats(context.Background(), domain.StatsFilter{
+		From:        day(20, 0),
+		To:          day(22, 0),
+		Granularity: domain.GranularityDay,
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	prs := got.Global.PRsPerPeriod
+	if len(prs) != 2 {
+		t.Fatalf("expected 2 periods, got %d", len(prs))
+	}
+	if prs[0].Opened != 2 || prs[0].Merged != 1 || prs[1].Opened != 1 || prs[1].Merged != 1 {
+		t.Fatalf("wrong pr series: %+v", prs)
+	}
+
+	// merge times are 2h and 24h
+	if m := got.Global.MedianTimeToMergeSeconds; m == nil || *m != 13*3600 {
+		t.Fatalf("wrong median: %v", m)
+	}
+
+	r := got.Global.ReassignmentsPerPeriod[0]
+	if r.Assignments != 2 || r.Reassignments != 1 || r.Rate != 0.5 {
+		t.Fatalf("wrong reassignment stats: %+v", r)
+	}
+
+	if len(got.Teams) != 2 || got.Teams[0].TeamName != "backend" || got.Teams[1].TeamName != "payments" {
+		t.Fatalf("wrong teams: %+v", got.Teams)
+	}
+	if got.Teams[1].MedianTimeToMergeSeconds != nil {
+		t.Fatalf("payments has no merges, got median %v", *got.Teams[1].MedianTimeToMergeSeconds)
+	}
+	if len(got.Teams[1].OpenLoad) != 1 || got.Teams[1].OpenLoad[0].Open != 1 {
+		t.Fatalf("wrong payments load: %+v", got.Teams[1].OpenLoad)
+	}
+	if len(got.Teams[0].AssignmentsPerPeriod) != 2 {
+		t.Fatalf("wrong backend assignments: %+v", got.Teams[0].AssignmentsPerPeriod)
+	}
+}
+
+func TestStatsService_GetStats_WeekGranularity(t *testing.T) {
+	repo := &statsRepoMock{
+		prStatsFn: func(ctx context.Context) (domain.PRStats, error) { return domain.PRStats{}, nil },
+		assignFn:  func(ctx context.Context) ([]domain.UserAssignmentStat, error) { return nil, nil },
+	}
+	svc := NewStatsService(repo, fixedClock{now: time.Now()})
+
+	// 2025-10-22 is a Wednesday, so the first week starts on Monday 2025-10-20.
+	got, err := svc.GetStats(context.Background(), domain.StatsFilter{
+		From:        time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		Granularity: domain.GranularityWeek,
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	prs := got.Global.PRsPerPeriod
+	if len(prs) != 2 || !prs[0].PeriodStart.Equal(time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("wrong weekly periods: %+v", prs)
+	}
+}
+
+func TestStatsService_GetStats_BadFilter(t *testing.T) {
+	svc := NewStatsService(&statsRepoMock{}, fixedClock{now: time.Now()})
+
+	_, err := svc.GetStats(context.Background(), domain.StatsFilter{Granularity: "month"})
+	requireCode(t, err, domain.ErrorCodeBadRequest)
+
+	now := time.Now()
+	_, err = svc.GetStats(context.Background(), domain.StatsFilter{From: now, To: now.Add(-time.Hour)})
+	requireCode(t, err, domain.ErrorCodeBadRequest)
+
+	_, err = svc.GetStats(context.Background(), domain.StatsFilter{From: now.AddDate(-2, 0, 0), To: now})
+	requireCode(t, err, domain.ErrorCodeBadRequest)
+
+	err = svc.ExportAssignments(context.Background(), domain.StatsFilter{From: now, To: now}, func(domain.AssignmentRecord) error { return nil })
+	requireCode(t, err, domain.ErrorCodeBadRequest)
+}
+
+func TestStatsService_ExportAssignments(t *testing.T) {
+	repo := &statsRepoMock{history: []domain.AssignmentRecord{
+		{PullRequestID: "pr1", ReviewerID: "u2"},
+		{PullRequestID: "pr1", ReviewerID: "u3"},
+	}}
+	svc := NewStatsService(repo, fixedClock{now: time.Now()})
+
+	var got []string
+	err := svc.ExportAssignments(context.Background(), domain.StatsFilter{}, func(a domain.AssignmentRecord) error {
+		got = append(got, a.ReviewerID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[0] != "u2" || got[1] != "u3" {
+		t.Fatalf("wrong export: %v", got)
+	}
+}
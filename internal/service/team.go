@@ -10,6 +10,13 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, name string, members []domain.User) (*domain.Team, error)
 	GetTeam(ctx context.Context, name string) (*domain.Team, error)
+	// ImportTeams brings teams and users in line with the snapshot and
+	// returns what changed. With dryRun nothing is written.
+	ImportTeams(ctx context.Context, snapshot domain.OrgSnapshot, dryRun bool) (domain.OrgDiff, error)
+	ExportTeams(ctx context.Context) (domain.OrgSnapshot, error)
+	// DeleteTeam deletes the team together with its members and returns
+	// the deleted members.
+	DeleteTeam(ctx context.Context, name string) (*domain.Team, error)
 }
 
 var _ TeamService = (*teamService)(nil)
@@ -66,3 +73,16 @@ func (s *teamService) GetTeam(ctx context.Context, name string) (*domain.Team, e
 
 	return team, nil
 }
+
+func (s *teamService) DeleteTeam(ctx context.Context, name string) (*domain.Team, error) {
+	if name == "" {
+		return nil, fmt.Errorf("delete team: empty team name")
+	}
+
+	team, err := s.teams.Delete(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("delete team: %w", err)
+	}
+
+	return team, nil
+}
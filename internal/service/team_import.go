@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+func (s *teamService) ImportTeams(ctx context.Context, snapshot domain.OrgSnapshot, dryRun bool) (domain.OrgDiff, error) {
+	if err := snapshot.Validate(); err != nil {
+		return domain.OrgDiff{}, domain.NewError(domain.ErrorCodeBadRequest, err.Error())
+	}
+
+	current, err := s.teams.List(ctx)
+	if err != nil {
+		return domain.OrgDiff{}, fmt.Errorf("import teams: %w", err)
+	}
+
+	diff := diffOrg(current, snapshot)
+	if dryRun || diff.Empty() {
+		return diff, nil
+	}
+
+	if err := s.teams.ApplyDiff(ctx, diff); err != nil {
+		return domain.OrgDiff{}, fmt.Errorf("import teams: %w", err)
+	}
+
+	return diff, nil
+}
+
+func (s *teamService) ExportTeams(ctx context.Context) (domain.OrgSnapshot, error) {
+	teams, err := s.teams.List(ctx)
+	if err != nil {
+		return domain.OrgSnapshot{}, fmt.Errorf("export teams: %w", err)
+	}
+
+	return domain.OrgSnapshot{Teams: teams}, nil
+}
+
+// diffOrg compares the current teams with the snapshot. Created and updated
+// entries follow snapshot order; deactivated users are ordered by id.
+// Teams missing from the snapshot are kept, but their active members are
+// deactivated like any other user the snapshot does not mention.
+func diffOrg(current []domain.Team, snapshot domain.OrgSnapshot) domain.OrgDiff {
+	var diff domain.OrgDiff
+
+	existingTeams := make(map[string]struct{}, len(current))
+	existingUsers := make(map[string]domain.User)
+	for _, team := range current {
+		existingTeams[team.Name] = struct{}{}
+		for _, u := range team.Members {
+			u.TeamName = team.Name
+			existingUsers[u.ID] = u
+		}
+	}
+
+	listed := make(map[string]struct{})
+	for _, team := range snapshot.Teams {
+		if _, ok := existingTeams[team.Name]; !ok {
+			diff.CreatedTeams = append(diff.CreatedTeams, team.Name)
+		}
+
+		for _, u := range team.Members {
+			u.TeamName = team.Name
+			listed[u.ID] = struct{}{}
+
+			old, ok := existingUsers[u.ID]
+			if !ok {
+				diff.CreatedUsers = append(diff.CreatedUsers, u)
+				continue
+			}
+			if old.TeamName != u.TeamName {
+				diff.MovedUsers = append(diff.MovedUsers, domain.UserMove{User: u, FromTeam: old.TeamName})
+			}
+			if old.Name != u.Name || old.IsActive != u.IsActive {
+				diff.UpdatedUsers = append(diff.UpdatedUsers, u)
+			}
+		}
+	}
+
+	for id, u := range existingUsers {
+		if _, ok := listed[id]; ok || !u.IsActive {
+			continue
+		}
+		u.IsActive = false
+		diff.DeactivatedUsers = append(diff.DeactivatedUsers, u)
+	}
+	sort.Slice(diff.DeactivatedUsers, func(i, j int) bool {
+		return diff.DeactivatedUsers[i].ID < diff.DeactivatedUsers[j].ID
+	})
+
+	return diff
+}
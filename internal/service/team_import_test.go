@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+)
+
+func currentOrg() []domain.Team {
+	return []domain.Team{
+		{Name: "backend", Members: []domain.User{
+			{ID: "u1", Name: "Alice", TeamName: "backend", IsActive: true},
+			{ID: "u2", Name: "Bob", TeamName: "backend", IsActive: true},
+			{ID: "u3", Name: "Carol", TeamName: "backend", IsActive: true},
+		}},
+		{Name: "legacy", Members: []domain.User{
+			{ID: "u4", Name: "Dave", TeamName: "legacy", IsActive: true},
+			{ID: "u5", Name: "Eve", TeamName: "legacy", IsActive: false},
+		}},
+	}
+}
+
+func TestDiffOrg(t *testing.T) {
+	snapshot := domain.OrgSnapshot{Teams: []domain.Team{
+		{Name: "backend", Members: []domain.User{
+			{ID: "u1", Name: "Alice", IsActive: true},
+			{ID: "u2", Name: "Robert", IsActive: true},
+		}},
+		{Name: "payments", Members: []domain.User{
+			{ID: "u3", Name: "Carol", IsActive: false},
+			{ID: "u6", Name: "Frank", IsActive: true},
+		}},
+	}}
+
+	diff := diffOrg(currentOrg(), snapshot)
+
+	want := domain.OrgDiff{
+		CreatedTeams: []string{"payments"},
+		CreatedUsers: []domain.User{{ID: "u6", Name: "Frank", TeamName: "payments", IsActive: true}},
+		UpdatedUsers: []domain.User{
+			{ID: "u2", Name: "Robert", TeamName: "backend", IsActive: true},
+			{ID: "u3", Name: "Carol", TeamName: "payments", IsActive: false},
+		},
+		MovedUsers: []domain.UserMove{
+			{User: domain.User{ID: "u3", Name: "Carol", TeamName: "payments", IsActive: false}, FromTeam: "backend"},
+		},
+		// u5 is already inactive and stays untouched.
+		DeactivatedUsers: []domain.User{{ID: "u4", Name: "Dave", TeamName: "legacy", IsActive: false}},
+	}
+
+	if !reflect.DeepEqual(diff, want) {
+		t.Fatalf("unexpected diff:\n got %+v\nwant %+v", diff, want)
+	}
+	if got := len(diff.Upserts()); got != 4 {
+		t.Fatalf("expected 4 upserts (u6, u2, u3, u4), got %d", got)
+	}
+}
+
+func TestDiffOrg_SameStateIsEmpty(t *testing.T) {
+	diff := diffOrg(currentOrg(), domain.OrgSnapshot{Teams: currentOrg()})
+	if !diff.Empty() {
+		t.Fatalf("expected empty diff, got %+v", diff)
+	}
+}
+
+// newOrgStore returns a store holding currentOrg.
+func newOrgStore(t *testing.T) *fakes.Store {
+	t.Helper()
+
+	store := fakes.New()
+	for _, team := range currentOrg() {
+		fixturesFor(store).Team(team.Name).With(team.Members...).Create(t)
+	}
+	return store
+}
+
+func TestTeamService_ImportTeams(t *testing.T) {
+	snapshot := domain.OrgSnapshot{Teams: []domain.Team{
+		{Name: "payments", Members: []domain.User{{ID: "u9", Name: "Ivan", IsActive: true}}},
+	}}
+	invalid := domain.OrgSnapshot{Teams: []domain.Team{
+		{Name: "a", Members: []domain.User{{ID: "u1", Name: "Alice"}}},
+		{Name: "b", Members: []domain.User{{ID: "u1", Name: "Alice"}}},
+	}}
+
+	tests := []struct {
+		name      string
+		snapshot  domain.OrgSnapshot
+		dryRun    bool
+		fail      string
+		code      domain.Code
+		err       error
+		wantRead  bool
+		wantApply bool
+		wantEmpty bool
+	}{
+		{name: "apply", snapshot: snapshot, wantRead: true, wantApply: true},
+		{name: "dry run", snapshot: snapshot, dryRun: true, wantRead: true},
+		{name: "no changes", snapshot: domain.OrgSnapshot{Teams: currentOrg()}, wantRead: true, wantEmpty: true},
+		// A user listed in two teams is rejected before reading state.
+		{name: "invalid snapshot", snapshot: invalid, code: domain.ErrorCodeBadRequest},
+		{name: "list fails", snapshot: snapshot, fail: "Teams.List", err: errDB, wantRead: true},
+		{name: "apply fails", snapshot: snapshot, fail: "Teams.ApplyDiff", err: errDB, wantRead: true, wantApply: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newOrgStore(t)
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+
+			diff, err := NewTeamService(store.Teams(), store.Users()).ImportTeams(ctx, tt.snapshot, tt.dryRun)
+			requireErr(t, err, tt.code, tt.err)
+			if read := store.Calls("Teams.List") > 0; read != tt.wantRead {
+				t.Fatalf("expected read=%v, got %v", tt.wantRead, read)
+			}
+			if applied := store.Calls("Teams.ApplyDiff") > 0; applied != tt.wantApply {
+				t.Fatalf("expected applied=%v, got %v", tt.wantApply, applied)
+			}
+			if err != nil {
+				return
+			}
+
+			if tt.wantEmpty {
+				if !diff.Empty() {
+					t.Fatalf("expected empty diff, got %+v", diff)
+				}
+				return
+			}
+			if len(diff.CreatedTeams) != 1 || len(diff.DeactivatedUsers) != 4 {
+				t.Fatalf("unexpected diff: %+v", diff)
+			}
+
+			_, err = store.Teams().GetByName(ctx, "payments")
+			if tt.dryRun {
+				requireCode(t, err, domain.ErrorCodeNotFound)
+			} else if err != nil {
+				t.Fatalf("imported team: %v", err)
+			}
+		})
+	}
+}
+
+func TestTeamService_ExportTeams(t *testing.T) {
+	tests := []struct {
+		name string
+		fail string
+		err  error
+	}{
+		{name: "ok"},
+		{name: "list fails", fail: "Teams.List", err: errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOrgStore(t)
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+
+			snapshot, err := NewTeamService(store.Teams(), store.Users()).ExportTeams(context.Background())
+			requireErr(t, err, "", tt.err)
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(snapshot.Teams, currentOrg()) {
+				t.Fatalf("expected currentOrg, got %+v", snapshot.Teams)
+			}
+		})
+	}
+}
@@ -2,116 +2,159 @@ package service
 
 import (
 	"context"
-	"errors"
 	"testing"
 
 	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
 )
 
-type teamRepoMock struct {
-	createFn    func(ctx context.Context, team *domain.Team) error
-	getByNameFn func(ctx context.Context, name string) (*domain.Team, error)
+func newTeamService(store *fakes.Store) TeamService {
+	return NewTeamService(store.Teams(), store.Users())
 }
 
-func (m *teamRepoMock) Create(ctx context.Context, team *domain.Team) error {
-	return m.createFn(ctx, team)
-}
-
-func (m *teamRepoMock) GetByName(ctx context.Context, name string) (*domain.Team, error) {
-	return m.getByNameFn(ctx, name)
-}
-
-type userRepoMock struct {
-	saveAllFn func(ctx context.Context, users []domain.User) error
-}
-
-func (m *userRepoMock) SaveAll(ctx context.Context, users []domain.User) error {
-	return m.saveAllFn(ctx, users)
-}
-
-func (m *userRepoMock) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
-	panic("not used")
-}
-
-func (m *userRepoMock) SetIsActive(ctx context.Context, id string, active bool) (*domain.User, error) {
-	panic("not used")
-}
+func TestTeamService_CreateTeam(t *testing.T) {
+	members := func() []domain.User {
+		return []domain.User{
+			{ID: "n1", Name: "A", IsActive: true},
+			{ID: "n2", Name: "B", IsActive: true},
+		}
+	}
 
-func (m *userRepoMock) ListReviewCandidates(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
-	panic("not used")
-}
+	tests := []struct {
+		name     string
+		teamName string
+		members  []domain.User
+		fail     string
+		code     domain.Code
+		err      error
+	}{
+		{name: "ok", teamName: "team1", members: members()},
+		{name: "empty name", teamName: "", members: members(), err: errBadInput},
+		{name: "no members", teamName: "team1", err: errBadInput},
+		{name: "existing team", teamName: "backend", members: members(), code: domain.ErrorCodeTeamExists},
+		{name: "create fails", teamName: "team1", members: members(), fail: "Teams.Create", err: errDB},
+		{name: "save members fails", teamName: "team1", members: members(), fail: "Users.SaveAll", err: errDB},
+	}
 
-func TestTeamService_CreateTeam_OK(t *testing.T) {
-	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			creates := store.Calls("Teams.Create")
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
 
-	teams := &teamRepoMock{
-		createFn: func(ctx context.Context, team *domain.Team) error {
-			if team.Name != "team1" {
-				t.Fatalf("expected team name team1, got %s", team.Name)
+			team, err := newTeamService(store).CreateTeam(ctx, tt.teamName, tt.members)
+			requireErr(t, err, tt.code, tt.err)
+			if tt.err == errBadInput && store.Calls("Teams.Create") != creates {
+				t.Fatal("invalid team reached the repository")
 			}
-			if len(team.Members) != 2 {
-				t.Fatalf("expected 2 members, got %d", len(team.Members))
+			if err != nil {
+				return
 			}
-			return nil
-		},
-	}
 
-	users := &userRepoMock{
-		saveAllFn: func(ctx context.Context, members []domain.User) error {
-			for _, u := range members {
-				if u.TeamName != "team1" {
-					t.Fatalf("expected TeamName=team1, got %s", u.TeamName)
+			if team.Name != tt.teamName || len(team.Members) != len(tt.members) {
+				t.Fatalf("unexpected team: %+v", team)
+			}
+			stored, err := store.Teams().GetByName(ctx, tt.teamName)
+			if err != nil {
+				t.Fatalf("get team: %v", err)
+			}
+			if len(stored.Members) != len(tt.members) {
+				t.Fatalf("expected %d stored members, got %+v", len(tt.members), stored.Members)
+			}
+			for _, u := range stored.Members {
+				if u.TeamName != tt.teamName {
+					t.Fatalf("expected TeamName=%s, got %s", tt.teamName, u.TeamName)
 				}
 			}
-			return nil
-		},
+		})
 	}
+}
 
-	svc := NewTeamService(teams, users)
-
-	members := []domain.User{
-		{ID: "1", Name: "A", IsActive: true},
-		{ID: "2", Name: "B", IsActive: true},
+func TestTeamService_GetTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		teamName string
+		fail     string
+		code     domain.Code
+		err      error
+		members  int
+	}{
+		{name: "ok", teamName: "backend", members: 5},
+		{name: "empty name", teamName: "", err: errBadInput},
+		{name: "unknown", teamName: "missing", code: domain.ErrorCodeNotFound},
+		{name: "deleted", teamName: "pair", code: domain.ErrorCodeNotFound},
+		{name: "repository fails", teamName: "backend", fail: "Teams.GetByName", err: errDB},
 	}
 
-	team, err := svc.CreateTeam(ctx, "team1", members)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	if team.Name != "team1" {
-		t.Fatalf("wrong team name: %s", team.Name)
-	}
-}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			if _, err := store.Teams().Delete(ctx, "pair"); err != nil {
+				t.Fatalf("delete pair: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
 
-func TestTeamService_CreateTeam_EmptyName(t *testing.T) {
-	svc := NewTeamService(&teamRepoMock{
-		createFn:    func(ctx context.Context, team *domain.Team) error { return nil },
-		getByNameFn: func(ctx context.Context, name string) (*domain.Team, error) { return nil, nil },
-	}, &userRepoMock{
-		saveAllFn: func(ctx context.Context, users []domain.User) error { return nil },
-	})
-
-	_, err := svc.CreateTeam(context.Background(), "", []domain.User{{ID: "1"}})
-	if err == nil {
-		t.Fatal("expected error, got nil")
+			team, err := newTeamService(store).GetTeam(ctx, tt.teamName)
+			requireErr(t, err, tt.code, tt.err)
+			if err != nil {
+				return
+			}
+
+			if team.Name != tt.teamName || len(team.Members) != tt.members {
+				t.Fatalf("unexpected team: %+v", team)
+			}
+		})
 	}
 }
 
-func TestTeamService_CreateTeam_TeamRepoError(t *testing.T) {
-	wantErr := errors.New("db fail")
-
-	teams := &teamRepoMock{
-		createFn:    func(ctx context.Context, team *domain.Team) error { return wantErr },
-		getByNameFn: func(ctx context.Context, name string) (*domain.Team, error) { return nil, nil },
-	}
-	users := &userRepoMock{
-		saveAllFn: func(ctx context.Context, users []domain.User) error { return nil },
+func TestTeamService_DeleteTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		teamName string
+		fail     string
+		code     domain.Code
+		err      error
+	}{
+		{name: "ok", teamName: "pair"},
+		{name: "empty name", teamName: "", err: errBadInput},
+		{name: "unknown", teamName: "missing", code: domain.ErrorCodeNotFound},
+		{name: "already deleted", teamName: "solo", code: domain.ErrorCodeNotFound},
+		{name: "repository fails", teamName: "pair", fail: "Teams.Delete", err: errDB},
 	}
 
-	svc := NewTeamService(teams, users)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			if _, err := store.Teams().Delete(ctx, "solo"); err != nil {
+				t.Fatalf("delete solo: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
 
-	_, err := svc.CreateTeam(context.Background(), "team1", []domain.User{{ID: "1"}})
-	if err == nil || !errors.Is(err, wantErr) {
-		t.Fatalf("expected wrapped error %v, got %v", wantErr, err)
+			team, err := newTeamService(store).DeleteTeam(ctx, tt.teamName)
+			requireErr(t, err, tt.code, tt.err)
+			if err != nil {
+				return
+			}
+
+			if team.Name != tt.teamName || len(team.Members) != 2 {
+				t.Fatalf("unexpected team: %+v", team)
+			}
+			for _, u := range team.Members {
+				if u.IsActive {
+					t.Fatalf("member %s of a deleted team stays active", u.ID)
+				}
+			}
+			_, err = store.Teams().GetByName(ctx, tt.teamName)
+			requireCode(t, err, domain.ErrorCodeNotFound)
+		})
 	}
 }
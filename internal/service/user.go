@@ -9,7 +9,15 @@ import (
 
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, active bool) (*domain.User, error)
-	GetUserReviewPRs(ctx context.Context, userID string) ([]domain.PullRequest, error)
+	// GetUserReviewPRs lists the pull requests userID reviews, followed by
+	// the archived ones if includeArchived is set.
+	GetUserReviewPRs(ctx context.Context, userID string, includeArchived bool) ([]domain.PullRequest, error)
+	// Delete hides the user from teams and review assignment; history and
+	// pull requests keep referring to it.
+	Delete(ctx context.Context, userID string) (*domain.User, error)
+	// Purge deletes the user and replaces its name with
+	// domain.PurgedUserName.
+	Purge(ctx context.Context, userID string) (*domain.User, error)
 }
 
 var _ UserService = (*userService)(nil)
@@ -38,7 +46,34 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, active boo
 
 	return user, nil
 }
-func (s *userService) GetUserReviewPRs(ctx context.Context, userID string) ([]domain.PullRequest, error) {
+
+func (s *userService) Delete(ctx context.Context, userID string) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("delete user: empty user id")
+	}
+
+	user, err := s.users.Delete(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *userService) Purge(ctx context.Context, userID string) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("purge user: empty user id")
+	}
+
+	user, err := s.users.Purge(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("purge user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *userService) GetUserReviewPRs(ctx context.Context, userID string, includeArchived bool) ([]domain.PullRequest, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("get user review PRs: empty user id")
 	}
@@ -52,5 +87,21 @@ func (s *userService) GetUserReviewPRs(ctx context.Context, userID string) ([]do
 		return nil, fmt.Errorf("get user review PRs: %w", err)
 	}
 
-	return prs, nil
+	// Closed pull requests keep their reviewers frozen but no longer need review.
+	active := prs[:0]
+	for _, pr := range prs {
+		if pr.Status != domain.PRStatusClosed {
+			active = append(active, pr)
+		}
+	}
+
+	if includeArchived {
+		archived, err := s.pullReq.ListArchivedByReviewer(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("get user review PRs: %w", err)
+		}
+		active = append(active, archived...)
+	}
+
+	return active, nil
 }
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fakes"
+)
+
+func newUserService(store *fakes.Store) UserService {
+	return NewUserService(store.Users(), store.PRs())
+}
+
+func TestUserService_SetIsActive(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		active bool
+		fail   string
+		code   domain.Code
+		err    error
+	}{
+		{name: "empty id", userID: "", err: errBadInput},
+		{name: "unknown", userID: "nobody", code: domain.ErrorCodeNotFound},
+		{name: "deleted", userID: "u5", code: domain.ErrorCodeNotFound},
+		{name: "deactivate", userID: "u2", active: false},
+		{name: "activate", userID: "u3", active: true},
+		{name: "repository fails", userID: "u2", fail: "Users.SetIsActive", err: errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			if _, err := store.Users().Delete(ctx, "u5"); err != nil {
+				t.Fatalf("delete u5: %v", err)
+			}
+			if _, err := store.Users().SetIsActive(ctx, "u3", false); err != nil {
+				t.Fatalf("deactivate u3: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+
+			user, err := newUserService(store).SetIsActive(ctx, tt.userID, tt.active)
+			requireErr(t, err, tt.code, tt.err)
+			if err != nil {
+				return
+			}
+
+			if user.ID != tt.userID || user.IsActive != tt.active || user.TeamName != "backend" {
+				t.Fatalf("unexpected user: %+v", user)
+			}
+			candidates, err := store.Users().ListReviewCandidates(ctx, "backend", "u1")
+			if err != nil {
+				t.Fatalf("list candidates: %v", err)
+			}
+			isCandidate := slices.ContainsFunc(candidates, func(u domain.User) bool { return u.ID == tt.userID })
+			if isCandidate != tt.active {
+				t.Fatalf("candidate = %v, want %v", isCandidate, tt.active)
+			}
+		})
+	}
+}
+
+func TestUserService_GetUserReviewPRs(t *testing.T) {
+	tests := []struct {
+		name            string
+		userID          string
+		includeArchived bool
+		fail            string
+		code            domain.Code
+		err             error
+		want            []string
+	}{
+		{name: "empty id", userID: "", err: errBadInput},
+		{name: "unknown", userID: "nobody", code: domain.ErrorCodeNotFound},
+		{name: "deleted", userID: "u5", code: domain.ErrorCodeNotFound},
+		{name: "skips closed", userID: "u2", want: []string{"open", "merged"}},
+		{name: "includes archived last", userID: "u2", includeArchived: true, want: []string{"open", "merged", "old"}},
+		{name: "no reviews", userID: "u4", includeArchived: true},
+		{name: "inactive reviewer", userID: "u3", want: []string{"open"}},
+		{name: "get user fails", userID: "u2", fail: "Users.GetUserByID", err: errDB},
+		{name: "list fails", userID: "u2", fail: "PRs.ListByReviewer", err: errDB},
+		{name: "list archived fails", userID: "u2", includeArchived: true, fail: "PRs.ListArchivedByReviewer", err: errDB},
+		{name: "archive not read", userID: "u2", fail: "PRs.ListArchivedByReviewer", want: []string{"open", "merged"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			fixturesFor(store).PR("old", "u1").Status(domain.PRStatusMerged).Reviewers("u2").Create(t)
+			if _, err := store.PRs().Archive(ctx, testEpoch.Add(time.Hour)); err != nil {
+				t.Fatalf("archive: %v", err)
+			}
+			fixturesFor(store).PR("open", "u1").Reviewers("u2", "u3").Create(t)
+			fixturesFor(store).PR("merged", "u1").Status(domain.PRStatusMerged).Reviewers("u2").Create(t)
+			fixturesFor(store).PR("closed", "u1").Status(domain.PRStatusClosed).Reviewers("u2").Create(t)
+			if _, err := store.Users().SetIsActive(ctx, "u3", false); err != nil {
+				t.Fatalf("deactivate u3: %v", err)
+			}
+			if _, err := store.Users().Delete(ctx, "u5"); err != nil {
+				t.Fatalf("delete u5: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+
+			prs, err := newUserService(store).GetUserReviewPRs(ctx, tt.userID, tt.includeArchived)
+			requireErr(t, err, tt.code, tt.err)
+			if err != nil {
+				return
+			}
+
+			var got []string
+			for _, pr := range prs {
+				got = append(got, pr.ID)
+				if archived := pr.ID == "old"; archived == pr.ArchivedAt.IsZero() {
+					t.Fatalf("%s: archived at %v", pr.ID, pr.ArchivedAt)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserService_Delete(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		fail   string
+		code   domain.Code
+		err    error
+	}{
+		{name: "empty id", userID: "", err: errBadInput},
+		{name: "unknown", userID: "nobody", code: domain.ErrorCodeNotFound},
+		{name: "already deleted", userID: "u5", code: domain.ErrorCodeNotFound},
+		{name: "active", userID: "u2"},
+		{name: "inactive", userID: "u3"},
+		{name: "repository fails", userID: "u2", fail: "Users.Delete", err: errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			fixturesFor(store).PR("pr1", "u1").Reviewers("u2", "u3").Create(t)
+			if _, err := store.Users().SetIsActive(ctx, "u3", false); err != nil {
+				t.Fatalf("deactivate u3: %v", err)
+			}
+			if _, err := store.Users().Delete(ctx, "u5"); err != nil {
+				t.Fatalf("delete u5: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+
+			user, err := newUserService(store).Delete(ctx, tt.userID)
+			requireErr(t, err, tt.code, tt.err)
+			if err != nil {
+				return
+			}
+
+			if user.ID != tt.userID || user.IsActive || user.DeletedAt.IsZero() || user.Name != "User "+tt.userID {
+				t.Fatalf("unexpected user: %+v", user)
+			}
+			_, err = store.Users().GetUserByID(ctx, tt.userID)
+			requireCode(t, err, domain.ErrorCodeNotFound)
+			// Pull requests keep referring to deleted reviewers.
+			requireStored(t, store, "pr1", domain.PRStatusOpen, []string{"u2", "u3"})
+		})
+	}
+}
+
+func TestUserService_Purge(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID string
+		fail   string
+		code   domain.Code
+		err    error
+	}{
+		{name: "empty id", userID: "", err: errBadInput},
+		{name: "unknown", userID: "nobody", code: domain.ErrorCodeNotFound},
+		{name: "active", userID: "u2"},
+		{name: "deleted", userID: "u5"},
+		{name: "repository fails", userID: "u2", fail: "Users.Purge", err: errDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := newTestStore(t)
+			if _, err := store.Users().Delete(ctx, "u5"); err != nil {
+				t.Fatalf("delete u5: %v", err)
+			}
+			if tt.fail != "" {
+				store.Fail(tt.fail, errDB)
+			}
+
+			user, err := newUserService(store).Purge(ctx, tt.userID)
+			requireErr(t, err, tt.code, tt.err)
+			if err != nil {
+				return
+			}
+
+			if user.ID != tt.userID || user.Name != domain.PurgedUserName || user.IsActive || user.DeletedAt.IsZero() {
+				t.Fatalf("unexpected user: %+v", user)
+			}
+			_, err = store.Users().GetUserByID(ctx, tt.userID)
+			requireCode(t, err, domain.ErrorCodeNotFound)
+		})
+	}
+}
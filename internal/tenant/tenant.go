@@ -0,0 +1,163 @@
+// Package tenant decides which organization a request acts for. Tenants
+// come from a bearer token mapped to a tenant or from a request header;
+// the resolved tenant is put into the request context, where repositories
+// pick it up.
+package tenant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// Header is the default header naming the tenant of a request.
+const Header = "X-Tenant-ID"
+
+var (
+	ErrRequired = errors.New("tenant is required")
+	ErrUnknown  = errors.New("unknown tenant")
+	ErrMismatch = errors.New("tenant does not match the token")
+)
+
+type Config struct {
+	// Header names the tenant of a request. Empty disables it, so that
+	// only tokens select tenants.
+	Header string
+
+	// Tokens maps bearer tokens to the tenant they act for. A request
+	// with such a token may not name another tenant in Header.
+	Tokens map[string]string
+
+	// Known lists the tenants that may be named in Header, in addition to
+	// those in Tokens and Default.
+	Known []string
+
+	// Default serves requests that name no tenant. Empty rejects them.
+	Default string
+
+	// ExemptPaths are served without a tenant, e.g. health checks.
+	ExemptPaths []string
+}
+
+type Resolver struct {
+	header  string
+	tokens  map[string]string
+	known   map[string]struct{}
+	def     string
+	exempts []string
+}
+
+// New checks that every tenant in cfg has a valid id.
+func New(cfg Config) (*Resolver, error) {
+	r := &Resolver{
+		header:  cfg.Header,
+		tokens:  cfg.Tokens,
+		known:   make(map[string]struct{}),
+		def:     cfg.Default,
+		exempts: cfg.ExemptPaths,
+	}
+
+	ids := slices.Clone(cfg.Known)
+	for _, id := range cfg.Tokens {
+		ids = append(ids, id)
+	}
+	if cfg.Default != "" {
+		ids = append(ids, cfg.Default)
+	}
+	for _, id := range ids {
+		if !domain.ValidTenantID(id) {
+			return nil, fmt.Errorf("invalid tenant id %q", id)
+		}
+		r.known[id] = struct{}{}
+	}
+
+	return r, nil
+}
+
+// Header returns the header naming the tenant, empty if disabled.
+func (r *Resolver) Header() string {
+	return r.header
+}
+
+// Tenants returns every tenant requests can resolve to, ordered by id.
+func (r *Resolver) Tenants() []string {
+	ids := make([]string, 0, len(r.known))
+	for id := range r.known {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Resolve picks the tenant of a request from its bearer token and the
+// value of its tenant header, either of which may be empty. Tokens that
+// are not mapped to a tenant are ignored: they may only identify the
+// client for rate limiting.
+func (r *Resolver) Resolve(token, header string) (string, error) {
+	if r.header == "" {
+		header = ""
+	}
+
+	if id, ok := r.tokens[token]; ok && token != "" {
+		if header != "" && header != id {
+			return "", ErrMismatch
+		}
+		return id, nil
+	}
+
+	if header != "" {
+		if _, ok := r.known[header]; !ok {
+			return "", fmt.Errorf("%w %q", ErrUnknown, header)
+		}
+		return header, nil
+	}
+
+	if r.def == "" {
+		return "", ErrRequired
+	}
+	return r.def, nil
+}
+
+// Middleware scopes the context of every request to its tenant and
+// rejects requests whose tenant cannot be resolved with 400.
+func Middleware(r *Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if slices.Contains(r.exempts, req.URL.Path) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			var header string
+			if r.header != "" {
+				header = req.Header.Get(r.header)
+			}
+
+			id, err := r.Resolve(token, header)
+			if err != nil {
+				writeError(w, err.Error())
+				return
+			}
+
+			next.ServeHTTP(w, req.WithContext(domain.WithTenant(req.Context(), id)))
+		})
+	}
+}
+
+func writeError(w http.ResponseWriter, msg string) {
+	var resp api.ErrorResponse
+	resp.Error.Code = api.BADREQUEST
+	resp.Error.Message = msg
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(resp)
+}
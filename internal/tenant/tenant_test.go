@@ -0,0 +1,141 @@
+package tenant
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/api"
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+func TestResolve(t *testing.T) {
+	r, err := New(Config{
+		Header:  Header,
+		Tokens:  map[string]string{"secret-pay": "payments"},
+		Known:   []string{"search"},
+		Default: domain.DefaultTenant,
+	})
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		token, header string
+		want          string
+		wantErr       error
+	}{
+		{name: "nothing", want: domain.DefaultTenant},
+		{name: "header", header: "search", want: "search"},
+		{name: "token", token: "secret-pay", want: "payments"},
+		{name: "token and same header", token: "secret-pay", header: "payments", want: "payments"},
+		{name: "token and other header", token: "secret-pay", header: "search", wantErr: ErrMismatch},
+		{name: "unmapped token", token: "rate-limit-only", header: "search", want: "search"},
+		{name: "unknown header", header: "marketing", wantErr: ErrUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Resolve(tt.token, tt.header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("tenant = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve_TokensOnly(t *testing.T) {
+	r, err := New(Config{Tokens: map[string]string{"secret-pay": "payments"}})
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+
+	if _, err := r.Resolve("", ""); !errors.Is(err, ErrRequired) {
+		t.Fatalf("err = %v, want %v", err, ErrRequired)
+	}
+	// Without a configured header a named tenant is not trusted.
+	if _, err := r.Resolve("", "payments"); !errors.Is(err, ErrRequired) {
+		t.Fatalf("err = %v, want %v", err, ErrRequired)
+	}
+	if got, _ := r.Resolve("secret-pay", ""); got != "payments" {
+		t.Fatalf("tenant = %q, want payments", got)
+	}
+}
+
+func TestNew_RejectsInvalidIDs(t *testing.T) {
+	for _, cfg := range []Config{
+		{Known: []string{"Payments"}},
+		{Tokens: map[string]string{"t": "pay ments"}},
+		{Default: "-default"},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want an error", cfg)
+		}
+	}
+}
+
+func TestResolver_Tenants(t *testing.T) {
+	r, err := New(Config{
+		Tokens:  map[string]string{"a": "payments", "b": "payments"},
+		Known:   []string{"search"},
+		Default: domain.DefaultTenant,
+	})
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+
+	if got, want := r.Tenants(), []string{"default", "payments", "search"}; !slices.Equal(got, want) {
+		t.Fatalf("tenants = %v, want %v", got, want)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	r, err := New(Config{
+		Header:      Header,
+		Tokens:      map[string]string{"secret-pay": "payments"},
+		ExemptPaths: []string{"/health"},
+	})
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+
+	var got string
+	h := Middleware(r)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = domain.TenantFrom(req.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+	req.Header.Set("Authorization", "Bearer secret-pay")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || got != "payments" {
+		t.Fatalf("status = %d, tenant = %q; want 200 and payments", rec.Code, got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status without tenant = %d, want 400", rec.Code)
+	}
+	var resp api.ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if resp.Error.Code != api.BADREQUEST {
+		t.Fatalf("code = %s, want %s", resp.Error.Code, api.BADREQUEST)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("exempt path status = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,94 @@
+// Package apptest boots the HTTP server on a random port over a fresh
+// database schema from pgtest.
+package apptest
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/app"
+	"github.com/ChernykhITMO/Avito/internal/tenant"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+	"github.com/ChernykhITMO/Avito/internal/testutil/pgtest"
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+const stopTimeout = 5 * time.Second
+
+// App is a running server with its own schema.
+type App struct {
+	URL      string
+	DB       *pgtest.DB
+	Fixtures fixtures.Repos
+}
+
+// Start serves the application until the test ends. It uses the pgx
+// repositories and the default configuration of the service, without the
+// rate limits. Requests act for the default tenant unless they name one of
+// tenants in the X-Tenant-ID header.
+func Start(tb testing.TB, tenants ...string) *App {
+	tb.Helper()
+
+	db := pgtest.NewDB(tb)
+
+	tenantCfg := app.DefaultTenantConfig()
+	tenantCfg.Known = tenants
+	resolver, err := tenant.New(tenantCfg)
+	if err != nil {
+		tb.Fatalf("apptest: tenants: %v", err)
+	}
+
+	cfg := app.DefaultConfig()
+	// Tests send bursts far above the production rate.
+	cfg.Limits = nil
+	cfg.Tenants = resolver
+	cfg.DrainDelay = 0
+
+	repos := app.PgxRepos(db.Pool)
+	a := app.New(repos, db.SQL, cfg)
+	if err := a.Ready(context.Background()); err != nil {
+		tb.Fatalf("apptest: %v", err)
+	}
+	srv := a.HTTPServer("")
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("apptest: listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	// Registered after pgtest's cleanups, so it runs before the schema is
+	// dropped.
+	tb.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil && !errors.Is(err, context.Canceled) {
+				tb.Errorf("apptest: server: %v", err)
+			}
+		case <-time.After(stopTimeout):
+			tb.Errorf("apptest: server did not stop")
+		}
+	})
+
+	return &App{
+		URL: "http://" + ln.Addr().String(),
+		DB:  db,
+		Fixtures: fixtures.Repos{
+			Teams: repos.Teams,
+			Users: repos.Users,
+			PRs:   repos.PRs,
+		},
+	}
+}
+
+// Client returns a client for the server.
+func (a *App) Client(opts ...client.Option) *client.Client {
+	return client.New(a.URL, opts...)
+}
@@ -0,0 +1,190 @@
+package fakes
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+var (
+	_ domain.TenantRepository  = (*tenantRepository)(nil)
+	_ domain.ArchiveRepository = (*archiveRepository)(nil)
+)
+
+type tenantRepository struct {
+	s *Store
+}
+
+// Tenants returns the tenant repository of the store.
+func (s *Store) Tenants() domain.TenantRepository {
+	return &tenantRepository{s: s}
+}
+
+func (r *tenantRepository) Ensure(_ context.Context, tenants []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Tenants.Ensure"); err != nil {
+		return err
+	}
+
+	for _, t := range tenants {
+		if _, ok := r.s.tenants[t]; !ok {
+			r.s.tenants[t] = newTenantData()
+		}
+	}
+	return nil
+}
+
+func (r *tenantRepository) List(context.Context) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Tenants.List"); err != nil {
+		return nil, err
+	}
+
+	tenants := make([]string, 0, len(r.s.tenants))
+	for t := range r.s.tenants {
+		tenants = append(tenants, t)
+	}
+	slices.Sort(tenants)
+	return tenants, nil
+}
+
+type archiveRepository struct {
+	s *Store
+}
+
+// Archives returns the snapshot repository of the store.
+func (s *Store) Archives() domain.ArchiveRepository {
+	return &archiveRepository{s: s}
+}
+
+func (r *archiveRepository) Dump(ctx context.Context) (*domain.Archive, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Archives.Dump"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	var archive domain.Archive
+	for name := range d.teams {
+		if d.liveTeam(name) {
+			archive.Teams = append(archive.Teams, name)
+		}
+	}
+	slices.Sort(archive.Teams)
+
+	// Deleted users stay, as pull requests and history refer to them, but
+	// lose their membership in deleted teams.
+	for _, u := range d.users {
+		user := *u
+		if !d.liveTeam(user.TeamName) {
+			user.TeamName = ""
+		}
+		archive.Users = append(archive.Users, user)
+	}
+	sortUsers(archive.Users)
+
+	for _, p := range d.allPRs() {
+		pr := p.pr
+		pr.Reviewers = p.reviewerIDs()
+		archive.PullRequests = append(archive.PullRequests, pr)
+	}
+	slices.SortFunc(archive.PullRequests, func(a, b domain.PullRequest) int { return cmp.Compare(a.ID, b.ID) })
+
+	for _, a := range d.history {
+		team, _ := d.teamOf(a.reviewerID)
+		archive.Assignments = append(archive.Assignments, domain.AssignmentRecord{
+			PullRequestID: a.prID,
+			ReviewerID:    a.reviewerID,
+			TeamName:      team,
+			AssignedAt:    a.assignedAt,
+			UnassignedAt:  a.unassignedAt,
+		})
+	}
+
+	for _, e := range d.events {
+		e.TeamName, _ = d.teamOf(e.UserID)
+		archive.ActivityEvents = append(archive.ActivityEvents, e)
+	}
+
+	return &archive, nil
+}
+
+// Restore expects a validated archive.
+func (r *archiveRepository) Restore(ctx context.Context, archive *domain.Archive) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Archives.Restore"); err != nil {
+		return err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(d.teams) > 0 || len(d.users) > 0 || len(d.prs) > 0 || len(d.archived) > 0 {
+		return domain.NewError(domain.ErrorCodeStoreNotEmpty, "restore requires an empty store")
+	}
+
+	now := r.s.tick()
+	for _, name := range archive.Teams {
+		d.teams[name] = time.Time{}
+	}
+	for _, u := range archive.Users {
+		user := u
+		d.users[u.ID] = &user
+	}
+
+	// Current reviewers keep the assignment time of their open assignment.
+	openSince := make(map[[2]string]time.Time)
+	for _, a := range archive.Assignments {
+		if a.UnassignedAt.IsZero() {
+			openSince[[2]string{a.PullRequestID, a.ReviewerID}] = a.AssignedAt
+		}
+	}
+
+	for _, pr := range archive.PullRequests {
+		p := &pullRequest{pr: pr}
+		p.pr.Reviewers = nil
+		if p.pr.CreatedAt.IsZero() {
+			p.pr.CreatedAt = now
+		}
+		for _, id := range pr.Reviewers {
+			assignedAt, ok := openSince[[2]string{pr.ID, id}]
+			if !ok {
+				assignedAt = now
+			}
+			p.reviewers = append(p.reviewers, reviewer{id: id, assignedAt: assignedAt})
+		}
+
+		if pr.ArchivedAt.IsZero() {
+			d.prs[pr.ID] = p
+		} else {
+			d.archived[pr.ID] = p
+		}
+	}
+
+	for _, a := range archive.Assignments {
+		d.history = append(d.history, &assignment{
+			prID:         a.PullRequestID,
+			reviewerID:   a.ReviewerID,
+			assignedAt:   a.AssignedAt,
+			unassignedAt: a.UnassignedAt,
+		})
+	}
+	for _, e := range archive.ActivityEvents {
+		e.TeamName = ""
+		d.events = append(d.events, e)
+	}
+
+	return nil
+}
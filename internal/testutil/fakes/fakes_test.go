@@ -0,0 +1,18 @@
+package fakes
+
+import (
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+	"github.com/ChernykhITMO/Avito/internal/testutil/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		s := New()
+		return repotest.Repos{
+			Repos: fixtures.Repos{Teams: s.Teams(), Users: s.Users(), PRs: s.PRs()},
+			Stats: s.Stats(),
+		}
+	})
+}
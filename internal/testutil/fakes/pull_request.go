@@ -0,0 +1,280 @@
+package fakes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+var _ domain.PRRepository = (*prRepository)(nil)
+
+type prRepository struct {
+	s *Store
+}
+
+// PRs returns the pull request repository of the store.
+func (s *Store) PRs() domain.PRRepository {
+	return &prRepository{s: s}
+}
+
+func (r *prRepository) Create(ctx context.Context, req domain.PullRequest) (*domain.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.Create"); err != nil {
+		return nil, err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, live := d.prs[req.ID]
+	_, archived := d.archived[req.ID]
+	if live || archived {
+		return nil, domain.NewError(domain.ErrorCodePRExists, "pull request already exists")
+	}
+	if _, ok := d.users[req.AuthorID]; !ok {
+		return nil, fmt.Errorf("fakes: create pull request %s: author %q does not exist", req.ID, req.AuthorID)
+	}
+	switch req.Status {
+	case domain.PRStatusDraft, domain.PRStatusOpen, domain.PRStatusMerged, domain.PRStatusClosed:
+	default:
+		return nil, fmt.Errorf("fakes: create pull request %s: unknown status %q", req.ID, req.Status)
+	}
+
+	pr := domain.PullRequest{
+		ID:        req.ID,
+		Name:      req.Name,
+		AuthorID:  req.AuthorID,
+		Status:    req.Status,
+		CreatedAt: r.s.tick(),
+	}
+	d.prs[pr.ID] = &pullRequest{pr: pr}
+	return &pr, nil
+}
+
+// Get returns the pull request without its reviewers, as ListReviewers
+// does that.
+func (r *prRepository) Get(ctx context.Context, id string) (*domain.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.Get"); err != nil {
+		return nil, err
+	}
+
+	p, ok := r.s.read(ctx).prs[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+	}
+	pr := p.pr
+	return &pr, nil
+}
+
+func (r *prRepository) Update(ctx context.Context, id string, status domain.PRStatus) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.Update"); err != nil {
+		return err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return err
+	}
+
+	p, ok := d.prs[id]
+	if !ok {
+		return domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+	}
+
+	now := r.s.tick()
+	p.pr.Status = status
+	if status == domain.PRStatusMerged {
+		p.pr.MergedAt = now
+	}
+	return nil
+}
+
+func (r *prRepository) SetReviewers(ctx context.Context, id string, reviewers []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.SetReviewers"); err != nil {
+		return err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return err
+	}
+
+	p, ok := d.prs[id]
+	if !ok {
+		return domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+	}
+	for _, reviewerID := range reviewers {
+		if _, ok := d.users[reviewerID]; !ok {
+			return fmt.Errorf("fakes: set reviewers of %s: user %q does not exist", id, reviewerID)
+		}
+	}
+
+	now := r.s.tick()
+	for _, a := range d.history {
+		if a.prID == id && a.unassignedAt.IsZero() && !slices.Contains(reviewers, a.reviewerID) {
+			a.unassignedAt = now
+		}
+	}
+	p.reviewers = slices.DeleteFunc(p.reviewers, func(rv reviewer) bool {
+		return !slices.Contains(reviewers, rv.id)
+	})
+	for _, reviewerID := range reviewers {
+		if p.hasReviewer(reviewerID) {
+			continue
+		}
+		p.reviewers = append(p.reviewers, reviewer{id: reviewerID, assignedAt: now})
+		d.history = append(d.history, &assignment{prID: id, reviewerID: reviewerID, assignedAt: now})
+	}
+	return nil
+}
+
+func (r *prRepository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.ListByReviewer"); err != nil {
+		return nil, err
+	}
+
+	var prs []domain.PullRequest
+	for _, p := range r.s.read(ctx).prs {
+		if p.hasReviewer(reviewerID) {
+			prs = append(prs, p.pr)
+		}
+	}
+	slices.SortFunc(prs, func(a, b domain.PullRequest) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return prs, nil
+}
+
+func (r *prRepository) ListReviewers(ctx context.Context, prID string) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.ListReviewers"); err != nil {
+		return nil, err
+	}
+
+	p, ok := r.s.read(ctx).prs[prID]
+	if !ok {
+		return nil, nil
+	}
+	return p.reviewerIDs(), nil
+}
+
+func (r *prRepository) ListOverdueReviews(ctx context.Context, assignedBefore time.Time, teamName string) ([]domain.ReviewAssignment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.ListOverdueReviews"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	var reviews []domain.ReviewAssignment
+	for _, p := range d.prs {
+		if p.pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, rv := range p.reviewers {
+			team, ok := d.teamOf(rv.id)
+			if !ok || rv.assignedAt.After(assignedBefore) || (teamName != "" && team != teamName) {
+				continue
+			}
+			reviews = append(reviews, domain.ReviewAssignment{
+				PullRequestID:   p.pr.ID,
+				PullRequestName: p.pr.Name,
+				AuthorID:        p.pr.AuthorID,
+				ReviewerID:      rv.id,
+				TeamName:        team,
+				AssignedAt:      rv.assignedAt,
+			})
+		}
+	}
+	slices.SortFunc(reviews, func(a, b domain.ReviewAssignment) int {
+		return cmp.Or(
+			a.AssignedAt.Compare(b.AssignedAt),
+			cmp.Compare(a.PullRequestID, b.PullRequestID),
+			cmp.Compare(a.ReviewerID, b.ReviewerID),
+		)
+	})
+	return reviews, nil
+}
+
+func (r *prRepository) Archive(ctx context.Context, mergedBefore time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.Archive"); err != nil {
+		return 0, err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := r.s.tick()
+	n := 0
+	for id, p := range d.prs {
+		if p.pr.Status != domain.PRStatusMerged || !p.pr.MergedAt.Before(mergedBefore) {
+			continue
+		}
+		p.pr.ArchivedAt = now
+		d.archived[id] = p
+		delete(d.prs, id)
+		n++
+	}
+	return n, nil
+}
+
+func (r *prRepository) GetArchived(ctx context.Context, id string) (*domain.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.GetArchived"); err != nil {
+		return nil, err
+	}
+
+	p, ok := r.s.read(ctx).archived[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "pull request not found")
+	}
+	pr := p.pr
+	pr.Reviewers = p.reviewerIDs()
+	return &pr, nil
+}
+
+func (r *prRepository) ListArchivedByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequest, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("PRs.ListArchivedByReviewer"); err != nil {
+		return nil, err
+	}
+
+	var prs []domain.PullRequest
+	for _, p := range r.s.read(ctx).archived {
+		if p.hasReviewer(reviewerID) {
+			prs = append(prs, p.pr)
+		}
+	}
+	slices.SortFunc(prs, func(a, b domain.PullRequest) int {
+		return cmp.Or(a.MergedAt.Compare(b.MergedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return prs, nil
+}
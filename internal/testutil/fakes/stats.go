@@ -0,0 +1,230 @@
+package fakes
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+var _ domain.StatsRepository = (*statsRepository)(nil)
+
+type statsRepository struct {
+	s *Store
+}
+
+// Stats returns the statistics repository of the store.
+func (s *Store) Stats() domain.StatsRepository {
+	return &statsRepository{s: s}
+}
+
+// allPRs returns live and archived pull requests.
+func (d *tenantData) allPRs() []*pullRequest {
+	prs := make([]*pullRequest, 0, len(d.prs)+len(d.archived))
+	for _, p := range d.prs {
+		prs = append(prs, p)
+	}
+	for _, p := range d.archived {
+		prs = append(prs, p)
+	}
+	return prs
+}
+
+func (r *statsRepository) GetPRStats(ctx context.Context) (domain.PRStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Stats.GetPRStats"); err != nil {
+		return domain.PRStats{}, err
+	}
+
+	var stats domain.PRStats
+	for _, p := range r.s.read(ctx).allPRs() {
+		stats.Total++
+		switch p.pr.Status {
+		case domain.PRStatusDraft:
+			stats.Draft++
+		case domain.PRStatusOpen:
+			stats.Open++
+		case domain.PRStatusMerged:
+			stats.Merged++
+		case domain.PRStatusClosed:
+			stats.Closed++
+		}
+	}
+	return stats, nil
+}
+
+func (r *statsRepository) GetAssignmentsStats(ctx context.Context) ([]domain.UserAssignmentStat, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Stats.GetAssignmentsStats"); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, p := range r.s.read(ctx).allPRs() {
+		for _, rv := range p.reviewers {
+			counts[rv.id]++
+		}
+	}
+
+	var stats []domain.UserAssignmentStat
+	for id, n := range counts {
+		stats = append(stats, domain.UserAssignmentStat{UserID: id, Count: n})
+	}
+	slices.SortFunc(stats, func(a, b domain.UserAssignmentStat) int { return cmp.Compare(a.UserID, b.UserID) })
+	return stats, nil
+}
+
+// ListPRTimelines returns pull requests created or merged within the range,
+// ordered by creation time and id.
+func (r *statsRepository) ListPRTimelines(ctx context.Context, f domain.StatsFilter) ([]domain.PRTimeline, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Stats.ListPRTimelines"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	var timelines []domain.PRTimeline
+	for _, p := range d.allPRs() {
+		team, ok := d.teamOf(p.pr.AuthorID)
+		if !ok || (f.TeamName != "" && team != f.TeamName) {
+			continue
+		}
+		if !inRange(p.pr.CreatedAt, f) && (p.pr.MergedAt.IsZero() || !inRange(p.pr.MergedAt, f)) {
+			continue
+		}
+		timelines = append(timelines, domain.PRTimeline{
+			ID:        p.pr.ID,
+			TeamName:  team,
+			CreatedAt: p.pr.CreatedAt,
+			MergedAt:  p.pr.MergedAt,
+		})
+	}
+	slices.SortFunc(timelines, func(a, b domain.PRTimeline) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return timelines, nil
+}
+
+func (r *statsRepository) ListAssignments(ctx context.Context, f domain.StatsFilter) ([]domain.AssignmentRecord, error) {
+	var records []domain.AssignmentRecord
+	err := r.EachAssignment(ctx, f, func(a domain.AssignmentRecord) error {
+		records = append(records, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// EachAssignment calls fn after releasing the store, so fn may use the
+// other fakes.
+func (r *statsRepository) EachAssignment(ctx context.Context, f domain.StatsFilter, fn func(domain.AssignmentRecord) error) error {
+	records, err := r.assignments(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range records {
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *statsRepository) assignments(ctx context.Context, f domain.StatsFilter) ([]domain.AssignmentRecord, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Stats.EachAssignment"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	var records []domain.AssignmentRecord
+	for _, a := range d.history {
+		team, ok := d.teamOf(a.reviewerID)
+		if !ok || (f.TeamName != "" && team != f.TeamName) || !inRange(a.assignedAt, f) {
+			continue
+		}
+		records = append(records, domain.AssignmentRecord{
+			PullRequestID: a.prID,
+			ReviewerID:    a.reviewerID,
+			TeamName:      team,
+			AssignedAt:    a.assignedAt,
+			UnassignedAt:  a.unassignedAt,
+		})
+	}
+	// The history is in insertion order, which breaks ties.
+	slices.SortStableFunc(records, func(a, b domain.AssignmentRecord) int {
+		return a.AssignedAt.Compare(b.AssignedAt)
+	})
+	return records, nil
+}
+
+func (r *statsRepository) ListOpenLoad(ctx context.Context, teamName string) ([]domain.ReviewerLoad, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Stats.ListOpenLoad"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	loads := make(map[string]*domain.ReviewerLoad)
+	for _, p := range d.prs {
+		if p.pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, rv := range p.reviewers {
+			team, ok := d.teamOf(rv.id)
+			if !ok || (teamName != "" && team != teamName) {
+				continue
+			}
+			if loads[rv.id] == nil {
+				loads[rv.id] = &domain.ReviewerLoad{UserID: rv.id, TeamName: team}
+			}
+			loads[rv.id].Open++
+		}
+	}
+
+	var result []domain.ReviewerLoad
+	for _, l := range loads {
+		result = append(result, *l)
+	}
+	slices.SortFunc(result, func(a, b domain.ReviewerLoad) int { return cmp.Compare(a.UserID, b.UserID) })
+	return result, nil
+}
+
+func (r *statsRepository) ListActivityEvents(ctx context.Context, teamName string, before time.Time) ([]domain.ActivityEvent, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Stats.ListActivityEvents"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	var events []domain.ActivityEvent
+	for _, e := range d.events {
+		team, ok := d.teamOf(e.UserID)
+		if !ok || (teamName != "" && team != teamName) || !e.ChangedAt.Before(before) {
+			continue
+		}
+		e.TeamName = team
+		events = append(events, e)
+	}
+	slices.SortStableFunc(events, func(a, b domain.ActivityEvent) int {
+		return cmp.Or(cmp.Compare(a.UserID, b.UserID), a.ChangedAt.Compare(b.ChangedAt))
+	})
+	return events, nil
+}
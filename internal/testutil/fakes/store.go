@@ -0,0 +1,231 @@
+// Package fakes holds in-memory implementations of the domain repositories
+// for tests. The fakes of one Store share its state, keep data per tenant
+// and pass the repotest conformance suite, so they behave like the Postgres
+// repositories: same error codes, same ordering, soft deletes, archive and
+// history. They are safe for concurrent use.
+//
+// Time comes from a logical clock that moves a millisecond forward on every
+// write; writes never share a timestamp. Any method can be made to fail:
+//
+//	store := fakes.New()
+//	store.Fail("PRs.SetReviewers", errors.New("connection reset"))
+package fakes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// Store is the state behind the fakes.
+type Store struct {
+	mu      sync.Mutex
+	now     time.Time
+	fails   map[string]error
+	calls   map[string]int
+	tenants map[string]*tenantData
+}
+
+type tenantData struct {
+	// teams maps names to the deletion time, zero for live teams.
+	teams    map[string]time.Time
+	users    map[string]*domain.User
+	prs      map[string]*pullRequest
+	archived map[string]*pullRequest
+	history  []*assignment
+	events   []domain.ActivityEvent
+}
+
+type pullRequest struct {
+	pr        domain.PullRequest
+	reviewers []reviewer
+}
+
+type reviewer struct {
+	id         string
+	assignedAt time.Time
+}
+
+type assignment struct {
+	prID         string
+	reviewerID   string
+	assignedAt   time.Time
+	unassignedAt time.Time
+}
+
+// New returns an empty store with the default tenant. Its clock starts at
+// the current time.
+func New() *Store {
+	return &Store{
+		now:     time.Now().UTC().Truncate(time.Millisecond),
+		fails:   make(map[string]error),
+		calls:   make(map[string]int),
+		tenants: map[string]*tenantData{domain.DefaultTenant: newTenantData()},
+	}
+}
+
+func newTenantData() *tenantData {
+	return &tenantData{
+		teams:    make(map[string]time.Time),
+		users:    make(map[string]*domain.User),
+		prs:      make(map[string]*pullRequest),
+		archived: make(map[string]*pullRequest),
+	}
+}
+
+// Fail makes every call of method, named like "PRs.SetReviewers", return
+// err until Fail is called for it again with a nil error.
+func (s *Store) Fail(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		delete(s.fails, method)
+		return
+	}
+	s.fails[method] = err
+}
+
+// Calls returns how many times method was called, failed calls included.
+func (s *Store) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[method]
+}
+
+// SetNow moves the clock; the next write happens a millisecond after t.
+func (s *Store) SetNow(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = t
+}
+
+// called records a call of method and returns its injected failure. The
+// store must be locked.
+func (s *Store) called(method string) error {
+	s.calls[method]++
+	return s.fails[method]
+}
+
+// tick returns the time of the current write.
+func (s *Store) tick() time.Time {
+	s.now = s.now.Add(time.Millisecond)
+	return s.now
+}
+
+// read returns the data of the tenant in ctx; unknown tenants read as empty.
+func (s *Store) read(ctx context.Context) *tenantData {
+	if d, ok := s.tenants[domain.TenantFrom(ctx)]; ok {
+		return d
+	}
+	return newTenantData()
+}
+
+// write returns the data of the tenant in ctx, which has to exist.
+func (s *Store) write(ctx context.Context) (*tenantData, error) {
+	tenant := domain.TenantFrom(ctx)
+	d, ok := s.tenants[tenant]
+	if !ok {
+		return nil, fmt.Errorf("fakes: unknown tenant %q", tenant)
+	}
+	return d, nil
+}
+
+func (d *tenantData) liveUser(id string) (*domain.User, bool) {
+	u, ok := d.users[id]
+	if !ok || !u.DeletedAt.IsZero() {
+		return nil, false
+	}
+	return u, true
+}
+
+func (d *tenantData) liveTeam(name string) bool {
+	deletedAt, ok := d.teams[name]
+	return ok && deletedAt.IsZero()
+}
+
+// members returns the live users of the team ordered by id.
+func (d *tenantData) members(team string) []domain.User {
+	var users []domain.User
+	for _, u := range d.users {
+		if u.TeamName == team && u.DeletedAt.IsZero() {
+			users = append(users, *u)
+		}
+	}
+	sortUsers(users)
+	return users
+}
+
+// checkTeams fails like the foreign key on users.team_name would.
+func (d *tenantData) checkTeams(users []domain.User, created ...string) error {
+	for _, u := range users {
+		if _, ok := d.teams[u.TeamName]; ok || u.TeamName == "" || slices.Contains(created, u.TeamName) {
+			continue
+		}
+		return fmt.Errorf("fakes: save user %s: team %q does not exist", u.ID, u.TeamName)
+	}
+	return nil
+}
+
+// upsert saves u, bringing a deleted user back.
+func (d *tenantData) upsert(u domain.User, now time.Time) {
+	u.DeletedAt = time.Time{}
+	d.users[u.ID] = &u
+	d.logActivity(u.ID, u.IsActive, now)
+}
+
+// logActivity records a change unless it matches the latest recorded state.
+func (d *tenantData) logActivity(userID string, active bool, now time.Time) {
+	for i := len(d.events) - 1; i >= 0; i-- {
+		if d.events[i].UserID != userID {
+			continue
+		}
+		if d.events[i].IsActive == active {
+			return
+		}
+		break
+	}
+	d.events = append(d.events, domain.ActivityEvent{UserID: userID, IsActive: active, ChangedAt: now})
+}
+
+// teamOf returns the current team of a user, as joins on users do.
+func (d *tenantData) teamOf(userID string) (string, bool) {
+	u, ok := d.users[userID]
+	if !ok {
+		return "", false
+	}
+	return u.TeamName, true
+}
+
+// reviewerIDs returns the reviewers ordered by assignment time and id.
+func (p *pullRequest) reviewerIDs() []string {
+	sorted := slices.Clone(p.reviewers)
+	slices.SortFunc(sorted, func(a, b reviewer) int {
+		return cmp.Or(a.assignedAt.Compare(b.assignedAt), cmp.Compare(a.id, b.id))
+	})
+
+	var ids []string
+	for _, r := range sorted {
+		ids = append(ids, r.id)
+	}
+	return ids
+}
+
+func (p *pullRequest) hasReviewer(id string) bool {
+	return slices.ContainsFunc(p.reviewers, func(r reviewer) bool { return r.id == id })
+}
+
+func sortUsers(users []domain.User) {
+	slices.SortFunc(users, func(a, b domain.User) int { return cmp.Compare(a.ID, b.ID) })
+}
+
+func inRange(t time.Time, f domain.StatsFilter) bool {
+	return (f.From.IsZero() || !t.Before(f.From)) && (f.To.IsZero() || t.Before(f.To))
+}
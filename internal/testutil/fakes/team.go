@@ -0,0 +1,140 @@
+package fakes
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+var _ domain.TeamRepository = (*teamRepository)(nil)
+
+type teamRepository struct {
+	s *Store
+}
+
+// Teams returns the team repository of the store.
+func (s *Store) Teams() domain.TeamRepository {
+	return &teamRepository{s: s}
+}
+
+// Create stores the team only; members are saved through Users.
+func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Teams.Create"); err != nil {
+		return err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return err
+	}
+
+	if d.liveTeam(team.Name) {
+		return domain.NewError(domain.ErrorCodeTeamExists, "team already exists")
+	}
+	d.teams[team.Name] = time.Time{}
+	return nil
+}
+
+func (r *teamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Teams.GetByName"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	if !d.liveTeam(name) {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+	}
+	return &domain.Team{Name: name, Members: d.members(name)}, nil
+}
+
+func (r *teamRepository) List(ctx context.Context) ([]domain.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Teams.List"); err != nil {
+		return nil, err
+	}
+	d := r.s.read(ctx)
+
+	var names []string
+	for name := range d.teams {
+		if d.liveTeam(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var teams []domain.Team
+	for _, name := range names {
+		teams = append(teams, domain.Team{Name: name, Members: d.members(name)})
+	}
+	return teams, nil
+}
+
+func (r *teamRepository) ApplyDiff(ctx context.Context, diff domain.OrgDiff) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Teams.ApplyDiff"); err != nil {
+		return err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return err
+	}
+
+	users := diff.Upserts()
+	if err := d.checkTeams(users, diff.CreatedTeams...); err != nil {
+		return err
+	}
+
+	now := r.s.tick()
+	for _, name := range diff.CreatedTeams {
+		d.teams[name] = time.Time{}
+	}
+	for _, u := range users {
+		d.upsert(u, now)
+	}
+	return nil
+}
+
+func (r *teamRepository) Delete(ctx context.Context, name string) (*domain.Team, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Teams.Delete"); err != nil {
+		return nil, err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.liveTeam(name) {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "team not found")
+	}
+
+	now := r.s.tick()
+	d.teams[name] = now
+
+	team := domain.Team{Name: name}
+	for _, u := range d.users {
+		if u.TeamName != name || !u.DeletedAt.IsZero() {
+			continue
+		}
+		u.IsActive = false
+		u.DeletedAt = now
+		d.logActivity(u.ID, false, now)
+		team.Members = append(team.Members, *u)
+	}
+	sortUsers(team.Members)
+
+	return &team, nil
+}
@@ -0,0 +1,153 @@
+package fakes
+
+import (
+	"context"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+var _ domain.UserRepository = (*userRepository)(nil)
+
+type userRepository struct {
+	s *Store
+}
+
+// Users returns the user repository of the store.
+func (s *Store) Users() domain.UserRepository {
+	return &userRepository{s: s}
+}
+
+// SaveAll saves all users or, if a team is missing, none.
+func (r *userRepository) SaveAll(ctx context.Context, users []domain.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Users.SaveAll"); err != nil {
+		return err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := d.checkTeams(users); err != nil {
+		return err
+	}
+
+	now := r.s.tick()
+	for _, u := range users {
+		d.upsert(u, now)
+	}
+	return nil
+}
+
+func (r *userRepository) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Users.GetUserByID"); err != nil {
+		return nil, err
+	}
+
+	u, ok := r.s.read(ctx).liveUser(id)
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	user := *u
+	return &user, nil
+}
+
+func (r *userRepository) SetIsActive(ctx context.Context, id string, active bool) (*domain.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Users.SetIsActive"); err != nil {
+		return nil, err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := d.liveUser(id)
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+	u.IsActive = active
+	d.logActivity(id, active, r.s.tick())
+
+	user := *u
+	return &user, nil
+}
+
+func (r *userRepository) ListReviewCandidates(ctx context.Context, teamName, excludeUserID string) ([]domain.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Users.ListReviewCandidates"); err != nil {
+		return nil, err
+	}
+
+	var users []domain.User
+	for _, u := range r.s.read(ctx).members(teamName) {
+		if u.IsActive && u.ID != excludeUserID {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id string) (*domain.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Users.Delete"); err != nil {
+		return nil, err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := d.liveUser(id)
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+
+	now := r.s.tick()
+	u.IsActive = false
+	u.DeletedAt = now
+	d.logActivity(id, false, now)
+
+	user := *u
+	return &user, nil
+}
+
+func (r *userRepository) Purge(ctx context.Context, id string) (*domain.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.s.called("Users.Purge"); err != nil {
+		return nil, err
+	}
+	d, err := r.s.write(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := d.users[id]
+	if !ok {
+		return nil, domain.NewError(domain.ErrorCodeNotFound, "user not found")
+	}
+
+	now := r.s.tick()
+	u.Name = domain.PurgedUserName
+	u.IsActive = false
+	if u.DeletedAt.IsZero() {
+		u.DeletedAt = now
+	}
+	d.logActivity(id, false, now)
+
+	user := *u
+	return &user, nil
+}
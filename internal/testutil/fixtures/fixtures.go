@@ -0,0 +1,149 @@
+// Package fixtures builds teams, users and pull requests through the domain
+// repositories, so the same arrangement works for every implementation.
+package fixtures
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// Repos are the repositories fixtures are written through.
+type Repos struct {
+	Teams domain.TeamRepository
+	Users domain.UserRepository
+	PRs   domain.PRRepository
+}
+
+// User returns an active user named after its id.
+func User(id string) domain.User {
+	return domain.User{ID: id, Name: "User " + id, IsActive: true}
+}
+
+// InactiveUser returns an inactive user named after its id.
+func InactiveUser(id string) domain.User {
+	u := User(id)
+	u.IsActive = false
+	return u
+}
+
+type TeamBuilder struct {
+	repos Repos
+	team  domain.Team
+}
+
+// Team starts a team with the given name and no members.
+func (r Repos) Team(name string) *TeamBuilder {
+	return &TeamBuilder{repos: r, team: domain.Team{Name: name}}
+}
+
+// Members adds active users with the given ids.
+func (b *TeamBuilder) Members(ids ...string) *TeamBuilder {
+	for _, id := range ids {
+		b.With(User(id))
+	}
+	return b
+}
+
+// Inactive adds inactive users with the given ids.
+func (b *TeamBuilder) Inactive(ids ...string) *TeamBuilder {
+	for _, id := range ids {
+		b.With(InactiveUser(id))
+	}
+	return b
+}
+
+// With adds users as they are.
+func (b *TeamBuilder) With(users ...domain.User) *TeamBuilder {
+	for _, u := range users {
+		u.TeamName = b.team.Name
+		b.team.Members = append(b.team.Members, u)
+	}
+	return b
+}
+
+// Create stores the team and its members, failing the test on error.
+func (b *TeamBuilder) Create(tb testing.TB) domain.Team {
+	tb.Helper()
+
+	ctx := context.Background()
+	if err := b.repos.Teams.Create(ctx, &b.team); err != nil {
+		tb.Fatalf("fixtures: create team %s: %v", b.team.Name, err)
+	}
+	if len(b.team.Members) > 0 {
+		if err := b.repos.Users.SaveAll(ctx, b.team.Members); err != nil {
+			tb.Fatalf("fixtures: save members of %s: %v", b.team.Name, err)
+		}
+	}
+	return b.team
+}
+
+type PRBuilder struct {
+	repos     Repos
+	pr        domain.PullRequest
+	reviewers []string
+}
+
+// PR starts an open pull request by author without reviewers.
+func (r Repos) PR(id, authorID string) *PRBuilder {
+	return &PRBuilder{
+		repos: r,
+		pr: domain.PullRequest{
+			ID:       id,
+			Name:     "PR " + id,
+			AuthorID: authorID,
+			Status:   domain.PRStatusOpen,
+		},
+	}
+}
+
+func (b *PRBuilder) Name(name string) *PRBuilder {
+	b.pr.Name = name
+	return b
+}
+
+func (b *PRBuilder) Status(status domain.PRStatus) *PRBuilder {
+	b.pr.Status = status
+	return b
+}
+
+func (b *PRBuilder) Reviewers(ids ...string) *PRBuilder {
+	b.reviewers = append(b.reviewers, ids...)
+	return b
+}
+
+// Create stores the pull request and its reviewers, failing the test on
+// error. Statuses other than the initial one are set after creation, so
+// merged pull requests get a merge time.
+func (b *PRBuilder) Create(tb testing.TB) *domain.PullRequest {
+	tb.Helper()
+
+	ctx := context.Background()
+	initial := b.pr
+	if initial.Status != domain.PRStatusDraft {
+		initial.Status = domain.PRStatusOpen
+	}
+
+	pr, err := b.repos.PRs.Create(ctx, initial)
+	if err != nil {
+		tb.Fatalf("fixtures: create pull request %s: %v", b.pr.ID, err)
+	}
+	if len(b.reviewers) > 0 {
+		if err := b.repos.PRs.SetReviewers(ctx, pr.ID, b.reviewers); err != nil {
+			tb.Fatalf("fixtures: set reviewers of %s: %v", pr.ID, err)
+		}
+	}
+	if b.pr.Status != initial.Status {
+		if err := b.repos.PRs.Update(ctx, pr.ID, b.pr.Status); err != nil {
+			tb.Fatalf("fixtures: set status of %s: %v", pr.ID, err)
+		}
+	}
+
+	pr, err = b.repos.PRs.Get(ctx, pr.ID)
+	if err != nil {
+		tb.Fatalf("fixtures: get pull request %s: %v", b.pr.ID, err)
+	}
+	pr.Reviewers = append([]string(nil), b.reviewers...)
+	return pr
+}
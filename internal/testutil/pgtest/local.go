@@ -0,0 +1,111 @@
+package pgtest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// local is the Postgres started by this test binary, if any.
+var local localServer
+
+type localServer struct {
+	once        sync.Once
+	bin         string
+	dir         string
+	dsn         string
+	unavailable string
+	err         error
+}
+
+// start returns the DSN of the local server, starting it on first use.
+// When no server can be started here, unavailable says why.
+func (s *localServer) start() (dsn, unavailable string, err error) {
+	s.once.Do(func() {
+		bin, ok := findBinaries()
+		if !ok {
+			s.unavailable = "no local postgres binaries were found"
+			return
+		}
+		if os.Geteuid() == 0 {
+			s.unavailable = "initdb refuses to run as root"
+			return
+		}
+		s.bin = bin
+		s.dsn, s.err = s.initAndStart()
+	})
+	return s.dsn, s.unavailable, s.err
+}
+
+func (s *localServer) initAndStart() (string, error) {
+	// Unix socket paths are limited to about a hundred bytes, so keep the
+	// directory short.
+	dir, err := os.MkdirTemp("", "pgtest")
+	if err != nil {
+		return "", err
+	}
+	s.dir = dir
+	data := filepath.Join(dir, "data")
+
+	initdb := exec.Command(filepath.Join(s.bin, "initdb"),
+		"-D", data, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--no-sync")
+	if out, err := initdb.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("initdb: %w: %s", err, out)
+	}
+
+	// No TCP listener: the server is reachable only through the socket in dir.
+	opts := fmt.Sprintf("-k %s -c listen_addresses='' -c fsync=off -c synchronous_commit=off -c full_page_writes=off", dir)
+	pgctl := exec.Command(filepath.Join(s.bin, "pg_ctl"),
+		"-D", data, "-o", opts, "-l", filepath.Join(dir, "postgres.log"), "-w", "start")
+	if out, err := pgctl.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("pg_ctl start: %w: %s", err, out)
+	}
+
+	return fmt.Sprintf("host=%s user=postgres dbname=postgres sslmode=disable", dir), nil
+}
+
+func (s *localServer) stop() {
+	if s.dir == "" {
+		return
+	}
+
+	if s.dsn != "" {
+		stop := exec.Command(filepath.Join(s.bin, "pg_ctl"), "-D", filepath.Join(s.dir, "data"), "-m", "immediate", "stop")
+		if out, err := stop.CombinedOutput(); err != nil {
+			fmt.Fprintf(os.Stderr, "pgtest: pg_ctl stop: %v: %s\n", err, out)
+		}
+	}
+	_ = os.RemoveAll(s.dir)
+}
+
+// findBinaries returns the directory holding initdb and pg_ctl.
+func findBinaries() (string, bool) {
+	var candidates []string
+	if dir := os.Getenv("PG_BIN"); dir != "" {
+		candidates = append(candidates, dir)
+	}
+	if path, err := exec.LookPath("pg_ctl"); err == nil {
+		candidates = append(candidates, filepath.Dir(path))
+	}
+	// Debian and Ubuntu keep the server binaries out of PATH.
+	versions, _ := filepath.Glob("/usr/lib/postgresql/*/bin")
+	candidates = append(candidates, versions...)
+	candidates = append(candidates, "/usr/local/pgsql/bin", "/opt/homebrew/bin", "/usr/local/bin")
+
+	for _, dir := range candidates {
+		if isExecutable(filepath.Join(dir, "initdb")) && isExecutable(filepath.Join(dir, "pg_ctl")) {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir() && info.Mode()&0o111 != 0
+}
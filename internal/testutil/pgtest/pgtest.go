@@ -0,0 +1,157 @@
+// Package pgtest gives tests a freshly migrated Postgres schema.
+//
+// Tests use the server at DB_DSN when it is set. Otherwise the package starts
+// a throwaway Postgres from the local binaries (PG_BIN, then PATH, then the
+// usual distribution directories) listening on a unix socket only, shared by
+// all tests of the package. Without either, or as root where initdb refuses
+// to run, the tests are skipped and Run prints the reason once; with
+// PGTEST_REQUIRE=1 they fail instead.
+//
+// Packages using it stop the local server from TestMain:
+//
+//	func TestMain(m *testing.M) { os.Exit(pgtest.Run(m)) }
+package pgtest
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/db/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// DB is a migrated schema of its own, dropped when the test ends. SQL and
+// Pool both have the schema as their search_path.
+type DB struct {
+	Schema string
+	SQL    *sql.DB
+	Pool   *pgxpool.Pool
+}
+
+// skipped is the reason database tests of the package were skipped.
+var skipped struct {
+	once   sync.Once
+	reason string
+}
+
+// Run runs the tests and stops the local server if one was started.
+func Run(m *testing.M) int {
+	code := m.Run()
+	local.stop()
+	if skipped.reason != "" {
+		fmt.Printf("pgtest: database tests skipped: %s; set PGTEST_REQUIRE=1 to fail instead\n", skipped.reason)
+	}
+	return code
+}
+
+// NewDB creates and migrates a new schema.
+func NewDB(tb testing.TB) *DB {
+	tb.Helper()
+
+	dsn := baseDSN(tb)
+	ctx := context.Background()
+
+	admin, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		tb.Fatalf("pgtest: connect: %v", err)
+	}
+	defer func() { _ = admin.Close(ctx) }()
+
+	schema := "test_" + randomSuffix(tb)
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		tb.Fatalf("pgtest: create schema: %v", err)
+	}
+	tb.Cleanup(func() {
+		conn, err := pgx.Connect(ctx, dsn)
+		if err != nil {
+			tb.Logf("pgtest: connect to drop schema: %v", err)
+			return
+		}
+		defer func() { _ = conn.Close(ctx) }()
+		if _, err := conn.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			tb.Logf("pgtest: drop schema: %v", err)
+		}
+	})
+
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		tb.Fatalf("pgtest: parse dsn: %v", err)
+	}
+	poolCfg.ConnConfig.RuntimeParams["search_path"] = schema
+	poolCfg.MaxConns = 8
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		tb.Fatalf("pgtest: open pool: %v", err)
+	}
+	db := stdlib.OpenDBFromPool(pool)
+	// Cleanups run last-in first-out: close the connections before the
+	// schema is dropped.
+	tb.Cleanup(func() {
+		_ = db.Close()
+		pool.Close()
+	})
+
+	if err := migrations.CreateTables(db); err != nil {
+		tb.Fatalf("pgtest: migrate: %v", err)
+	}
+
+	return &DB{Schema: schema, SQL: db, Pool: pool}
+}
+
+func baseDSN(tb testing.TB) string {
+	tb.Helper()
+
+	if dsn := os.Getenv("DB_DSN"); dsn != "" {
+		return dsn
+	}
+
+	dsn, unavailable, err := local.start()
+	if err != nil {
+		tb.Fatalf("pgtest: start local postgres: %v", err)
+	}
+	if unavailable != "" {
+		skip(tb, "DB_DSN is not set and "+unavailable)
+	}
+	return dsn
+}
+
+// skip skips the test for want of a database, or fails it when
+// PGTEST_REQUIRE=1 asks for the database tests to run.
+func skip(tb testing.TB, reason string) {
+	tb.Helper()
+
+	if os.Getenv("PGTEST_REQUIRE") == "1" {
+		tb.Fatalf("pgtest: %s, but PGTEST_REQUIRE=1", reason)
+	}
+	skipped.once.Do(func() { skipped.reason = reason })
+	tb.Skip("pgtest: " + reason)
+}
+
+func randomSuffix(tb testing.TB) string {
+	tb.Helper()
+
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		tb.Fatalf("pgtest: random schema name: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Exec runs statements in the schema, failing the test on error. It is meant
+// for arranging states the repositories cannot produce.
+func (db *DB) Exec(tb testing.TB, query string, args ...any) {
+	tb.Helper()
+
+	if _, err := db.Pool.Exec(context.Background(), query, args...); err != nil {
+		tb.Fatalf("pgtest: %s: %v", query, err)
+	}
+}
@@ -0,0 +1,122 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// writers is how many goroutines race in each case.
+const writers = 8
+
+func runConcurrency(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	// wantOneWinner checks that exactly one call succeeded and the others
+	// failed with code.
+	wantOneWinner := func(t *testing.T, errs []error, code domain.Code) {
+		t.Helper()
+
+		won := 0
+		for _, err := range errs {
+			if err == nil {
+				won++
+				continue
+			}
+			wantCode(t, err, code)
+		}
+		if won != 1 {
+			t.Fatalf("%d calls succeeded, want 1", won)
+		}
+	}
+
+	t.Run("create the same team", func(t *testing.T) {
+		r := newRepos(t)
+
+		errs := concurrently(writers, func(int) error {
+			return r.Teams.Create(ctx, &domain.Team{Name: "backend"})
+		})
+		wantOneWinner(t, errs, domain.ErrorCodeTeamExists)
+	})
+
+	t.Run("create the same pull request", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1").Create(t)
+
+		errs := concurrently(writers, func(i int) error {
+			_, err := r.PRs.Create(ctx, domain.PullRequest{
+				ID: "pr-1", Name: fmt.Sprintf("attempt %d", i), AuthorID: "u1", Status: domain.PRStatusOpen,
+			})
+			return err
+		})
+		wantOneWinner(t, errs, domain.ErrorCodePRExists)
+	})
+
+	t.Run("set reviewers of one pull request", func(t *testing.T) {
+		r := newRepos(t)
+		team := r.Team("backend").Members("u0")
+		for i := range writers {
+			team.Members(fmt.Sprintf("r%d", i))
+		}
+		team.Create(t)
+		r.PR("pr-1", "u0").Create(t)
+
+		sets := make([][]string, writers)
+		for i := range sets {
+			sets[i] = []string{fmt.Sprintf("r%d", i), fmt.Sprintf("r%d", (i+1)%writers)}
+			slices.Sort(sets[i])
+		}
+
+		errs := concurrently(writers, func(i int) error {
+			return r.PRs.SetReviewers(ctx, "pr-1", sets[i])
+		})
+		for _, err := range errs {
+			mustNot(t, err)
+		}
+
+		// The last writer wins whole; the reviewers of others must not
+		// survive next to its own.
+		got, err := r.PRs.ListReviewers(ctx, "pr-1")
+		mustNot(t, err)
+		slices.Sort(got)
+		if !slices.ContainsFunc(sets, func(set []string) bool { return slices.Equal(set, got) }) {
+			t.Fatalf("reviewers = %v, want one of %v", got, sets)
+		}
+	})
+
+	t.Run("save the same users", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Create(t)
+
+		errs := concurrently(writers, func(i int) error {
+			users := make([]domain.User, 4)
+			for j := range users {
+				users[j] = domain.User{
+					ID:       fmt.Sprintf("u%d", j),
+					Name:     fmt.Sprintf("version %d", i),
+					TeamName: "backend",
+					IsActive: true,
+				}
+			}
+			return r.Users.SaveAll(ctx, users)
+		})
+		for _, err := range errs {
+			mustNot(t, err)
+		}
+
+		// SaveAll is atomic, so all users carry the name one writer gave.
+		team, err := r.Teams.GetByName(ctx, "backend")
+		mustNot(t, err)
+		if len(team.Members) != 4 {
+			t.Fatalf("members = %v, want 4", userIDs(team.Members))
+		}
+		for _, m := range team.Members {
+			if m.Name != team.Members[0].Name {
+				t.Fatalf("members saved by different writers: %+v", team.Members)
+			}
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package repotest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+// clockSkew bounds the difference between the test clock and the store's,
+// which sets creation, merge and assignment times.
+const clockSkew = time.Hour
+
+func runPullRequests(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("create and get", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1").Create(t)
+
+		created, err := r.PRs.Create(ctx, domain.PullRequest{
+			ID: "pr-1", Name: "Add search", AuthorID: "u1", Status: domain.PRStatusDraft,
+		})
+		mustNot(t, err)
+		if created.ID != "pr-1" || created.Name != "Add search" || created.AuthorID != "u1" ||
+			created.Status != domain.PRStatusDraft || created.CreatedAt.IsZero() || !created.MergedAt.IsZero() {
+			t.Fatalf("created = %+v", *created)
+		}
+
+		got, err := r.PRs.Get(ctx, "pr-1")
+		mustNot(t, err)
+		if got.ID != created.ID || got.Status != created.Status || !got.CreatedAt.Equal(created.CreatedAt) {
+			t.Fatalf("get = %+v, want %+v", *got, *created)
+		}
+	})
+
+	t.Run("create existing pull request", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1").Create(t)
+		r.PR("pr-1", "u1").Create(t)
+
+		_, err := r.PRs.Create(ctx, domain.PullRequest{ID: "pr-1", Name: "again", AuthorID: "u1", Status: domain.PRStatusOpen})
+		wantCode(t, err, domain.ErrorCodePRExists)
+	})
+
+	t.Run("missing pull request", func(t *testing.T) {
+		r := newRepos(t)
+
+		_, err := r.PRs.Get(ctx, "pr-1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		wantCode(t, r.PRs.Update(ctx, "pr-1", domain.PRStatusMerged), domain.ErrorCodeNotFound)
+		wantCode(t, r.PRs.SetReviewers(ctx, "pr-1", nil), domain.ErrorCodeNotFound)
+		_, err = r.PRs.GetArchived(ctx, "pr-1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+	})
+
+	t.Run("merge sets the merge time", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1").Create(t)
+		r.PR("pr-1", "u1").Create(t)
+
+		mustNot(t, r.PRs.Update(ctx, "pr-1", domain.PRStatusMerged))
+
+		pr, err := r.PRs.Get(ctx, "pr-1")
+		mustNot(t, err)
+		if pr.Status != domain.PRStatusMerged || pr.MergedAt.Before(pr.CreatedAt) {
+			t.Fatalf("merged = %+v", *pr)
+		}
+	})
+
+	t.Run("set reviewers replaces them and keeps assignment order", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2", "u3", "u4").Create(t)
+		r.PR("pr-1", "u1").Reviewers("u4").Create(t)
+
+		steps := []struct {
+			set  []string
+			want []string
+		}{
+			// u4 was assigned first, u2 and u3 together later.
+			{[]string{"u3", "u4", "u2"}, []string{"u4", "u2", "u3"}},
+			{[]string{"u3"}, []string{"u3"}},
+			{nil, nil},
+		}
+		for _, s := range steps {
+			mustNot(t, r.PRs.SetReviewers(ctx, "pr-1", s.set))
+
+			got, err := r.PRs.ListReviewers(ctx, "pr-1")
+			mustNot(t, err)
+			if !slices.Equal(got, s.want) {
+				t.Fatalf("after setting %v reviewers = %v, want %v", s.set, got, s.want)
+			}
+		}
+	})
+
+	t.Run("list by reviewer orders by creation", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2", "u3").Create(t)
+		r.PR("pr-b", "u1").Reviewers("u2").Create(t)
+		r.PR("pr-c", "u1").Reviewers("u3").Create(t)
+		r.PR("pr-a", "u1").Reviewers("u2", "u3").Status(domain.PRStatusMerged).Create(t)
+
+		prs, err := r.PRs.ListByReviewer(ctx, "u2")
+		mustNot(t, err)
+		if got, want := prIDs(prs), []string{"pr-b", "pr-a"}; !slices.Equal(got, want) {
+			t.Fatalf("prs of u2 = %v, want %v", got, want)
+		}
+		if prs[1].Status != domain.PRStatusMerged || prs[1].MergedAt.IsZero() {
+			t.Fatalf("merged pr = %+v", prs[1])
+		}
+
+		prs, err = r.PRs.ListByReviewer(ctx, "u1")
+		mustNot(t, err)
+		if len(prs) != 0 {
+			t.Fatalf("prs of u1 = %v, want none", prIDs(prs))
+		}
+	})
+
+	t.Run("overdue reviews are open ones of the team", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2", "u3").Create(t)
+		r.Team("payments").Members("p1", "p2").Create(t)
+		r.PR("pr-1", "u1").Reviewers("u2").Create(t)
+		r.PR("pr-2", "u1").Reviewers("u3").Status(domain.PRStatusMerged).Create(t)
+		r.PR("pr-3", "p1").Reviewers("p2").Create(t)
+		r.PR("pr-4", "u1").Reviewers("u3").Create(t)
+
+		reviews, err := r.PRs.ListOverdueReviews(ctx, time.Now().Add(clockSkew), "backend")
+		mustNot(t, err)
+
+		var got []string
+		for _, a := range reviews {
+			if a.TeamName != "backend" || a.AuthorID != "u1" || a.AssignedAt.IsZero() {
+				t.Fatalf("review = %+v", a)
+			}
+			got = append(got, a.PullRequestID+"/"+a.ReviewerID)
+		}
+		if want := []string{"pr-1/u2", "pr-4/u3"}; !slices.Equal(got, want) {
+			t.Fatalf("overdue = %v, want %v", got, want)
+		}
+
+		reviews, err = r.PRs.ListOverdueReviews(ctx, time.Now().Add(-clockSkew), "")
+		mustNot(t, err)
+		if len(reviews) != 0 {
+			t.Fatalf("reviews assigned before an hour ago = %+v", reviews)
+		}
+	})
+
+	t.Run("archive moves merged pull requests", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2", "u3").Create(t)
+		r.PR("pr-1", "u1").Reviewers("u2").Status(domain.PRStatusMerged).Create(t)
+		r.PR("pr-2", "u1").Reviewers("u2").Create(t)
+
+		n, err := r.PRs.Archive(ctx, time.Now().Add(-clockSkew))
+		mustNot(t, err)
+		if n != 0 {
+			t.Fatalf("archived %d pull requests merged before an hour ago", n)
+		}
+
+		n, err = r.PRs.Archive(ctx, time.Now().Add(clockSkew))
+		mustNot(t, err)
+		if n != 1 {
+			t.Fatalf("archived %d pull requests, want 1", n)
+		}
+
+		_, err = r.PRs.Get(ctx, "pr-1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.PRs.Create(ctx, domain.PullRequest{ID: "pr-1", Name: "again", AuthorID: "u1", Status: domain.PRStatusOpen})
+		wantCode(t, err, domain.ErrorCodePRExists)
+
+		archived, err := r.PRs.GetArchived(ctx, "pr-1")
+		mustNot(t, err)
+		if archived.Status != domain.PRStatusMerged || archived.ArchivedAt.IsZero() || archived.MergedAt.IsZero() ||
+			!slices.Equal(archived.Reviewers, []string{"u2"}) {
+			t.Fatalf("archived = %+v", *archived)
+		}
+		_, err = r.PRs.GetArchived(ctx, "pr-2")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+
+		live, err := r.PRs.ListByReviewer(ctx, "u2")
+		mustNot(t, err)
+		if got, want := prIDs(live), []string{"pr-2"}; !slices.Equal(got, want) {
+			t.Fatalf("live prs of u2 = %v, want %v", got, want)
+		}
+		old, err := r.PRs.ListArchivedByReviewer(ctx, "u2")
+		mustNot(t, err)
+		if got, want := prIDs(old), []string{"pr-1"}; !slices.Equal(got, want) {
+			t.Fatalf("archived prs of u2 = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("archived pull requests are listed by merge time", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2").Create(t)
+		r.PR("pr-b", "u1").Reviewers("u2").Create(t)
+		r.PR("pr-a", "u1").Reviewers("u2").Status(domain.PRStatusMerged).Create(t)
+		mustNot(t, r.PRs.Update(ctx, "pr-b", domain.PRStatusMerged))
+
+		_, err := r.PRs.Archive(ctx, time.Now().Add(clockSkew))
+		mustNot(t, err)
+
+		prs, err := r.PRs.ListArchivedByReviewer(ctx, "u2")
+		mustNot(t, err)
+		if got, want := prIDs(prs), []string{"pr-a", "pr-b"}; !slices.Equal(got, want) {
+			t.Fatalf("archived prs = %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+// Package repotest is a conformance suite for implementations of the domain
+// repositories: error codes, ordering and concurrent access. Each
+// implementation runs it from its own tests:
+//
+//	repotest.Run(t, func(t *testing.T) repotest.Repos { ... })
+package repotest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+)
+
+// Repos are the repositories under test, all over the same store.
+type Repos struct {
+	fixtures.Repos
+	// Stats may be nil for implementations without statistics; the stats
+	// cases are skipped then.
+	Stats domain.StatsRepository
+}
+
+// Factory returns repositories over a new, empty store in which the default
+// tenant exists. It is called once per case.
+type Factory func(t *testing.T) Repos
+
+// Run runs every case of the suite as a subtest.
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("teams", func(t *testing.T) { runTeams(t, newRepos) })
+	t.Run("users", func(t *testing.T) { runUsers(t, newRepos) })
+	t.Run("pull requests", func(t *testing.T) { runPullRequests(t, newRepos) })
+	t.Run("stats", func(t *testing.T) { runStats(t, newRepos) })
+	t.Run("concurrency", func(t *testing.T) { runConcurrency(t, newRepos) })
+}
+
+func wantCode(t *testing.T, err error, code domain.Code) {
+	t.Helper()
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) || derr.Code != code {
+		t.Fatalf("got error %v, want %s", err, code)
+	}
+}
+
+func mustNot(t *testing.T, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func userIDs(users []domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func prIDs(prs []domain.PullRequest) []string {
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+	return ids
+}
+
+// concurrently calls fn n times at once and returns the error of each call.
+func concurrently(n int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	start := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = fn(i)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
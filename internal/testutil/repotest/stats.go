@@ -0,0 +1,73 @@
+package repotest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+)
+
+func runStats(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	// newStatsRepos skips the case for implementations without statistics.
+	newStatsRepos := func(t *testing.T) Repos {
+		t.Helper()
+
+		r := newRepos(t)
+		if r.Stats == nil {
+			t.Skip("repotest: no stats repository")
+		}
+		return r
+	}
+
+	t.Run("pull request counts include the archive", func(t *testing.T) {
+		r := newStatsRepos(t)
+		r.Team("backend").Members("u1", "u2").Create(t)
+		r.PR("pr-1", "u1").Status(domain.PRStatusDraft).Create(t)
+		r.PR("pr-2", "u1").Create(t)
+		r.PR("pr-3", "u1").Status(domain.PRStatusMerged).Create(t)
+		r.PR("pr-4", "u1").Status(domain.PRStatusMerged).Create(t)
+		_, err := r.PRs.Archive(ctx, time.Now().Add(clockSkew))
+		mustNot(t, err)
+
+		stats, err := r.Stats.GetPRStats(ctx)
+		mustNot(t, err)
+		if want := (domain.PRStats{Total: 4, Draft: 1, Open: 1, Merged: 2}); stats != want {
+			t.Fatalf("stats = %+v, want %+v", stats, want)
+		}
+	})
+
+	t.Run("assignments are counted per reviewer ordered by id", func(t *testing.T) {
+		r := newStatsRepos(t)
+		r.Team("backend").Members("u1", "u2", "u3", "u4").Create(t)
+		r.PR("pr-1", "u1").Reviewers("u3", "u2").Status(domain.PRStatusMerged).Create(t)
+		r.PR("pr-2", "u1").Reviewers("u3").Create(t)
+		r.PR("pr-3", "u2").Reviewers("u4", "u3").Create(t)
+		_, err := r.PRs.Archive(ctx, time.Now().Add(clockSkew))
+		mustNot(t, err)
+
+		stats, err := r.Stats.GetAssignmentsStats(ctx)
+		mustNot(t, err)
+		want := []domain.UserAssignmentStat{
+			{UserID: "u2", Count: 1},
+			{UserID: "u3", Count: 3},
+			{UserID: "u4", Count: 1},
+		}
+		if !slices.Equal(stats, want) {
+			t.Fatalf("assignments = %+v, want %+v", stats, want)
+		}
+	})
+
+	t.Run("no assignments", func(t *testing.T) {
+		r := newStatsRepos(t)
+
+		stats, err := r.Stats.GetAssignmentsStats(ctx)
+		mustNot(t, err)
+		if len(stats) != 0 {
+			t.Fatalf("assignments = %+v, want none", stats)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package repotest
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+)
+
+func runTeams(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("get returns members ordered by id", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u2", "u3").Inactive("u1").Create(t)
+
+		team, err := r.Teams.GetByName(ctx, "backend")
+		mustNot(t, err)
+		if got, want := userIDs(team.Members), []string{"u1", "u2", "u3"}; !slices.Equal(got, want) {
+			t.Fatalf("members = %v, want %v", got, want)
+		}
+		for _, m := range team.Members {
+			if m.TeamName != "backend" || m.Name != "User "+m.ID || m.IsActive != (m.ID != "u1") {
+				t.Fatalf("member = %+v", m)
+			}
+		}
+	})
+
+	t.Run("create existing team", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Create(t)
+
+		wantCode(t, r.Teams.Create(ctx, &domain.Team{Name: "backend"}), domain.ErrorCodeTeamExists)
+	})
+
+	t.Run("get missing team", func(t *testing.T) {
+		r := newRepos(t)
+
+		_, err := r.Teams.GetByName(ctx, "backend")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+	})
+
+	t.Run("list orders teams by name and members by id", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("payments").Members("p2", "p1").Create(t)
+		r.Team("empty").Create(t)
+		r.Team("backend").Members("b1").Create(t)
+
+		teams, err := r.Teams.List(ctx)
+		mustNot(t, err)
+
+		var names []string
+		for _, team := range teams {
+			names = append(names, team.Name)
+		}
+		if want := []string{"backend", "empty", "payments"}; !slices.Equal(names, want) {
+			t.Fatalf("teams = %v, want %v", names, want)
+		}
+		if len(teams[1].Members) != 0 {
+			t.Fatalf("empty team members = %v", userIDs(teams[1].Members))
+		}
+		if got, want := userIDs(teams[2].Members), []string{"p1", "p2"}; !slices.Equal(got, want) {
+			t.Fatalf("payments members = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("apply diff creates teams and moves users", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2").Create(t)
+
+		moved := fixtures.User("u2")
+		moved.TeamName = "payments"
+		created := fixtures.User("u3")
+		created.TeamName = "payments"
+
+		mustNot(t, r.Teams.ApplyDiff(ctx, domain.OrgDiff{
+			CreatedTeams: []string{"payments"},
+			CreatedUsers: []domain.User{created},
+			MovedUsers:   []domain.UserMove{{User: moved, FromTeam: "backend"}},
+		}))
+
+		backend, err := r.Teams.GetByName(ctx, "backend")
+		mustNot(t, err)
+		if got, want := userIDs(backend.Members), []string{"u1"}; !slices.Equal(got, want) {
+			t.Fatalf("backend members = %v, want %v", got, want)
+		}
+		payments, err := r.Teams.GetByName(ctx, "payments")
+		mustNot(t, err)
+		if got, want := userIDs(payments.Members), []string{"u2", "u3"}; !slices.Equal(got, want) {
+			t.Fatalf("payments members = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("delete hides the team and its members", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u2", "u1").Create(t)
+		r.Team("payments").Members("u3").Create(t)
+
+		team, err := r.Teams.Delete(ctx, "backend")
+		mustNot(t, err)
+		if got, want := userIDs(team.Members), []string{"u1", "u2"}; !slices.Equal(got, want) {
+			t.Fatalf("deleted members = %v, want %v", got, want)
+		}
+		for _, m := range team.Members {
+			if m.IsActive || m.DeletedAt.IsZero() {
+				t.Fatalf("deleted member = %+v", m)
+			}
+		}
+
+		_, err = r.Teams.GetByName(ctx, "backend")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.Users.GetUserByID(ctx, "u1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.Teams.Delete(ctx, "backend")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+
+		teams, err := r.Teams.List(ctx)
+		mustNot(t, err)
+		if len(teams) != 1 || teams[0].Name != "payments" {
+			t.Fatalf("teams after delete = %+v", teams)
+		}
+	})
+
+	t.Run("create brings a deleted team back without members", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1").Create(t)
+
+		_, err := r.Teams.Delete(ctx, "backend")
+		mustNot(t, err)
+		mustNot(t, r.Teams.Create(ctx, &domain.Team{Name: "backend"}))
+
+		team, err := r.Teams.GetByName(ctx, "backend")
+		mustNot(t, err)
+		if len(team.Members) != 0 {
+			t.Fatalf("members = %v, want none", userIDs(team.Members))
+		}
+	})
+}
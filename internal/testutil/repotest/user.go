@@ -0,0 +1,152 @@
+package repotest
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/internal/testutil/fixtures"
+)
+
+func runUsers(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("save all inserts and updates", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1").Create(t)
+		r.Team("payments").Create(t)
+
+		mustNot(t, r.Users.SaveAll(ctx, []domain.User{
+			{ID: "u1", Name: "Alice", TeamName: "payments", IsActive: false},
+			{ID: "u2", Name: "Bob", TeamName: "payments", IsActive: true},
+		}))
+
+		u1, err := r.Users.GetUserByID(ctx, "u1")
+		mustNot(t, err)
+		if want := (domain.User{ID: "u1", Name: "Alice", TeamName: "payments"}); *u1 != want {
+			t.Fatalf("u1 = %+v, want %+v", *u1, want)
+		}
+		u2, err := r.Users.GetUserByID(ctx, "u2")
+		mustNot(t, err)
+		if want := (domain.User{ID: "u2", Name: "Bob", TeamName: "payments", IsActive: true}); *u2 != want {
+			t.Fatalf("u2 = %+v, want %+v", *u2, want)
+		}
+	})
+
+	t.Run("user without team", func(t *testing.T) {
+		r := newRepos(t)
+		mustNot(t, r.Users.SaveAll(ctx, []domain.User{fixtures.User("u1")}))
+
+		u, err := r.Users.GetUserByID(ctx, "u1")
+		mustNot(t, err)
+		if u.TeamName != "" {
+			t.Fatalf("team = %q, want none", u.TeamName)
+		}
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		r := newRepos(t)
+
+		_, err := r.Users.GetUserByID(ctx, "u1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.Users.SetIsActive(ctx, "u1", true)
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.Users.Delete(ctx, "u1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.Users.Purge(ctx, "u1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+	})
+
+	t.Run("set is active", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1").Create(t)
+
+		u, err := r.Users.SetIsActive(ctx, "u1", false)
+		mustNot(t, err)
+		if u.IsActive || u.TeamName != "backend" {
+			t.Fatalf("returned user = %+v", *u)
+		}
+
+		u, err = r.Users.GetUserByID(ctx, "u1")
+		mustNot(t, err)
+		if u.IsActive {
+			t.Fatal("user is still active")
+		}
+	})
+
+	t.Run("review candidates are active teammates ordered by id", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u4", "u1", "u3", "u2").Inactive("u5").Create(t)
+		r.Team("payments").Members("p1").Create(t)
+		_, err := r.Users.Delete(ctx, "u4")
+		mustNot(t, err)
+
+		users, err := r.Users.ListReviewCandidates(ctx, "backend", "u1")
+		mustNot(t, err)
+		if got, want := userIDs(users), []string{"u2", "u3"}; !slices.Equal(got, want) {
+			t.Fatalf("candidates = %v, want %v", got, want)
+		}
+		for _, u := range users {
+			if u.TeamName != "backend" || !u.IsActive {
+				t.Fatalf("candidate = %+v", u)
+			}
+		}
+
+		users, err = r.Users.ListReviewCandidates(ctx, "missing", "")
+		mustNot(t, err)
+		if len(users) != 0 {
+			t.Fatalf("candidates of a missing team = %v", userIDs(users))
+		}
+	})
+
+	t.Run("delete hides the user until it is saved again", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2").Create(t)
+
+		u, err := r.Users.Delete(ctx, "u1")
+		mustNot(t, err)
+		if u.IsActive || u.DeletedAt.IsZero() || u.TeamName != "backend" {
+			t.Fatalf("deleted user = %+v", *u)
+		}
+
+		_, err = r.Users.GetUserByID(ctx, "u1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.Users.SetIsActive(ctx, "u1", true)
+		wantCode(t, err, domain.ErrorCodeNotFound)
+		_, err = r.Users.Delete(ctx, "u1")
+		wantCode(t, err, domain.ErrorCodeNotFound)
+
+		team, err := r.Teams.GetByName(ctx, "backend")
+		mustNot(t, err)
+		if got, want := userIDs(team.Members), []string{"u2"}; !slices.Equal(got, want) {
+			t.Fatalf("members = %v, want %v", got, want)
+		}
+
+		back := fixtures.User("u1")
+		back.TeamName = "backend"
+		mustNot(t, r.Users.SaveAll(ctx, []domain.User{back}))
+		u, err = r.Users.GetUserByID(ctx, "u1")
+		mustNot(t, err)
+		if !u.IsActive || !u.DeletedAt.IsZero() {
+			t.Fatalf("saved user = %+v", *u)
+		}
+	})
+
+	t.Run("purge replaces the name of live and deleted users", func(t *testing.T) {
+		r := newRepos(t)
+		r.Team("backend").Members("u1", "u2").Create(t)
+		_, err := r.Users.Delete(ctx, "u2")
+		mustNot(t, err)
+
+		for _, id := range []string{"u1", "u2"} {
+			u, err := r.Users.Purge(ctx, id)
+			mustNot(t, err)
+			if u.Name != domain.PurgedUserName || u.IsActive || u.DeletedAt.IsZero() {
+				t.Fatalf("purged %s = %+v", id, *u)
+			}
+			_, err = r.Users.GetUserByID(ctx, id)
+			wantCode(t, err, domain.ErrorCodeNotFound)
+		}
+	})
+}
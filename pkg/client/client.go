@@ -0,0 +1,424 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Client talks to the reviewer service over HTTP. The zero value is not
+// usable; construct it with New.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	tenant     string
+	token      string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to set a timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTenant sends every request on behalf of tenant in the X-Tenant-ID
+// header.
+func WithTenant(tenant string) Option {
+	return func(c *Client) {
+		c.tenant = tenant
+	}
+}
+
+// WithToken authenticates every request with a bearer token; tokens mapped
+// to a tenant on the server select that tenant.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey makes the mutating calls made with the returned context
+// send key in the Idempotency-Key header, so that retrying them with the
+// same key and arguments repeats the first response instead of the action.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// Error is returned for every non-2xx response. Code and Message come from
+// the ErrorResponse body when the server sent one.
+type Error struct {
+	StatusCode int
+	Code       ErrorResponseErrorCode
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("http %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("http %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (c *Client) Health(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/health", nil, nil, nil)
+}
+
+func (c *Client) AddTeam(ctx context.Context, team Team) (*Team, error) {
+	var resp TeamResponse
+	if err := c.do(ctx, http.MethodPost, "/team/add", nil, team, &resp); err != nil {
+		return nil, fmt.Errorf("add team: %w", err)
+	}
+	return &resp.Team, nil
+}
+
+// DeleteTeam deletes the team and its members and returns them.
+func (c *Client) DeleteTeam(ctx context.Context, teamName string) (*Team, error) {
+	var resp TeamResponse
+	if err := c.do(ctx, http.MethodPost, "/team/delete", nil, TeamNameRequest{TeamName: teamName}, &resp); err != nil {
+		return nil, fmt.Errorf("delete team: %w", err)
+	}
+	return &resp.Team, nil
+}
+
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*Team, error) {
+	var team Team
+	q := url.Values{"team_name": {teamName}}
+	if err := c.do(ctx, http.MethodGet, "/team/get", q, nil, &team); err != nil {
+		return nil, fmt.Errorf("get team: %w", err)
+	}
+	return &team, nil
+}
+
+// ImportTeams synchronises teams and users with the snapshot. With dryRun
+// the server only reports the changes it would make.
+func (c *Client) ImportTeams(ctx context.Context, snapshot OrgSnapshot, dryRun bool) (*ImportResult, error) {
+	var resp ImportResult
+	q := url.Values{"dry_run": {strconv.FormatBool(dryRun)}}
+	if err := c.do(ctx, http.MethodPost, "/team/import", q, snapshot, &resp); err != nil {
+		return nil, fmt.Errorf("import teams: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *Client) ExportTeams(ctx context.Context) (*OrgSnapshot, error) {
+	var snapshot OrgSnapshot
+	q := url.Values{"format": {string(ExportTeamsParamsFormatJson)}}
+	if err := c.do(ctx, http.MethodGet, "/team/export", q, nil, &snapshot); err != nil {
+		return nil, fmt.Errorf("export teams: %w", err)
+	}
+	return &snapshot, nil
+}
+
+func (c *Client) SetIsActive(ctx context.Context, userID string, isActive bool) (*User, error) {
+	var resp UserResponse
+	body := SetIsActiveRequest{UserId: userID, IsActive: isActive}
+	if err := c.do(ctx, http.MethodPost, "/users/setIsActive", nil, body, &resp); err != nil {
+		return nil, fmt.Errorf("set is_active: %w", err)
+	}
+	return &resp.User, nil
+}
+
+func (c *Client) GetUserReviews(ctx context.Context, userID string) ([]PullRequestShort, error) {
+	return c.getUserReviews(ctx, userID, false)
+}
+
+// GetUserReviewsIncludingArchived lists the archived pull requests after
+// the live ones.
+func (c *Client) GetUserReviewsIncludingArchived(ctx context.Context, userID string) ([]PullRequestShort, error) {
+	return c.getUserReviews(ctx, userID, true)
+}
+
+func (c *Client) getUserReviews(ctx context.Context, userID string, includeArchived bool) ([]PullRequestShort, error) {
+	var resp UserReviewsResponse
+	q := url.Values{"user_id": {userID}}
+	if includeArchived {
+		q.Set("include_archived", "true")
+	}
+	if err := c.do(ctx, http.MethodGet, "/users/getReview", q, nil, &resp); err != nil {
+		return nil, fmt.Errorf("get user reviews: %w", err)
+	}
+	return resp.PullRequests, nil
+}
+
+func (c *Client) DeleteUser(ctx context.Context, userID string) (*User, error) {
+	var resp UserResponse
+	if err := c.do(ctx, http.MethodPost, "/users/delete", nil, UserIdRequest{UserId: userID}, &resp); err != nil {
+		return nil, fmt.Errorf("delete user: %w", err)
+	}
+	return &resp.User, nil
+}
+
+// PurgeUser deletes the user and anonymises its name.
+func (c *Client) PurgeUser(ctx context.Context, userID string) (*User, error) {
+	var resp UserResponse
+	if err := c.do(ctx, http.MethodPost, "/admin/users/purge", nil, UserIdRequest{UserId: userID}, &resp); err != nil {
+		return nil, fmt.Errorf("purge user: %w", err)
+	}
+	return &resp.User, nil
+}
+
+func (c *Client) CreatePullRequest(ctx context.Context, req CreatePullRequestRequest) (*PullRequest, error) {
+	var resp PullRequestResponse
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/create", nil, req, &resp); err != nil {
+		return nil, fmt.Errorf("create pull request: %w", err)
+	}
+	return &resp.Pr, nil
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	return c.getPullRequest(ctx, id, false)
+}
+
+// GetPullRequestIncludingArchived also finds pull requests moved to the
+// archive.
+func (c *Client) GetPullRequestIncludingArchived(ctx context.Context, id string) (*PullRequest, error) {
+	return c.getPullRequest(ctx, id, true)
+}
+
+func (c *Client) getPullRequest(ctx context.Context, id string, includeArchived bool) (*PullRequest, error) {
+	var resp PullRequestResponse
+	q := url.Values{"pull_request_id": {id}}
+	if includeArchived {
+		q.Set("include_archived", "true")
+	}
+	if err := c.do(ctx, http.MethodGet, "/pullRequest/get", q, nil, &resp); err != nil {
+		return nil, fmt.Errorf("get pull request: %w", err)
+	}
+	return &resp.Pr, nil
+}
+
+func (c *Client) Merge(ctx context.Context, id string) (*PullRequest, error) {
+	return c.changeStatus(ctx, "/pullRequest/merge", "merge pull request", id)
+}
+
+func (c *Client) MarkReady(ctx context.Context, id string) (*PullRequest, error) {
+	return c.changeStatus(ctx, "/pullRequest/ready", "mark pull request ready", id)
+}
+
+func (c *Client) Close(ctx context.Context, id string) (*PullRequest, error) {
+	return c.changeStatus(ctx, "/pullRequest/close", "close pull request", id)
+}
+
+func (c *Client) Reopen(ctx context.Context, id string) (*PullRequest, error) {
+	return c.changeStatus(ctx, "/pullRequest/reopen", "reopen pull request", id)
+}
+
+func (c *Client) changeStatus(ctx context.Context, path, op, id string) (*PullRequest, error) {
+	var resp PullRequestResponse
+	if err := c.do(ctx, http.MethodPost, path, nil, PullRequestIdRequest{PullRequestId: id}, &resp); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &resp.Pr, nil
+}
+
+func (c *Client) Reassign(ctx context.Context, prID, oldUserID string) (*ReassignResponse, error) {
+	var resp ReassignResponse
+	body := ReassignRequest{PullRequestId: prID, OldUserId: oldUserID}
+	if err := c.do(ctx, http.MethodPost, "/pullRequest/reassign", nil, body, &resp); err != nil {
+		return nil, fmt.Errorf("reassign reviewer: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *Client) OverdueReviews(ctx context.Context, params ListOverdueReviewsParams) ([]ReviewAssignment, error) {
+	var resp OverdueReviewsResponse
+	q := url.Values{}
+	setOptional(q, "older_than", params.OlderThan)
+	setOptional(q, "team_name", params.TeamName)
+	if err := c.do(ctx, http.MethodGet, "/reviews/overdue", q, nil, &resp); err != nil {
+		return nil, fmt.Errorf("list overdue reviews: %w", err)
+	}
+	return resp.Reviews, nil
+}
+
+// Stats always requests the JSON representation; params.Format and
+// params.Table are ignored.
+func (c *Client) Stats(ctx context.Context, params GetStatsParams) (*StatsResponse, error) {
+	var resp StatsResponse
+	q := url.Values{"format": {string(GetStatsParamsFormatJson)}}
+	setOptional(q, "team_name", params.TeamName)
+	setOptional(q, "from", params.From)
+	setOptional(q, "to", params.To)
+	if params.Granularity != nil {
+		q.Set("granularity", string(*params.Granularity))
+	}
+	if err := c.do(ctx, http.MethodGet, "/stats", q, nil, &resp); err != nil {
+		return nil, fmt.Errorf("get stats: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *Client) Fairness(ctx context.Context, params GetFairnessParams) ([]TeamFairness, error) {
+	var resp FairnessResponse
+	q := url.Values{}
+	setOptional(q, "team_name", params.TeamName)
+	setOptional(q, "from", params.From)
+	setOptional(q, "to", params.To)
+	if err := c.do(ctx, http.MethodGet, "/stats/fairness", q, nil, &resp); err != nil {
+		return nil, fmt.Errorf("get fairness: %w", err)
+	}
+	return resp.Teams, nil
+}
+
+// ExportAssignments streams the NDJSON export, calling fn for every record
+// as it arrives. An error returned by fn stops the export.
+func (c *Client) ExportAssignments(ctx context.Context, params ExportAssignmentsParams, fn func(AssignmentRecord) error) error {
+	q := url.Values{}
+	setOptional(q, "team_name", params.TeamName)
+	setOptional(q, "from", params.From)
+	setOptional(q, "to", params.To)
+
+	resp, err := c.send(ctx, http.MethodGet, "/stats/assignments/export", q, nil)
+	if err != nil {
+		return fmt.Errorf("export assignments: %w", err)
+	}
+	defer resp.Body.Close()
+
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var rec AssignmentRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return fmt.Errorf("export assignments: decode record: %w", err)
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("export assignments: %w", err)
+	}
+	return nil
+}
+
+// Snapshot downloads a full archive of the store.
+func (c *Client) Snapshot(ctx context.Context) (*Archive, error) {
+	var archive Archive
+	if err := c.do(ctx, http.MethodGet, "/admin/snapshot", nil, nil, &archive); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	return &archive, nil
+}
+
+// Restore loads an archive into an empty store.
+func (c *Client) Restore(ctx context.Context, archive Archive) (*RestoreResult, error) {
+	var result RestoreResult
+	if err := c.do(ctx, http.MethodPost, "/admin/restore", nil, archive, &result); err != nil {
+		return nil, fmt.Errorf("restore: %w", err)
+	}
+	return &result, nil
+}
+
+// do sends the request and decodes a successful JSON response into out,
+// which may be nil when the body is not needed.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	resp, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// send performs the request and turns non-2xx responses into *Error. On
+// success the caller owns resp.Body.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.tenant != "" {
+		req.Header.Set("X-Tenant-ID", c.tenant)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if key, ok := ctx.Value(idempotencyKeyCtx{}).(string); ok && method == http.MethodPost {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	return nil, decodeError(resp)
+}
+
+func decodeError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	var body ErrorResponse
+	if err := json.Unmarshal(data, &body); err == nil && body.Error.Code != "" {
+		apiErr.Code = body.Error.Code
+		apiErr.Message = body.Error.Message
+		return apiErr
+	}
+
+	apiErr.Message = strings.TrimSpace(string(data))
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
+
+func setOptional(q url.Values, key string, v *string) {
+	if v != nil && *v != "" {
+		q.Set(key, *v)
+	}
+}
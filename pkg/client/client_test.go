@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL + "/")
+}
+
+func TestClient_GetPullRequest(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/pullRequest/get" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("pull_request_id"); got != "pr 1" {
+			t.Errorf("expected pull_request_id=%q, got %q", "pr 1", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"pr":{"pull_request_id":"pr 1","pull_request_name":"Add search","author_id":"u1","status":"OPEN","assigned_reviewers":["u2"]}}`)
+	})
+
+	pr, err := c.GetPullRequest(context.Background(), "pr 1")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if pr.Status != PullRequestStatusOPEN || len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("unexpected pr: %+v", pr)
+	}
+}
+
+func TestClient_GetPullRequestIncludingArchived(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("include_archived"); got != "true" {
+			t.Errorf("expected include_archived=true, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"pr":{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"u1","status":"MERGED",`+
+			`"assigned_reviewers":["u2"],"archivedAt":"2025-10-24T12:00:00Z"}}`)
+	})
+
+	pr, err := c.GetPullRequestIncludingArchived(context.Background(), "pr1")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if pr.ArchivedAt == nil {
+		t.Fatalf("expected archivedAt, got %+v", pr)
+	}
+}
+
+func TestClient_IdempotencyKey(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Idempotency-Key"); got != "retry-1" {
+			t.Errorf("expected Idempotency-Key %q, got %q", "retry-1", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"pr":{"pull_request_id":"pr1","pull_request_name":"Add search","author_id":"u1","status":"OPEN","assigned_reviewers":["u3"]},"replaced_by":"u3"}`)
+	})
+
+	ctx := WithIdempotencyKey(context.Background(), "retry-1")
+	resp, err := c.Reassign(ctx, "pr1", "u2")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if resp.ReplacedBy != "u3" {
+		t.Fatalf("unexpected replaced_by: %q", resp.ReplacedBy)
+	}
+}
+
+func TestClient_Tenant(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant-ID"); got != "payments" {
+			t.Errorf("expected X-Tenant-ID %q, got %q", "payments", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", got)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New(srv.URL, WithTenant("payments"), WithToken("secret"))
+	if err := c.Health(context.Background()); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
+
+func TestClient_ErrorResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected JSON request body")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":{"code":"TEAM_EXISTS","message":"team_name already exists"}}`)
+	})
+
+	_, err := c.AddTeam(context.Background(), Team{TeamName: "backend"})
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Code != TEAMEXISTS {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestClient_NonJSONError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	})
+
+	err := c.Health(context.Background())
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || apiErr.Message != "bad gateway" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_StatsForcesJSON(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("format") != "json" || q.Get("team_name") != "backend" || q.Get("granularity") != "week" || q.Has("from") {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		_, _ = io.WriteString(w, `{"pr_stats":{"total":3,"open":1,"merged":2,"draft":0,"closed":0},"assignments_per_user":[],"teams":[],"global":{"team_name":"","prs_per_period":[],"assignments_per_period":[],"reassignments_per_period":[],"open_load":[],"median_time_to_merge_seconds":null},"from":"2025-10-01T00:00:00Z","to":"2025-10-31T00:00:00Z","granularity":"week"}`)
+	})
+
+	team, csv := "backend", GetStatsParamsFormatCsv
+	week := GetStatsParamsGranularityWeek
+	stats, err := c.Stats(context.Background(), GetStatsParams{TeamName: &team, Granularity: &week, Format: &csv})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if stats.PrStats.Total != 3 {
+		t.Fatalf("unexpected stats: %+v", stats.PrStats)
+	}
+}
+
+func TestClient_ExportAssignments(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, `{"pull_request_id":"pr1","reviewer_id":"u2","team_name":"backend","assigned_at":"2025-10-24T12:00:00Z","unassigned_at":"2025-10-24T13:00:00Z"}
+{"pull_request_id":"pr1","reviewer_id":"u3","team_name":"backend","assigned_at":"2025-10-24T13:00:00Z"}
+`)
+	})
+
+	var got []AssignmentRecord
+	err := c.ExportAssignments(context.Background(), ExportAssignmentsParams{}, func(rec AssignmentRecord) error {
+		got = append(got, rec)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got) != 2 || got[0].UnassignedAt == nil || got[1].UnassignedAt != nil {
+		t.Fatalf("unexpected records: %+v", got)
+	}
+
+	stop := errors.New("stop")
+	err = c.ExportAssignments(context.Background(), ExportAssignmentsParams{}, func(AssignmentRecord) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+}
@@ -0,0 +1,7 @@
+// Package client is a typed Go client for the reviewer service HTTP API.
+// Request and response models are generated from task/openapi.yaml;
+// regenerate them with `go generate ./pkg/client` after changing the spec
+// and do not edit models.gen.go by hand.
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.0 --config=oapi-codegen.yaml ../../task/openapi.yaml
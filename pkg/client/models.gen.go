@@ -0,0 +1,705 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package client
+
+import (
+	"time"
+)
+
+const (
+	BearerAuthScopes   = "BearerAuth.Scopes"
+	TenantHeaderScopes = "TenantHeader.Scopes"
+)
+
+// Defines values for ErrorResponseErrorCode.
+const (
+	BADREQUEST           ErrorResponseErrorCode = "BAD_REQUEST"
+	IDEMPOTENCYKEYREUSED ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INTERNALERROR        ErrorResponseErrorCode = "INTERNAL_ERROR"
+	INVALIDTRANSITION    ErrorResponseErrorCode = "INVALID_TRANSITION"
+	NOCANDIDATE          ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED          ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTFOUND             ErrorResponseErrorCode = "NOT_FOUND"
+	PAYLOADTOOLARGE      ErrorResponseErrorCode = "PAYLOAD_TOO_LARGE"
+	PREXISTS             ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED             ErrorResponseErrorCode = "PR_MERGED"
+	PRNOTOPEN            ErrorResponseErrorCode = "PR_NOT_OPEN"
+	REQUESTINPROGRESS    ErrorResponseErrorCode = "REQUEST_IN_PROGRESS"
+	STORENOTEMPTY        ErrorResponseErrorCode = "STORE_NOT_EMPTY"
+	TEAMEXISTS           ErrorResponseErrorCode = "TEAM_EXISTS"
+	TOOMANYREQUESTS      ErrorResponseErrorCode = "TOO_MANY_REQUESTS"
+)
+
+// Defines values for MemberFairnessOutlier.
+const (
+	Over  MemberFairnessOutlier = "over"
+	Under MemberFairnessOutlier = "under"
+)
+
+// Defines values for PullRequestStatus.
+const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
+	PullRequestStatusMERGED PullRequestStatus = "MERGED"
+	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
+)
+
+// Defines values for PullRequestShortStatus.
+const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
+	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
+	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
+)
+
+// Defines values for ReadinessChecksLifecycle.
+const (
+	Draining ReadinessChecksLifecycle = "draining"
+	Serving  ReadinessChecksLifecycle = "serving"
+)
+
+// Defines values for ReadinessChecksMigrations.
+const (
+	Applied ReadinessChecksMigrations = "applied"
+	Pending ReadinessChecksMigrations = "pending"
+)
+
+// Defines values for ReadinessStatus.
+const (
+	NotReady ReadinessStatus = "not_ready"
+	Ready    ReadinessStatus = "ready"
+)
+
+// Defines values for StatsResponseGranularity.
+const (
+	StatsResponseGranularityDay  StatsResponseGranularity = "day"
+	StatsResponseGranularityWeek StatsResponseGranularity = "week"
+)
+
+// Defines values for GetStatsParamsGranularity.
+const (
+	GetStatsParamsGranularityDay  GetStatsParamsGranularity = "day"
+	GetStatsParamsGranularityWeek GetStatsParamsGranularity = "week"
+)
+
+// Defines values for GetStatsParamsFormat.
+const (
+	GetStatsParamsFormatCsv        GetStatsParamsFormat = "csv"
+	GetStatsParamsFormatJson       GetStatsParamsFormat = "json"
+	GetStatsParamsFormatPrometheus GetStatsParamsFormat = "prometheus"
+)
+
+// Defines values for GetStatsParamsTable.
+const (
+	Assignments GetStatsParamsTable = "assignments"
+	PrStats     GetStatsParamsTable = "pr_stats"
+)
+
+// Defines values for ExportTeamsParamsFormat.
+const (
+	ExportTeamsParamsFormatCsv  ExportTeamsParamsFormat = "csv"
+	ExportTeamsParamsFormatJson ExportTeamsParamsFormat = "json"
+	ExportTeamsParamsFormatYaml ExportTeamsParamsFormat = "yaml"
+)
+
+// ActivityEvent defines model for ActivityEvent.
+type ActivityEvent struct {
+	ChangedAt time.Time `json:"changed_at"`
+	IsActive  bool      `json:"is_active"`
+	UserId    string    `json:"user_id"`
+}
+
+// Archive Полная копия хранилища. Текущие ревьюверы PR — это его назначения без unassigned_at;
+// team_name в назначениях справочный и при восстановлении не используется.
+type Archive struct {
+	ActivityEvents []ActivityEvent    `json:"activity_events"`
+	Assignments    []AssignmentRecord `json:"assignments"`
+	CreatedAt      time.Time          `json:"created_at"`
+	PullRequests   []PullRequest      `json:"pull_requests"`
+	Teams          []string           `json:"teams"`
+	Users          []User             `json:"users"`
+
+	// Version Версия формата архива
+	Version int `json:"version"`
+}
+
+// AssignmentRecord defines model for AssignmentRecord.
+type AssignmentRecord struct {
+	AssignedAt    time.Time  `json:"assigned_at"`
+	PullRequestId string     `json:"pull_request_id"`
+	ReviewerId    string     `json:"reviewer_id"`
+	TeamName      string     `json:"team_name"`
+	UnassignedAt  *time.Time `json:"unassigned_at,omitempty"`
+}
+
+// CacheMetrics defines model for CacheMetrics.
+type CacheMetrics struct {
+	Entries int `json:"entries"`
+
+	// HitRate Доля запросов, обслуженных из кэша (0..1)
+	HitRate       float32 `json:"hit_rate"`
+	Hits          int64   `json:"hits"`
+	Invalidations int64   `json:"invalidations"`
+	Misses        int64   `json:"misses"`
+	Name          string  `json:"name"`
+}
+
+// CacheStats Кэш команд и пользователей перед хранилищем. Записи живут ttl_seconds и сбрасываются
+// при изменениях через этот экземпляр; изменения через другие экземпляры видны не позже ttl_seconds.
+type CacheStats struct {
+	Caches     []CacheMetrics `json:"caches"`
+	Enabled    bool           `json:"enabled"`
+	TtlSeconds float32        `json:"ttl_seconds"`
+}
+
+// CreatePullRequestRequest defines model for CreatePullRequestRequest.
+type CreatePullRequestRequest struct {
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать PR в статусе DRAFT без назначения ревьюверов
+	Draft           *bool  `json:"draft,omitempty"`
+	PullRequestId   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error struct {
+		Code    ErrorResponseErrorCode `json:"code"`
+		Message string                 `json:"message"`
+	} `json:"error"`
+}
+
+// ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
+type ErrorResponseErrorCode string
+
+// FairnessResponse defines model for FairnessResponse.
+type FairnessResponse struct {
+	Teams []TeamFairness `json:"teams"`
+}
+
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	CreatedTeams     []string `json:"created_teams"`
+	CreatedUsers     []User   `json:"created_users"`
+	DeactivatedUsers []User   `json:"deactivated_users"`
+
+	// DryRun Изменения только рассчитаны и не применены
+	DryRun     bool       `json:"dry_run"`
+	MovedUsers []UserMove `json:"moved_users"`
+
+	// UpdatedUsers Изменились username или is_active
+	UpdatedUsers []User `json:"updated_users"`
+}
+
+// MemberFairness defines model for MemberFairness.
+type MemberFairness struct {
+	ActiveDays      float64                `json:"active_days"`
+	ActiveShare     float64                `json:"active_share"`
+	AssignmentShare float64                `json:"assignment_share"`
+	Assignments     int                    `json:"assignments"`
+	Outlier         *MemberFairnessOutlier `json:"outlier,omitempty"`
+
+	// Ratio Доля назначений, делённая на долю активного времени
+	Ratio  *float64 `json:"ratio"`
+	UserId string   `json:"user_id"`
+}
+
+// MemberFairnessOutlier defines model for MemberFairness.Outlier.
+type MemberFairnessOutlier string
+
+// OpenLoadStat defines model for OpenLoadStat.
+type OpenLoadStat struct {
+	Open   int    `json:"open"`
+	UserId string `json:"user_id"`
+}
+
+// OrgSnapshot Полный состав организации; пользователи, которых нет в снимке, деактивируются
+type OrgSnapshot struct {
+	Teams []Team `json:"teams"`
+}
+
+// OverdueReviewsResponse defines model for OverdueReviewsResponse.
+type OverdueReviewsResponse struct {
+	Reviews []ReviewAssignment `json:"reviews"`
+}
+
+// PRStats defines model for PRStats.
+type PRStats struct {
+	Closed int `json:"closed"`
+	Draft  int `json:"draft"`
+	Merged int `json:"merged"`
+	Open   int `json:"open"`
+	Total  int `json:"total"`
+}
+
+// PeriodAssignmentStat defines model for PeriodAssignmentStat.
+type PeriodAssignmentStat struct {
+	Count       int       `json:"count"`
+	PeriodStart time.Time `json:"period_start"`
+	UserId      string    `json:"user_id"`
+}
+
+// PeriodPRStats defines model for PeriodPRStats.
+type PeriodPRStats struct {
+	Merged      int       `json:"merged"`
+	Opened      int       `json:"opened"`
+	PeriodStart time.Time `json:"period_start"`
+}
+
+// PeriodReassignmentStat defines model for PeriodReassignmentStat.
+type PeriodReassignmentStat struct {
+	Assignments   int       `json:"assignments"`
+	PeriodStart   time.Time `json:"period_start"`
+	Rate          float64   `json:"rate"`
+	Reassignments int       `json:"reassignments"`
+}
+
+// PullRequest defines model for PullRequest.
+type PullRequest struct {
+	// ArchivedAt Время переноса PR в архив
+	ArchivedAt *time.Time `json:"archivedAt"`
+
+	// AssignedReviewers user_id назначенных ревьюверов (0..2)
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	AuthorId          string            `json:"author_id"`
+	CreatedAt         *time.Time        `json:"createdAt"`
+	MergedAt          *time.Time        `json:"mergedAt"`
+	PullRequestId     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	Status            PullRequestStatus `json:"status"`
+}
+
+// PullRequestStatus defines model for PullRequest.Status.
+type PullRequestStatus string
+
+// PullRequestIdRequest defines model for PullRequestIdRequest.
+type PullRequestIdRequest struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PullRequestResponse defines model for PullRequestResponse.
+type PullRequestResponse struct {
+	Pr PullRequest `json:"pr"`
+}
+
+// PullRequestShort defines model for PullRequestShort.
+type PullRequestShort struct {
+	// ArchivedAt Время переноса PR в архив
+	ArchivedAt      *time.Time             `json:"archivedAt"`
+	AuthorId        string                 `json:"author_id"`
+	PullRequestId   string                 `json:"pull_request_id"`
+	PullRequestName string                 `json:"pull_request_name"`
+	Status          PullRequestShortStatus `json:"status"`
+}
+
+// PullRequestShortStatus defines model for PullRequestShort.Status.
+type PullRequestShortStatus string
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks struct {
+		// Database ok или текст ошибки подключения к БД
+		Database string `json:"database"`
+
+		// Lifecycle draining — сервис завершает работу и не принимает новый трафик
+		Lifecycle  ReadinessChecksLifecycle  `json:"lifecycle"`
+		Migrations ReadinessChecksMigrations `json:"migrations"`
+	} `json:"checks"`
+	Status ReadinessStatus `json:"status"`
+}
+
+// ReadinessChecksLifecycle draining — сервис завершает работу и не принимает новый трафик
+type ReadinessChecksLifecycle string
+
+// ReadinessChecksMigrations defines model for Readiness.Checks.Migrations.
+type ReadinessChecksMigrations string
+
+// ReadinessStatus defines model for Readiness.Status.
+type ReadinessStatus string
+
+// ReassignRequest defines model for ReassignRequest.
+type ReassignRequest struct {
+	OldUserId     string `json:"old_user_id"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// ReassignResponse defines model for ReassignResponse.
+type ReassignResponse struct {
+	Pr PullRequest `json:"pr"`
+
+	// ReplacedBy user_id нового ревьювера
+	ReplacedBy string `json:"replaced_by"`
+}
+
+// RestoreResult Количество восстановленных записей
+type RestoreResult struct {
+	ActivityEvents int `json:"activity_events"`
+	Assignments    int `json:"assignments"`
+	PullRequests   int `json:"pull_requests"`
+	Teams          int `json:"teams"`
+	Users          int `json:"users"`
+}
+
+// ReviewAssignment defines model for ReviewAssignment.
+type ReviewAssignment struct {
+	AssignedAt      time.Time `json:"assigned_at"`
+	AuthorId        string    `json:"author_id"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	ReviewerId      string    `json:"reviewer_id"`
+	TeamName        string    `json:"team_name"`
+}
+
+// SetIsActiveRequest defines model for SetIsActiveRequest.
+type SetIsActiveRequest struct {
+	IsActive bool   `json:"is_active"`
+	UserId   string `json:"user_id"`
+}
+
+// StatsResponse defines model for StatsResponse.
+type StatsResponse struct {
+	AssignmentsPerUser []UserAssignmentStat     `json:"assignments_per_user"`
+	From               time.Time                `json:"from"`
+	Global             TeamStats                `json:"global"`
+	Granularity        StatsResponseGranularity `json:"granularity"`
+	PrStats            PRStats                  `json:"pr_stats"`
+	Teams              []TeamStats              `json:"teams"`
+	To                 time.Time                `json:"to"`
+}
+
+// StatsResponseGranularity defines model for StatsResponse.Granularity.
+type StatsResponseGranularity string
+
+// Team defines model for Team.
+type Team struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// TeamFairness defines model for TeamFairness.
+type TeamFairness struct {
+	From time.Time `json:"from"`
+
+	// Gini Коэффициент Джини по числу назначений на день активности
+	Gini             float64          `json:"gini"`
+	MaxMinRatio      *float64         `json:"max_min_ratio"`
+	Members          []MemberFairness `json:"members"`
+	TeamName         string           `json:"team_name"`
+	To               time.Time        `json:"to"`
+	TotalAssignments int              `json:"total_assignments"`
+}
+
+// TeamMember defines model for TeamMember.
+type TeamMember struct {
+	IsActive bool   `json:"is_active"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// TeamNameRequest defines model for TeamNameRequest.
+type TeamNameRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+// TeamResponse defines model for TeamResponse.
+type TeamResponse struct {
+	Team Team `json:"team"`
+}
+
+// TeamStats defines model for TeamStats.
+type TeamStats struct {
+	AssignmentsPerPeriod     []PeriodAssignmentStat   `json:"assignments_per_period"`
+	MedianTimeToMergeSeconds *float64                 `json:"median_time_to_merge_seconds"`
+	OpenLoad                 []OpenLoadStat           `json:"open_load"`
+	PrsPerPeriod             []PeriodPRStats          `json:"prs_per_period"`
+	ReassignmentsPerPeriod   []PeriodReassignmentStat `json:"reassignments_per_period"`
+
+	// TeamName Отсутствует для глобальной статистики
+	TeamName *string `json:"team_name,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	// DeletedAt Время удаления пользователя; удалённые пользователи видны только в архиве хранилища
+	DeletedAt *time.Time `json:"deletedAt"`
+	IsActive  bool       `json:"is_active"`
+	TeamName  string     `json:"team_name"`
+	UserId    string     `json:"user_id"`
+	Username  string     `json:"username"`
+}
+
+// UserAssignmentStat defines model for UserAssignmentStat.
+type UserAssignmentStat struct {
+	Count  int    `json:"count"`
+	UserId string `json:"user_id"`
+}
+
+// UserIdRequest defines model for UserIdRequest.
+type UserIdRequest struct {
+	UserId string `json:"user_id"`
+}
+
+// UserMove defines model for UserMove.
+type UserMove struct {
+	FromTeam string `json:"from_team"`
+	IsActive bool   `json:"is_active"`
+	ToTeam   string `json:"to_team"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// UserResponse defines model for UserResponse.
+type UserResponse struct {
+	User User `json:"user"`
+}
+
+// UserReviewsResponse defines model for UserReviewsResponse.
+type UserReviewsResponse struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	UserId       string             `json:"user_id"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IncludeArchived defines model for IncludeArchived.
+type IncludeArchived = bool
+
+// TeamNameQuery defines model for TeamNameQuery.
+type TeamNameQuery = string
+
+// UserIdQuery defines model for UserIdQuery.
+type UserIdQuery = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = ErrorResponse
+
+// IdempotencyKeyReused defines model for IdempotencyKeyReused.
+type IdempotencyKeyReused = ErrorResponse
+
+// PayloadTooLarge defines model for PayloadTooLarge.
+type PayloadTooLarge = ErrorResponse
+
+// RequestInProgress defines model for RequestInProgress.
+type RequestInProgress = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// RestoreSnapshotParams defines parameters for RestoreSnapshot.
+type RestoreSnapshotParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// PurgeUserParams defines parameters for PurgeUser.
+type PurgeUserParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ClosePullRequestParams defines parameters for ClosePullRequest.
+type ClosePullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CreatePullRequestParams defines parameters for CreatePullRequest.
+type CreatePullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPullRequestParams defines parameters for GetPullRequest.
+type GetPullRequestParams struct {
+	PullRequestId string `form:"pull_request_id" json:"pull_request_id"`
+
+	// IncludeArchived Искать также среди архивных (смёрдженных и перенесённых в архив) PR
+	IncludeArchived *IncludeArchived `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
+// MergePullRequestParams defines parameters for MergePullRequest.
+type MergePullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// MarkPullRequestReadyParams defines parameters for MarkPullRequestReady.
+type MarkPullRequestReadyParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ReassignReviewerParams defines parameters for ReassignReviewer.
+type ReassignReviewerParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ReopenPullRequestParams defines parameters for ReopenPullRequest.
+type ReopenPullRequestParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ListOverdueReviewsParams defines parameters for ListOverdueReviews.
+type ListOverdueReviewsParams struct {
+	// OlderThan Минимальное время ожидания (Go duration); по умолчанию SLA сервиса
+	OlderThan *string `form:"older_than,omitempty" json:"older_than,omitempty"`
+
+	// TeamName Фильтр по команде ревьювера
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
+
+// GetStatsParams defines parameters for GetStats.
+type GetStatsParams struct {
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// From Начало периода (RFC 3339 или YYYY-MM-DD); по умолчанию to - 30 дней
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода, не включительно (RFC 3339 или YYYY-MM-DD); по умолчанию текущее время
+	To          *string                    `form:"to,omitempty" json:"to,omitempty"`
+	Granularity *GetStatsParamsGranularity `form:"granularity,omitempty" json:"granularity,omitempty"`
+
+	// Format Формат ответа; имеет приоритет над заголовком Accept
+	Format *GetStatsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Table Таблица для формата csv
+	Table *GetStatsParamsTable `form:"table,omitempty" json:"table,omitempty"`
+}
+
+// GetStatsParamsGranularity defines parameters for GetStats.
+type GetStatsParamsGranularity string
+
+// GetStatsParamsFormat defines parameters for GetStats.
+type GetStatsParamsFormat string
+
+// GetStatsParamsTable defines parameters for GetStats.
+type GetStatsParamsTable string
+
+// ExportAssignmentsParams defines parameters for ExportAssignments.
+type ExportAssignmentsParams struct {
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+	From     *string `form:"from,omitempty" json:"from,omitempty"`
+	To       *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetFairnessParams defines parameters for GetFairness.
+type GetFairnessParams struct {
+	// TeamName Если не указана, отчёт строится по всем командам
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+	From     *string `form:"from,omitempty" json:"from,omitempty"`
+	To       *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// AddTeamParams defines parameters for AddTeam.
+type AddTeamParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteTeamParams defines parameters for DeleteTeam.
+type DeleteTeamParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ExportTeamsParams defines parameters for ExportTeams.
+type ExportTeamsParams struct {
+	// Format Формат ответа; имеет приоритет над заголовком Accept
+	Format *ExportTeamsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportTeamsParamsFormat defines parameters for ExportTeams.
+type ExportTeamsParamsFormat string
+
+// GetTeamParams defines parameters for GetTeam.
+type GetTeamParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// ImportTeamsParams defines parameters for ImportTeams.
+type ImportTeamsParams struct {
+	// DryRun Только рассчитать изменения, не применяя их
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteUserParams defines parameters for DeleteUser.
+type DeleteUserParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetUserReviewsParams defines parameters for GetUserReviews.
+type GetUserReviewsParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// IncludeArchived Искать также среди архивных (смёрдженных и перенесённых в архив) PR
+	IncludeArchived *IncludeArchived `form:"include_archived,omitempty" json:"include_archived,omitempty"`
+}
+
+// SetUserIsActiveParams defines parameters for SetUserIsActive.
+type SetUserIsActiveParams struct {
+	// IdempotencyKey Ключ идемпотентности. Первый ответ на запрос с этим ключом сохраняется на время TTL
+	// и возвращается повторно (с заголовком Idempotent-Replayed: true) на запросы с тем же ключом и телом.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// RestoreSnapshotJSONRequestBody defines body for RestoreSnapshot for application/json ContentType.
+type RestoreSnapshotJSONRequestBody = Archive
+
+// PurgeUserJSONRequestBody defines body for PurgeUser for application/json ContentType.
+type PurgeUserJSONRequestBody = UserIdRequest
+
+// ClosePullRequestJSONRequestBody defines body for ClosePullRequest for application/json ContentType.
+type ClosePullRequestJSONRequestBody = PullRequestIdRequest
+
+// CreatePullRequestJSONRequestBody defines body for CreatePullRequest for application/json ContentType.
+type CreatePullRequestJSONRequestBody = CreatePullRequestRequest
+
+// MergePullRequestJSONRequestBody defines body for MergePullRequest for application/json ContentType.
+type MergePullRequestJSONRequestBody = PullRequestIdRequest
+
+// MarkPullRequestReadyJSONRequestBody defines body for MarkPullRequestReady for application/json ContentType.
+type MarkPullRequestReadyJSONRequestBody = PullRequestIdRequest
+
+// ReassignReviewerJSONRequestBody defines body for ReassignReviewer for application/json ContentType.
+type ReassignReviewerJSONRequestBody = ReassignRequest
+
+// ReopenPullRequestJSONRequestBody defines body for ReopenPullRequest for application/json ContentType.
+type ReopenPullRequestJSONRequestBody = PullRequestIdRequest
+
+// AddTeamJSONRequestBody defines body for AddTeam for application/json ContentType.
+type AddTeamJSONRequestBody = Team
+
+// DeleteTeamJSONRequestBody defines body for DeleteTeam for application/json ContentType.
+type DeleteTeamJSONRequestBody = TeamNameRequest
+
+// ImportTeamsJSONRequestBody defines body for ImportTeams for application/json ContentType.
+type ImportTeamsJSONRequestBody = OrgSnapshot
+
+// DeleteUserJSONRequestBody defines body for DeleteUser for application/json ContentType.
+type DeleteUserJSONRequestBody = UserIdRequest
+
+// SetUserIsActiveJSONRequestBody defines body for SetUserIsActive for application/json ContentType.
+type SetUserIsActiveJSONRequestBody = SetIsActiveRequest
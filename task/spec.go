@@ -0,0 +1,8 @@
+// Package task embeds the API specification so the service can serve the
+// same document the HTTP layer is generated from.
+package task
+
+import _ "embed"
+
+//go:embed openapi.yaml
+var OpenAPI []byte
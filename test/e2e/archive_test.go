@@ -0,0 +1,126 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+func TestE2E_ArchivedPullRequests(t *testing.T) {
+	app, c := newApp(t)
+	ctx := context.Background()
+
+	app.Fixtures.Team("backend").Members("author", "r1").Create(t)
+	app.Fixtures.PR("pr-old", "author").Reviewers("r1").Status(domain.PRStatusMerged).Create(t)
+	app.Fixtures.PR("pr-open", "author").Reviewers("r1").Create(t)
+
+	n, err := app.Fixtures.PRs.Archive(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("archived %d pull requests, want 1", n)
+	}
+
+	reviews, err := c.GetUserReviews(ctx, "r1")
+	if err != nil {
+		t.Fatalf("get reviews: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].PullRequestId != "pr-open" {
+		t.Fatalf("archived pull request still listed: %+v", reviews)
+	}
+
+	reviews, err = c.GetUserReviewsIncludingArchived(ctx, "r1")
+	if err != nil {
+		t.Fatalf("get reviews including archived: %v", err)
+	}
+	if len(reviews) != 2 || reviews[1].PullRequestId != "pr-old" || reviews[1].ArchivedAt == nil {
+		t.Fatalf("unexpected reviews: %+v", reviews)
+	}
+
+	var apiErr *client.Error
+	_, err = c.GetPullRequest(ctx, "pr-old")
+	if !errors.As(err, &apiErr) || apiErr.Code != client.NOTFOUND {
+		t.Fatalf("expected NOT_FOUND without include_archived, got %v", err)
+	}
+
+	pr, err := c.GetPullRequestIncludingArchived(ctx, "pr-old")
+	if err != nil {
+		t.Fatalf("get archived: %v", err)
+	}
+	if pr.ArchivedAt == nil || len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "r1" {
+		t.Fatalf("unexpected archived pull request: %+v", pr)
+	}
+
+	// Archived ids stay taken.
+	_, err = c.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId: "pr-old", PullRequestName: "Again", AuthorId: "author",
+	})
+	if !errors.As(err, &apiErr) || apiErr.Code != client.PREXISTS {
+		t.Fatalf("expected PR_EXISTS for an archived id, got %v", err)
+	}
+}
+
+func TestE2E_DeleteAndPurgeUsers(t *testing.T) {
+	app, c := newApp(t)
+	ctx := context.Background()
+
+	app.Fixtures.Team("backend").Members("author", "r1", "r2").Create(t)
+	app.Fixtures.PR("pr-1", "author").Reviewers("r1").Create(t)
+
+	if _, err := c.DeleteUser(ctx, "r2"); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	team, err := c.GetTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if len(team.Members) != 2 {
+		t.Fatalf("deleted user still a member: %+v", team.Members)
+	}
+
+	// Deleted users are never picked as replacements.
+	_, err = c.Reassign(ctx, "pr-1", "r1")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != client.NOCANDIDATE {
+		t.Fatalf("expected NO_CANDIDATE, got %v", err)
+	}
+
+	purged, err := c.PurgeUser(ctx, "r2")
+	if err != nil {
+		t.Fatalf("purge deleted user: %v", err)
+	}
+	if purged.Username != domain.PurgedUserName || purged.DeletedAt == nil {
+		t.Fatalf("unexpected purged user: %+v", purged)
+	}
+
+	deleted, err := c.DeleteTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("delete team: %v", err)
+	}
+	if len(deleted.Members) != 2 {
+		t.Fatalf("expected the remaining members, got %+v", deleted.Members)
+	}
+	_, err = c.GetTeam(ctx, "backend")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for a deleted team, got %v", err)
+	}
+
+	// The pull request keeps its deleted author.
+	if _, err := c.GetPullRequest(ctx, "pr-1"); err != nil {
+		t.Fatalf("get pull request of a deleted author: %v", err)
+	}
+
+	// A deleted team can be created again.
+	if _, err := c.AddTeam(ctx, client.Team{
+		TeamName: "backend",
+		Members:  []client.TeamMember{{UserId: "author", Username: "Alice", IsActive: true}},
+	}); err != nil {
+		t.Fatalf("recreate team: %v", err)
+	}
+}
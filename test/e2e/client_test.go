@@ -0,0 +1,22 @@
+package e2e
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/testutil/apptest"
+	"github.com/ChernykhITMO/Avito/internal/testutil/pgtest"
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// newApp starts the service over an empty schema of its own.
+func newApp(t *testing.T) (*apptest.App, *client.Client) {
+	t.Helper()
+
+	app := apptest.Start(t)
+	return app, app.Client()
+}
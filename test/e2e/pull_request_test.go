@@ -0,0 +1,71 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/domain"
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+func TestE2E_PullRequestLifecycle(t *testing.T) {
+	app, c := newApp(t)
+	ctx := context.Background()
+
+	app.Fixtures.Team("backend").Members("author", "r1", "r2").Inactive("r3").Create(t)
+
+	pr, err := c.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorId:        "author",
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if pr.Status != client.PullRequestStatusOPEN || len(pr.AssignedReviewers) != 2 {
+		t.Fatalf("unexpected pull request: %+v", pr)
+	}
+	for _, id := range pr.AssignedReviewers {
+		if id != "r1" && id != "r2" {
+			t.Fatalf("unexpected reviewer %s in %v", id, pr.AssignedReviewers)
+		}
+	}
+
+	reviews, err := c.GetUserReviews(ctx, pr.AssignedReviewers[0])
+	if err != nil {
+		t.Fatalf("get reviews: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].PullRequestId != "pr-1" {
+		t.Fatalf("unexpected reviews: %+v", reviews)
+	}
+
+	merged, err := c.Merge(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if merged.Status != client.PullRequestStatusMERGED || merged.MergedAt == nil {
+		t.Fatalf("unexpected merged pull request: %+v", merged)
+	}
+
+	_, err = c.Reassign(ctx, "pr-1", pr.AssignedReviewers[0])
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != client.PRMERGED {
+		t.Fatalf("expected PR_MERGED on reassign after merge, got %v", err)
+	}
+}
+
+func TestE2E_GetPullRequestFromFixtures(t *testing.T) {
+	app, c := newApp(t)
+
+	app.Fixtures.Team("backend").Members("author", "r1").Create(t)
+	app.Fixtures.PR("pr-2", "author").Reviewers("r1").Status(domain.PRStatusMerged).Create(t)
+
+	pr, err := c.GetPullRequest(context.Background(), "pr-2")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if pr.Status != client.PullRequestStatusMERGED || len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "r1" {
+		t.Fatalf("unexpected pull request: %+v", pr)
+	}
+}
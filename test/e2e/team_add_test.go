@@ -1,101 +1,61 @@
 package e2e
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"net/http"
 	"testing"
-)
-
-const url = "http://localhost:8080"
 
-func TestE2E_AddTeam(t *testing.T) {
-	t.Helper()
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
 
-	body := map[string]any{
-		"team_name": "team_e2e_001",
-		"members": []map[string]any{
-			{"user_id": "123", "username": "Alice", "is_active": true},
-			{"user_id": "124", "username": "Bob", "is_active": true},
+func e2eTeam() client.Team {
+	return client.Team{
+		TeamName: "team_e2e_001",
+		Members: []client.TeamMember{
+			{UserId: "123", Username: "Alice", IsActive: true},
+			{UserId: "124", Username: "Bob", IsActive: true},
 		},
 	}
+}
 
-	data, err := json.Marshal(body)
-	if err != nil {
-		t.Fatalf("json marshal: %v", err)
-	}
-
-	req, err := http.NewRequest(http.MethodPost, url+"/team/add", bytes.NewReader(data))
-	if err != nil {
-		t.Fatalf("new request: %v", err)
-	}
+func TestE2E_AddTeam(t *testing.T) {
+	t.Helper()
 
-	req.Header.Set("Content-Type", "application/json")
+	_, c := newApp(t)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	team, err := c.AddTeam(context.Background(), e2eTeam())
 	if err != nil {
-		t.Fatalf("http request: %v", err)
+		t.Fatalf("unexpected err: %v", err)
 	}
 
-	if resp.StatusCode != http.StatusCreated {
-		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	if team.TeamName != "team_e2e_001" || len(team.Members) != 2 {
+		t.Fatalf("unexpected team: %+v", team)
 	}
 }
 
 func TestE2E_AddTeam_AlreadyExists(t *testing.T) {
 	t.Helper()
 
-	body := map[string]any{
-		"team_name": "team_e2e_001",
-		"members": []map[string]any{
-			{"user_id": "123", "username": "Alice", "is_active": true},
-			{"user_id": "124", "username": "Bob", "is_active": true},
-		},
-	}
-
-	data, err := json.Marshal(body)
-	if err != nil {
-		t.Fatalf("json marshal: %v", err)
-	}
+	_, c := newApp(t)
+	ctx := context.Background()
 
-	client := &http.Client{}
-
-	req1, _ := http.NewRequest(http.MethodPost, url+"/team/add", bytes.NewReader(data))
-	req1.Header.Set("Content-Type", "application/json")
-	resp1, err := client.Do(req1)
-
-	if err != nil {
+	if _, err := c.AddTeam(ctx, e2eTeam()); err != nil {
 		t.Fatalf("first request: %v", err)
 	}
 
-	if resp1.StatusCode != http.StatusCreated {
-		t.Fatalf("expected 201 on first creation, got %d", resp1.StatusCode)
-	}
-
-	req2, _ := http.NewRequest(http.MethodPost, url+"/team/add", bytes.NewReader(data))
-	req2.Header.Set("Content-Type", "application/json")
-	resp2, err := client.Do(req2)
-	if err != nil {
-		t.Fatalf("second request: %v", err)
-	}
-
-	if resp2.StatusCode != http.StatusBadRequest {
-		t.Fatalf("expected 400 on duplicate team, got %d", resp2.StatusCode)
-	}
+	_, err := c.AddTeam(ctx, e2eTeam())
 
-	var errResp struct {
-		Error struct {
-			Code    string `json:"code"`
-			Message string `json:"message"`
-		} `json:"error"`
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected API error on duplicate team, got %v", err)
 	}
 
-	if err := json.NewDecoder(resp2.Body).Decode(&errResp); err != nil {
-		t.Fatalf("json decode: %v", err)
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 on duplicate team, got %d", apiErr.StatusCode)
 	}
 
-	if errResp.Error.Code != "TEAM_EXISTS" {
-		t.Fatalf("expected error code TEAM_EXISTS, got %s", errResp.Error.Code)
+	if apiErr.Code != client.TEAMEXISTS {
+		t.Fatalf("expected error code TEAM_EXISTS, got %s", apiErr.Code)
 	}
 }
@@ -1,7 +1,7 @@
 package e2e
 
 import (
-	"net/http"
+	"context"
 	"testing"
 )
 
@@ -10,12 +10,19 @@ func TestE2E_GetTeam(t *testing.T) {
 
 	const teamName = "avito"
 
-	resp, err := http.Get(url + "/team/get?team_name=" + teamName)
+	app, c := newApp(t)
+	app.Fixtures.Team(teamName).Members("u1", "u2").Inactive("u3").Create(t)
+
+	team, err := c.GetTeam(context.Background(), teamName)
 	if err != nil {
-		t.Errorf("get team name: %v", err)
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if team.TeamName != teamName {
+		t.Fatalf("expected team %s, got %s", teamName, team.TeamName)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		t.Fatal("not get team name")
+	if len(team.Members) != 3 {
+		t.Fatalf("expected 3 members, got %+v", team.Members)
 	}
 }
@@ -0,0 +1,68 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ChernykhITMO/Avito/internal/testutil/apptest"
+	"github.com/ChernykhITMO/Avito/pkg/client"
+)
+
+func TestE2E_TenantsAreIsolated(t *testing.T) {
+	ctx := context.Background()
+	app := apptest.Start(t, "acme", "globex")
+	acme := app.Client(client.WithTenant("acme"))
+	globex := app.Client(client.WithTenant("globex"))
+
+	// The same team and user ids live independently in both tenants.
+	for _, c := range []*client.Client{acme, globex} {
+		_, err := c.AddTeam(ctx, client.Team{
+			TeamName: "backend",
+			Members: []client.TeamMember{
+				{UserId: "u1", Username: "Alice", IsActive: true},
+				{UserId: "u2", Username: "Bob", IsActive: true},
+			},
+		})
+		if err != nil {
+			t.Fatalf("add team: %v", err)
+		}
+	}
+
+	if _, err := acme.SetIsActive(ctx, "u2", false); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	team, err := globex.GetTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	for _, m := range team.Members {
+		if !m.IsActive {
+			t.Fatalf("deactivation in acme leaked into globex: %+v", team.Members)
+		}
+	}
+
+	if _, err := acme.CreatePullRequest(ctx, client.CreatePullRequestRequest{
+		PullRequestId: "pr1", PullRequestName: "Add search", AuthorId: "u1",
+	}); err != nil {
+		t.Fatalf("create pull request: %v", err)
+	}
+
+	_, err = globex.GetPullRequest(ctx, "pr1")
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 for another tenant's pull request, got %v", err)
+	}
+
+	// Requests naming no tenant act for the default one, which is empty.
+	_, err = app.Client().GetTeam(ctx, "backend")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 in the default tenant, got %v", err)
+	}
+
+	_, err = app.Client(client.WithTenant("initech")).GetTeam(ctx, "backend")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown tenant, got %v", err)
+	}
+}